@@ -9,6 +9,7 @@ import (
 
 type Config struct {
 	GRPCServer GRPCServer
+	Search     Search
 }
 
 type GRPCServer struct {
@@ -18,6 +19,29 @@ type GRPCServer struct {
 	RetryTimeout time.Duration `env:"GRPC_RETRY_TIMEOUT" envDefault:"3s"`
 }
 
+type Search struct {
+	// bfs or best_first.
+	Mode string `env:"SEARCH_MODE" envDefault:"bfs"`
+
+	// Only for best_first: look for a shorter path after the first one is found.
+	ProveOptimal bool `env:"SEARCH_PROVE_OPTIMAL" envDefault:"false"`
+
+	// Number of paths to find, alternative ones are shown after the shortest path.
+	NumPaths uint32 `env:"SEARCH_NUM_PATHS" envDefault:"1"`
+
+	// Show the sentence containing the link for every hop of the shortest path.
+	Explain bool `env:"SEARCH_EXPLAIN" envDefault:"false"`
+
+	// Only for bfs: avoid the top AvoidHubs hub pages, and choose among the shortest paths
+	// by the hub scores if TieBreak is prefer_hubs or avoid_hubs.
+	AvoidHubs uint32 `env:"SEARCH_AVOID_HUBS" envDefault:"0"`
+	TieBreak  string `env:"SEARCH_TIE_BREAK" envDefault:""`
+
+	// Request only the distance between the pages, limited by MaxDistance if it's not 0.
+	DistanceOnly bool   `env:"SEARCH_DISTANCE_ONLY" envDefault:"false"`
+	MaxDistance  uint32 `env:"SEARCH_MAX_DISTANCE" envDefault:"0"`
+}
+
 func ReadConfig() Config {
 	var conf Config
 	err := env.Parse(&conf)
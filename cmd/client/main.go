@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	zlog "github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 )
 
 func main() {
@@ -33,7 +35,37 @@ func main() {
 	}
 	defer conn.Close()
 
-	go processRequests(ctx, wikigraphpb.NewWikiGraphClient(conn))
+	mode, ok := searchModes[conf.Search.Mode]
+	if !ok {
+		zlog.Fatal().Str("mode", conf.Search.Mode).Msg("unknown search mode")
+	}
+
+	tieBreak, ok := tieBreaks[conf.Search.TieBreak]
+	if !ok {
+		zlog.Fatal().Str("tie_break", conf.Search.TieBreak).Msg("unknown tie break")
+	}
+
+	template := &wikigraphpb.FindShortestPathRequest{
+		// The user is waiting for the result.
+		Priority: wikigraphpb.Priority_INTERACTIVE,
+
+		Mode:         mode,
+		ProveOptimal: conf.Search.ProveOptimal,
+		NumPaths:     conf.Search.NumPaths,
+		Explain:      conf.Search.Explain,
+		AvoidHubs:    conf.Search.AvoidHubs,
+		TieBreak:     tieBreak,
+	}
+
+	var distanceTemplate *wikigraphpb.GetDistanceRequest
+	if conf.Search.DistanceOnly {
+		distanceTemplate = &wikigraphpb.GetDistanceRequest{
+			Priority:    wikigraphpb.Priority_INTERACTIVE,
+			MaxDistance: conf.Search.MaxDistance,
+		}
+	}
+
+	go processRequests(ctx, wikigraphpb.NewWikiGraphClient(conn), template, distanceTemplate)
 
 	<-stop
 	cancel()
@@ -52,7 +84,25 @@ func createConnection(cfg GRPCServer) (*grpc.ClientConn, error) {
 	return conn, err
 }
 
-func processRequests(ctx context.Context, cli wikigraphpb.WikiGraphClient) {
+var searchModes = map[string]wikigraphpb.SearchMode{
+	"bfs":        wikigraphpb.SearchMode_BFS,
+	"best_first": wikigraphpb.SearchMode_BEST_FIRST,
+}
+
+var tieBreaks = map[string]wikigraphpb.TieBreak{
+	"":            wikigraphpb.TieBreak_TIE_BREAK_UNSPECIFIED,
+	"prefer_hubs": wikigraphpb.TieBreak_PREFER_HUBS,
+	"avoid_hubs":  wikigraphpb.TieBreak_AVOID_HUBS,
+}
+
+// processRequests reads pages from stdin and sends requests built from the template.
+// If distanceTemplate is set, only distances are requested.
+func processRequests(
+	ctx context.Context,
+	cli wikigraphpb.WikiGraphClient,
+	template *wikigraphpb.FindShortestPathRequest,
+	distanceTemplate *wikigraphpb.GetDistanceRequest,
+) {
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
@@ -71,35 +121,27 @@ func processRequests(ctx context.Context, cli wikigraphpb.WikiGraphClient) {
 		to, _ := reader.ReadString('\n')
 		to = to[:len(to)-1]
 
-		createTaskResponse, err := cli.FindShortestPath(ctx, &wikigraphpb.FindShortestPathRequest{
-			From: from,
-			To:   to,
-		})
-		if err != nil {
-			fmt.Printf("[!] Failed to create a task: %v\n\n", err)
+		if distanceTemplate != nil {
+			request := proto.Clone(distanceTemplate).(*wikigraphpb.GetDistanceRequest)
+			request.From, request.To = from, to
+
+			findDistance(ctx, cli, request)
 			continue
 		}
 
-		fmt.Printf("\nWaiting for task %s to complete...\n\n", createTaskResponse.GetTaskId().GetId())
-
-		var task *wikigraphpb.Task
-		for {
-			time.Sleep(time.Second)
-
-			resp, err := cli.GetTask(ctx, &wikigraphpb.GetTaskRequest{
-				TaskId: createTaskResponse.GetTaskId(),
-			})
-			if err != nil {
-				fmt.Printf("[!] Failed to get task status: %v\n\n", err)
-				continue
-			}
+		request := proto.Clone(template).(*wikigraphpb.FindShortestPathRequest)
+		request.From, request.To = from, to
 
-			if resp.GetTask().GetStatus() == wikigraphpb.Task_DONE {
-				task = resp.GetTask()
-				break
-			}
+		createTaskResponse, err := cli.FindShortestPath(ctx, request)
+		if err != nil {
+			fmt.Printf("[!] Failed to create a task: %v\n\n", err)
+			continue
+		}
 
-			fmt.Printf("Current status: %s\n", resp.GetTask().GetStatus())
+		task := waitForTask(ctx, cli, createTaskResponse.GetTaskId())
+		if task.GetStatus() == wikigraphpb.Task_FAILED {
+			fmt.Printf("[!] Task %s failed: %s\n\n", task.GetId().GetId(), task.GetError())
+			continue
 		}
 
 		fmt.Printf("Task %s completed\n\n", task.GetId().GetId())
@@ -109,11 +151,143 @@ func processRequests(ctx context.Context, cli wikigraphpb.WikiGraphClient) {
 			fmt.Printf("%s\n", url)
 		}
 
+		if explanations := task.GetExplanations(); len(explanations) != 0 {
+			fmt.Printf("\nWhy the pages link to each other:\n")
+			for _, explanation := range explanations {
+				fmt.Printf("%s -> %s\n", explanation.GetFrom(), explanation.GetTo())
+
+				switch {
+				case explanation.GetSentence() == "":
+					fmt.Printf("    the link is not in the text, it probably comes from a template\n")
+
+				case explanation.GetSection() != "":
+					fmt.Printf("    (%s) %s\n", explanation.GetSection(), explanation.GetSentence())
+
+				default:
+					fmt.Printf("    %s\n", explanation.GetSentence())
+				}
+			}
+		}
+
+		if alternatives := task.GetPaths(); len(alternatives) > 1 {
+			fmt.Printf("\nAlternative paths:\n")
+			for i, path := range alternatives[1:] {
+				fmt.Printf("%d. %s\n", i+1, strings.Join(path.GetPages(), " -> "))
+			}
+		}
+
 		if len(task.GetPath()) == 0 {
 			fmt.Printf("Unfortunately, the path was not found. Probably, the path is too long or you have typos in the provided page titles.\nTry Apple and Fruits as an example.")
 		}
 
+		if task.GetHeuristic() {
+			fmt.Printf("\nThe path was found by the best-first search and might be not the shortest one\n")
+		}
+
+		if !task.GetComplete() {
+			fmt.Printf("\nThe result may be incomplete: %d pages could not be fetched\n", len(task.GetFailedPages()))
+		}
+
+		if stats := task.GetStats(); stats != nil {
+			fmt.Printf("\nFetched %d pages with %d API requests in %s\n",
+				stats.GetPagesFetched(), stats.GetApiRequests(), stats.GetElapsed().AsDuration())
+		}
+
 		fmt.Println()
 		fmt.Println()
 	}
 }
+
+// findDistance requests the distance and waits for the task unless the distance is cached.
+func findDistance(ctx context.Context, cli wikigraphpb.WikiGraphClient, request *wikigraphpb.GetDistanceRequest) {
+	resp, err := cli.GetDistance(ctx, request)
+	if err != nil {
+		fmt.Printf("[!] Failed to get the distance: %v\n\n", err)
+		return
+	}
+
+	result := resp.GetResult()
+	if result == nil {
+		task := waitForTask(ctx, cli, resp.GetTaskId())
+		if task.GetStatus() == wikigraphpb.Task_FAILED {
+			fmt.Printf("[!] Task %s failed: %s\n\n", task.GetId().GetId(), task.GetError())
+			return
+		}
+
+		result = task.GetDistanceResult()
+		if !task.GetComplete() {
+			fmt.Printf("The result may be incomplete: %d pages could not be fetched\n", len(task.GetFailedPages()))
+		}
+	}
+
+	switch {
+	case result.GetReachable():
+		fmt.Printf("The pages are %d clicks apart\n", result.GetDistance())
+
+	default:
+		fmt.Printf("The page cannot be reached within %d clicks\n", result.GetSearchedDistance())
+	}
+
+	if result.GetCached() {
+		fmt.Printf("The distance was taken from the cache\n")
+	}
+
+	fmt.Println()
+}
+
+// waitForTask polls the task showing its progress until it's DONE or FAILED.
+func waitForTask(ctx context.Context, cli wikigraphpb.WikiGraphClient, id *wikigraphpb.TaskId) *wikigraphpb.Task {
+	fmt.Printf("\nWaiting for task %s to complete...\n\n", id.GetId())
+
+	var preliminaryShown bool
+	for {
+		time.Sleep(time.Second)
+
+		resp, err := cli.GetTask(ctx, &wikigraphpb.GetTaskRequest{
+			TaskId: id,
+		})
+		if err != nil {
+			fmt.Printf("[!] Failed to get task status: %v\n\n", err)
+			continue
+		}
+
+		status := resp.GetTask().GetStatus()
+		if status == wikigraphpb.Task_DONE || status == wikigraphpb.Task_FAILED {
+			// Finish the progress bar line.
+			fmt.Println()
+
+			return resp.GetTask()
+		}
+
+		if resp.GetTask().GetHeuristic() && !preliminaryShown {
+			preliminaryShown = true
+			fmt.Printf("\rA path has been found, looking for a shorter one:\n%s\n\n", strings.Join(resp.GetTask().GetPath(), "\n"))
+		}
+
+		if progress := resp.GetTask().GetProgress(); progress != nil {
+			fmt.Printf("\r%s", formatProgress(progress))
+			continue
+		}
+
+		fmt.Printf("Current status: %s\n", resp.GetTask().GetStatus())
+	}
+}
+
+const progressBarWidth = 30
+
+// formatProgress renders the progress as a bar followed by the current distance and fetched pages.
+func formatProgress(progress *wikigraphpb.TaskProgress) string {
+	fetched := progress.GetPagesFetched()
+	total := fetched + progress.GetEstimatedRemainingFetches()
+
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(fetched) / float64(total)
+	}
+
+	filled := int(ratio * progressBarWidth)
+
+	return fmt.Sprintf("[%s%s] %3.0f%% distance %d/%d, fetched %d pages (~%d left)    ",
+		strings.Repeat("#", filled), strings.Repeat(".", progressBarWidth-filled), ratio*100,
+		progress.GetDistance(), progress.GetMaxDistance(), fetched, progress.GetEstimatedRemainingFetches())
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/caarlos0/env/v6"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	Dumps Dumps
+
+	// The imported graph is saved to these files, at least one of them must be set.
+	StorePath string `env:"GRAPH_STORE_PATH"`
+	CSRPath   string `env:"GRAPH_CSR_PATH"`
+}
+
+type Dumps struct {
+	PagePath       string `env:"DUMP_PAGE_PATH,required"`
+	RedirectPath   string `env:"DUMP_REDIRECT_PATH,required"`
+	LinkTargetPath string `env:"DUMP_LINKTARGET_PATH"`
+	PageLinksPath  string `env:"DUMP_PAGELINKS_PATH,required"`
+}
+
+func ReadConfig() Config {
+	var conf Config
+	err := env.Parse(&conf)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("failed to read the config")
+	}
+
+	if conf.StorePath == "" && conf.CSRPath == "" {
+		zlog.Fatal().Msg("neither GRAPH_STORE_PATH nor GRAPH_CSR_PATH is set")
+	}
+
+	return conf
+}
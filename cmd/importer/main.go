@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+	"github.com/lodthe/wiki-graph/internal/graphstore"
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
+)
+
+func main() {
+	conf := ReadConfig()
+
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	zlog.Logger = zlog.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	startedAt := time.Now()
+
+	store, err := graphstore.ImportDumps(graphstore.DumpFiles{
+		Page:       conf.Dumps.PagePath,
+		Redirect:   conf.Dumps.RedirectPath,
+		LinkTarget: conf.Dumps.LinkTargetPath,
+		PageLinks:  conf.Dumps.PageLinksPath,
+	})
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("import failed")
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"pages":     store.PageCount(),
+		"redirects": store.RedirectCount(),
+		"links":     store.LinkCount(),
+		"elapsed":   time.Since(startedAt).String(),
+	}).Msg("dumps have been imported")
+
+	if conf.StorePath != "" {
+		err = store.Save(conf.StorePath)
+		if err != nil {
+			zlog.Fatal().Err(err).Str("path", conf.StorePath).Msg("failed to save the graph store")
+		}
+
+		zlog.Info().Str("path", conf.StorePath).Msg("graph store has been saved")
+	}
+
+	if conf.CSRPath != "" {
+		graph := csrgraph.FromStore(store)
+
+		err = graph.Save(conf.CSRPath)
+		if err != nil {
+			zlog.Fatal().Err(err).Str("path", conf.CSRPath).Msg("failed to save the CSR snapshot")
+		}
+
+		zlog.Info().Fields(map[string]interface{}{
+			"nodes": graph.NodeCount(),
+			"edges": graph.EdgeCount(),
+			"path":  conf.CSRPath,
+		}).Msg("CSR snapshot has been saved")
+	}
+}
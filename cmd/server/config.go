@@ -11,6 +11,10 @@ type Config struct {
 	DB         DB
 	GRPCServer GRPCServer
 	AMQP       AMQP
+	WikiAPI    WikiAPI
+
+	Verification Verification
+	Sampling     Sampling
 }
 
 type DB struct {
@@ -26,6 +30,21 @@ type GRPCServer struct {
 
 	Timeout   time.Duration `env:"GRPC_SERVER_TIMEOUT" envDefault:"10s"`
 	KeepAlive time.Duration `env:"GRPC_SERVER_KEEP_ALIVE" envDefault:"500ms"`
+
+	// Callers with this many unfinished tasks cannot create new ones until some of them are done.
+	// Callers are identified by the x-caller-token metadata or by the host. 0 means unlimited.
+	MaxUnfinishedTasksPerCaller int `env:"GRPC_SERVER_MAX_UNFINISHED_TASKS_PER_CALLER" envDefault:"100"`
+
+	// Caller tokens and the names of their callers, e.g. "token1:alice,token2:bob".
+	// Requests with unknown tokens are rejected.
+	CallerTokens map[string]string `env:"GRPC_SERVER_CALLER_TOKENS"`
+
+	// GetDistance answers from the cache if the distance was computed within this period.
+	DistanceCacheTTL time.Duration `env:"GRPC_SERVER_DISTANCE_CACHE_TTL" envDefault:"24h"`
+
+	// Must be the same as BFS_DISTANCE_QUERY_THRESHOLD of the workers.
+	// Cached unreachable results answer queries without a limit only if they were searched this far.
+	DistanceQueryThreshold uint `env:"GRPC_SERVER_DISTANCE_QUERY_THRESHOLD" envDefault:"4"`
 }
 
 type AMQP struct {
@@ -35,6 +54,53 @@ type AMQP struct {
 	RoutingKey   string `env:"AMQP_ROUTING_KEY" envDefault:"task"`
 }
 
+// WikiAPI is used by GetNeighbors, the path verification, the race referee and the sampler.
+type WikiAPI struct {
+	ApiURL string `env:"WIKIPEDIA_API_URL" envDefault:"https://en.wikipedia.org/w/api.php"`
+	MaxRPS int    `env:"WIKIPEDIA_API_RPS" envDefault:"50"`
+
+	// If enabled, WIKIPEDIA_API_RPS is shared with the workers using the same database,
+	// and the server leases its part of the limit like a worker. It must be set to the same value as for the workers.
+	// The TTL must be at least 1s.
+	SharedQuota   bool          `env:"WIKIPEDIA_API_SHARED_QUOTA" envDefault:"true"`
+	QuotaLeaseTTL time.Duration `env:"WIKIPEDIA_API_QUOTA_LEASE_TTL" envDefault:"15s"`
+
+	// The server fetches pages rarely, so its lease is capped and the rest of the limit is left to the workers.
+	// 0 means an equal share.
+	QuotaMaxRPS int `env:"WIKIPEDIA_API_SERVER_MAX_RPS" envDefault:"5"`
+}
+
+// Verification configures the background check of the stored paths against the current links.
+type Verification struct {
+	// Period between checks, 0 disables them.
+	Interval time.Duration `env:"VERIFY_INTERVAL" envDefault:"0"`
+
+	// Paths verified within this period are not checked again.
+	MaxAge time.Duration `env:"VERIFY_MAX_AGE" envDefault:"168h"`
+
+	// Maximum number of paths checked per period.
+	BatchSize int `env:"VERIFY_BATCH_SIZE" envDefault:"100"`
+
+	// If enabled, searches of broken paths are run again with the BATCH priority.
+	Rerun bool `env:"VERIFY_RERUN" envDefault:"false"`
+}
+
+// Sampling configures the scheduled runs estimating the distances between random articles.
+type Sampling struct {
+	// Period between runs, 0 disables them.
+	Interval time.Duration `env:"SAMPLING_INTERVAL" envDefault:"0"`
+
+	// Number of random pairs in a run.
+	Pairs int `env:"SAMPLING_PAIRS" envDefault:"100"`
+
+	// Distances are searched within this many clicks, 0 means the worker limit.
+	MaxDistance uint `env:"SAMPLING_MAX_DISTANCE" envDefault:"0"`
+
+	// Maximum number of unfinished tasks of the sampling runs of a single caller, 0 means unlimited.
+	// They are limited separately from the other tasks, so a run can have more pairs than the limit of those.
+	MaxUnfinishedTasks int `env:"SAMPLING_MAX_UNFINISHED_TASKS_PER_CALLER" envDefault:"1000"`
+}
+
 func ReadConfig() Config {
 	var conf Config
 	err := env.Parse(&conf)
@@ -7,11 +7,19 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"github.com/jmoiron/sqlx"
+	"github.com/lodthe/wiki-graph/internal/apiquota"
+	"github.com/lodthe/wiki-graph/internal/distcache"
+	"github.com/lodthe/wiki-graph/internal/hubrank"
 	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/pathverify"
+	"github.com/lodthe/wiki-graph/internal/separation"
 	"github.com/lodthe/wiki-graph/internal/taskqueue"
 	"github.com/lodthe/wiki-graph/internal/wikigraphserver"
+	"github.com/lodthe/wiki-graph/internal/wikirace"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
 	"github.com/lodthe/wiki-graph/pkg/wikigraphpb"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -28,7 +36,7 @@ func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	zlog.Logger = zlog.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
-	_, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
@@ -50,7 +58,57 @@ func main() {
 
 	repo := pathtask.NewRepository(db)
 	producer := taskqueue.NewProducer(publisher, conf.AMQP.ExchangeName, conf.AMQP.RoutingKey)
-	wikiGraphServer := wikigraphserver.New(repo, producer)
+	wikiClient := wikiclient.New(conf.WikiAPI.ApiURL, conf.WikiAPI.MaxRPS)
+
+	quotaReleased := make(chan struct{})
+	if conf.WikiAPI.SharedQuota {
+		if conf.WikiAPI.QuotaLeaseTTL < apiquota.MinLeaseTTL {
+			zlog.Fatal().Dur("ttl", conf.WikiAPI.QuotaLeaseTTL).Msg("WIKIPEDIA_API_QUOTA_LEASE_TTL must be at least 1s")
+		}
+
+		// Requests wait for the first lease.
+		wikiClient.SetMaxRPS(0)
+
+		allocator := apiquota.NewAllocator(db, "server-"+uuid.New().String(), conf.WikiAPI.MaxRPS, conf.WikiAPI.QuotaMaxRPS, conf.WikiAPI.QuotaLeaseTTL, wikiClient.SetMaxRPS)
+		go func() {
+			allocator.Run(ctx)
+			close(quotaReleased)
+		}()
+	} else {
+		close(quotaReleased)
+	}
+
+	verifier := pathverify.NewVerifier(repo, producer, wikiClient, conf.GRPCServer.MaxUnfinishedTasksPerCaller)
+
+	if conf.Verification.Interval > 0 {
+		go verifier.Run(ctx, pathverify.MonitorConfig{
+			Interval:  conf.Verification.Interval,
+			MaxAge:    conf.Verification.MaxAge,
+			BatchSize: conf.Verification.BatchSize,
+			Rerun:     conf.Verification.Rerun,
+		})
+	}
+
+	distances := distcache.NewRepository(db)
+	referee := wikirace.NewReferee(wikirace.NewRepository(db), wikirace.NewRaceRepository(db), repo, distances, producer, wikiClient, conf.GRPCServer.DistanceCacheTTL, conf.GRPCServer.DistanceQueryThreshold, conf.GRPCServer.MaxUnfinishedTasksPerCaller)
+
+	sampler := separation.NewSampler(separation.NewRepository(db), repo, producer, wikiClient, conf.Sampling.MaxUnfinishedTasks)
+
+	if conf.Sampling.Interval > 0 {
+		go sampler.Run(ctx, separation.ScheduleConfig{
+			Interval:    conf.Sampling.Interval,
+			Pairs:       conf.Sampling.Pairs,
+			MaxDistance: conf.Sampling.MaxDistance,
+		})
+	}
+
+	wikiGraphServer := wikigraphserver.New(repo, distances, hubrank.NewRepository(db), producer, wikiClient, verifier, referee, sampler, wikigraphserver.Config{
+		MaxUnfinishedTasks: conf.GRPCServer.MaxUnfinishedTasksPerCaller,
+		CallerTokens:       conf.GRPCServer.CallerTokens,
+		DistanceCacheTTL:   conf.GRPCServer.DistanceCacheTTL,
+
+		DistanceQueryThreshold: conf.GRPCServer.DistanceQueryThreshold,
+	})
 
 	srv, lis, err := registerServer(conf.GRPCServer, wikiGraphServer)
 	if err != nil {
@@ -68,6 +126,7 @@ func main() {
 
 	<-stop
 	cancel()
+	<-quotaReleased
 }
 
 func setupDatabaseConnection(config DB) (*sqlx.DB, error) {
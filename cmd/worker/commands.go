@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+	"github.com/lodthe/wiki-graph/internal/graphexport"
+	"github.com/lodthe/wiki-graph/internal/hubrank"
+	"github.com/lodthe/wiki-graph/internal/linkcache"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// runCommand runs a one-off worker subcommand instead of consuming tasks.
+func runCommand(ctx context.Context, name string, args []string, cache linkcache.Repository, hubs hubrank.Repository) error {
+	switch name {
+	case "export":
+		return runExport(args, cache)
+
+	case "import":
+		return runImport(args, cache)
+
+	case "rank":
+		return runRank(ctx, args, cache, hubs)
+
+	default:
+		return fmt.Errorf("unknown command %q, expected export, import or rank", name)
+	}
+}
+
+// runExport exports the cached graph, e.g.:
+//
+//	worker export -format graphml -seeds Apple,Fruit -hops 2 -output apple.graphml
+func runExport(args []string, cache linkcache.Repository) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	formatName := flags.String("format", string(graphexport.FormatSnapshot), "output format: snapshot, csv, graphml or dot")
+	output := flags.String("output", "", "output file, stdout if empty")
+	seeds := flags.String("seeds", "", "comma-separated titles to export the subgraph around, the whole cache if empty")
+	hops := flags.Uint("hops", 1, "maximum distance from the seeds")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	format, err := graphexport.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	var seedTitles []string
+	for _, seed := range strings.Split(*seeds, ",") {
+		if seed = strings.TrimSpace(seed); seed != "" {
+			seedTitles = append(seedTitles, seed)
+		}
+	}
+
+	startedAt := time.Now()
+
+	graph, err := graphexport.FromCache(cache, seedTitles, *hops)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect the graph")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the output file")
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	err = graphexport.Write(w, graph, format)
+	if err != nil {
+		return errors.Wrap(err, "export failed")
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"pages":   graph.NodeCount(),
+		"links":   graph.EdgeCount(),
+		"format":  format,
+		"elapsed": time.Since(startedAt).String(),
+	}).Msg("graph has been exported")
+
+	return nil
+}
+
+// runImport warms the cache with a snapshot produced by export, e.g.:
+//
+//	worker import -input cache.snapshot
+//
+// Pages are saved as fetched when the snapshot was, so stale links are not presented as fresh.
+// Snapshots without the fetch time (older snapshots and ones built from dumps) require -fetched-at.
+func runImport(args []string, cache linkcache.Repository) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	input := flags.String("input", "", "snapshot file")
+	fetchedAtFlag := flags.String("fetched-at", "", "RFC 3339 time the snapshot links were fetched at, overrides the time stored in the snapshot")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *input == "" {
+		return errors.New("input is not set")
+	}
+
+	graph, err := csrgraph.Open(*input)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the snapshot")
+	}
+	defer graph.Close()
+
+	fetchedAt := graph.FetchedAt()
+	if *fetchedAtFlag != "" {
+		fetchedAt, err = time.Parse(time.RFC3339, *fetchedAtFlag)
+		if err != nil {
+			return errors.Wrap(err, "invalid fetched-at")
+		}
+	}
+	if fetchedAt.IsZero() {
+		return errors.New("the snapshot has no fetch time, set fetched-at")
+	}
+
+	startedAt := time.Now()
+
+	imported, err := graphexport.ImportSnapshot(graph, cache, fetchedAt)
+	if err != nil {
+		return errors.Wrap(err, "import failed")
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"pages":      imported,
+		"fetched_at": fetchedAt,
+		"elapsed":    time.Since(startedAt).String(),
+	}).Msg("snapshot has been imported")
+
+	return nil
+}
+
+// runRank ranks the hub pages of a snapshot or of the whole cached graph and replaces the stored rankings, e.g.:
+//
+//	worker rank -input enwiki.csr -by betweenness -samples 500 -top 10000
+func runRank(ctx context.Context, args []string, cache linkcache.Repository, hubs hubrank.Repository) error {
+	flags := flag.NewFlagSet("rank", flag.ContinueOnError)
+	input := flags.String("input", "", "CSR snapshot to rank, the whole cache if empty")
+	by := flags.String("by", string(hubrank.MetricBetweenness), "metric to rank by: pagerank, in_degree or betweenness")
+	top := flags.Int("top", 10000, "number of the top pages to store, 0 means all pages")
+	iterations := flags.Int("iterations", 30, "number of PageRank iterations")
+	damping := flags.Float64("damping", 0.85, "PageRank damping factor")
+	samples := flags.Int("samples", 500, "number of source pages betweenness is estimated from")
+	seed := flags.Int64("seed", 1, "seed the betweenness sources are sampled with")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	metric, err := hubrank.ParseMetric(*by)
+	if err != nil {
+		return err
+	}
+
+	startedAt := time.Now()
+
+	var graph *csrgraph.Graph
+	if *input != "" {
+		graph, err = csrgraph.Open(*input)
+		if err != nil {
+			return errors.Wrap(err, "failed to open the snapshot")
+		}
+	} else {
+		graph, err = graphexport.FromCache(cache, nil, 0)
+		if err != nil {
+			return errors.Wrap(err, "failed to collect the graph")
+		}
+	}
+	defer graph.Close()
+
+	rankings, err := hubrank.Compute(ctx, graph, hubrank.Config{
+		By:                 metric,
+		Top:                *top,
+		PageRankIterations: *iterations,
+		Damping:            *damping,
+		BetweennessSamples: *samples,
+		Seed:               *seed,
+	})
+	if err != nil {
+		return errors.Wrap(err, "ranking failed")
+	}
+
+	err = hubs.Replace(rankings)
+	if err != nil {
+		return errors.Wrap(err, "failed to save the rankings")
+	}
+
+	fields := map[string]interface{}{
+		"pages":   graph.NodeCount(),
+		"links":   graph.EdgeCount(),
+		"ranked":  len(rankings),
+		"by":      metric,
+		"elapsed": time.Since(startedAt).String(),
+	}
+	if len(rankings) != 0 {
+		fields["top"] = rankings[0].PageTitle
+	}
+
+	zlog.Info().Fields(fields).Msg("hub rankings have been saved")
+
+	return nil
+}
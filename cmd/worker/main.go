@@ -2,17 +2,31 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"github.com/jmoiron/sqlx"
+	"github.com/lodthe/wiki-graph/internal/apiquota"
+	"github.com/lodthe/wiki-graph/internal/checkpoint"
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+	"github.com/lodthe/wiki-graph/internal/distcache"
+	"github.com/lodthe/wiki-graph/internal/fairqueue"
+	"github.com/lodthe/wiki-graph/internal/graphstore"
+	"github.com/lodthe/wiki-graph/internal/hubrank"
+	"github.com/lodthe/wiki-graph/internal/linkcache"
 	"github.com/lodthe/wiki-graph/internal/pathtask"
 	"github.com/lodthe/wiki-graph/internal/taskqueue"
 	"github.com/lodthe/wiki-graph/internal/wikibfs"
 	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	zlog "github.com/rs/zerolog/log"
 	"github.com/wagslane/go-rabbitmq"
@@ -24,7 +38,7 @@ func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	zlog.Logger = zlog.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
-	_, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
@@ -34,6 +48,15 @@ func main() {
 	}
 	defer db.Close()
 
+	if len(os.Args) > 1 {
+		err = runCommand(ctx, os.Args[1], os.Args[2:], linkcache.NewRepository(db), hubrank.NewRepository(db))
+		if err != nil {
+			zlog.Fatal().Err(err).Str("command", os.Args[1]).Msg("command failed")
+		}
+
+		return
+	}
+
 	rabbitConsumer, err := rabbitmq.NewConsumer(
 		conf.AMQP.ConnectionURL,
 		rabbitmq.Config{},
@@ -44,25 +67,107 @@ func main() {
 	defer rabbitConsumer.Close()
 
 	repo := pathtask.NewRepository(db)
+	linkCache := linkcache.NewRepository(db)
+	workerID := uuid.New().String()
+
 	wikiClient := wikiclient.New(conf.WikiAPI.ApiURL, conf.WikiAPI.MaxRPS)
-	handler := wikibfs.NewHandler(repo, wikiClient, wikibfs.BFSConfig{
-		DistanceThreshold: conf.Algorithm.DistanceThreshold,
-		WorkerCount:       conf.Algorithm.WorkerCount,
+
+	quotaReleased := make(chan struct{})
+	if conf.WikiAPI.SharedQuota {
+		if conf.WikiAPI.QuotaLeaseTTL < apiquota.MinLeaseTTL {
+			zlog.Fatal().Dur("ttl", conf.WikiAPI.QuotaLeaseTTL).Msg("WIKIPEDIA_API_QUOTA_LEASE_TTL must be at least 1s")
+		}
+
+		// Requests wait for the first lease.
+		wikiClient.SetMaxRPS(0)
+
+		allocator := apiquota.NewAllocator(db, workerID, conf.WikiAPI.MaxRPS, 0, conf.WikiAPI.QuotaLeaseTTL, wikiClient.SetMaxRPS)
+		go func() {
+			allocator.Run(ctx)
+			close(quotaReleased)
+		}()
+	} else {
+		close(quotaReleased)
+	}
+
+	if conf.Metrics.Address != "" {
+		go serveMetrics(conf.Metrics.Address)
+	}
+	scheduler := fairqueue.NewScheduler(conf.WikiAPI.MaxParallelFetches)
+
+	source, err := setupGraphSource(conf.Graph, wikiClient, scheduler, linkCache)
+	if err != nil {
+		zlog.Fatal().Err(err).Strs("sources", conf.Graph.Sources).Msg("failed to setup graph source")
+	}
+
+	sources := wikibfs.Sources{
+		Latest: source,
+		AsOf: func(asOf time.Time) wikibfs.GraphSource {
+			return wikibfs.NewHistoricalSource(wikiClient, scheduler, linkCache, asOf)
+		},
+	}
+
+	checkpoints, err := setupCheckpointStore(conf.Algorithm, db)
+	if err != nil {
+		zlog.Fatal().Err(err).Str("store", conf.Algorithm.CheckpointStore).Msg("failed to setup checkpoint store")
+	}
+
+	var coordinator *wikibfs.Coordinator
+	if conf.Cluster.Distributed {
+		coordinator, err = setupCoordinator(conf, workerID, sources)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("failed to setup the coordinator")
+		}
+	}
+
+	handler := wikibfs.NewHandler(repo, distcache.NewRepository(db), hubrank.NewRepository(db), checkpoints, sources, coordinator, wikibfs.BFSConfig{
+		DistanceThreshold:  conf.Algorithm.DistanceThreshold,
+		WorkerCount:        conf.Algorithm.WorkerCount,
+		CheckpointInterval: conf.Algorithm.CheckpointInterval,
+		ProgressInterval:   conf.Algorithm.ProgressInterval,
+		PageRetries:        conf.Algorithm.PageRetries,
+		PageRetryDelay:     conf.Algorithm.PageRetryDelay,
+		ExpandBatchSize:    conf.Algorithm.ExpandBatchSize,
+		SpillThreshold:     conf.Algorithm.SpillThresholdMB << 20,
+		SpillDir:           conf.Algorithm.SpillDir,
+		MemoryBudget:       conf.Algorithm.MemoryBudgetMB << 20,
+
+		HeuristicMaxFetches: conf.Algorithm.HeuristicMaxFetches,
+		HeuristicBeamWidth:  conf.Algorithm.HeuristicBeamWidth,
+		HeuristicCategories: conf.Algorithm.HeuristicCategories,
+
+		DistanceQueryThreshold: conf.Algorithm.DistanceQueryThreshold,
+		NeighborhoodMaxPages:   conf.Algorithm.NeighborhoodMaxPages,
+
+		MaxTaskAttempts: conf.Algorithm.MaxTaskAttempts,
 	})
 
 	consumer := taskqueue.NewConsumer(rabbitConsumer, conf.AMQP.QueueName, conf.AMQP.RoutingKey)
 
 	go func() {
-		err := consumer.StartConsuming(handler.HandleTask)
+		err := consumer.StartConsuming(conf.AMQP.Concurrency, handler.HandleTask)
 		if err != nil {
 			zlog.Fatal().Err(err).Msg("consumer failed")
 		}
 	}()
 
-	zlog.Info().Msg("consumer has been started")
+	zlog.Info().Int("concurrency", conf.AMQP.Concurrency).Msg("consumer has been started")
 
 	<-stop
 	cancel()
+	<-quotaReleased
+}
+
+func serveMetrics(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	zlog.Info().Str("address", address).Msg("serving metrics")
+
+	err := http.ListenAndServe(address, mux)
+	if err != nil {
+		zlog.Error().Err(err).Str("address", address).Msg("metrics server failed")
+	}
 }
 
 func setupDatabaseConnection(config DB) (*sqlx.DB, error) {
@@ -77,3 +182,140 @@ func setupDatabaseConnection(config DB) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+// setupCoordinator connects the worker to the expansion queues:
+// the shared one with requests and the worker's own one with results.
+func setupCoordinator(conf Config, workerID string, sources wikibfs.Sources) (*wikibfs.Coordinator, error) {
+	if conf.Cluster.ShardSize <= 0 {
+		return nil, errors.New("BFS_SHARD_SIZE must be positive")
+	}
+	if conf.Cluster.ShardTimeout < time.Second {
+		return nil, errors.New("BFS_SHARD_TIMEOUT must be at least 1s")
+	}
+
+	publisher, err := rabbitmq.NewPublisher(
+		conf.AMQP.ConnectionURL,
+		rabbitmq.Config{},
+		rabbitmq.WithPublisherOptionsLogging,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to RabbitMQ")
+	}
+
+	resultQueue := fmt.Sprintf("%s.%s", conf.Cluster.ResultQueuePrefix, workerID)
+
+	coordinator := wikibfs.NewCoordinator(
+		taskqueue.NewProducer(publisher, "", ""),
+		sources,
+		wikibfs.DistributedConfig{
+			ExpansionQueue:   conf.Cluster.ExpansionQueueName,
+			ResultQueue:      resultQueue,
+			ShardSize:        conf.Cluster.ShardSize,
+			ShardTimeout:     conf.Cluster.ShardTimeout,
+			MaxShardAttempts: conf.Cluster.MaxShardAttempts,
+
+			MaxInFlightShards: conf.Cluster.MaxInFlightShards,
+		},
+		conf.Algorithm.WorkerCount,
+	)
+
+	expansionConsumer, err := rabbitmq.NewConsumer(conf.AMQP.ConnectionURL, rabbitmq.Config{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to RabbitMQ")
+	}
+
+	resultConsumer, err := rabbitmq.NewConsumer(conf.AMQP.ConnectionURL, rabbitmq.Config{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to RabbitMQ")
+	}
+
+	go func() {
+		consumer := taskqueue.NewConsumer(expansionConsumer, conf.Cluster.ExpansionQueueName, "")
+		err := consumer.StartConsumingExpansions(conf.Cluster.ExpansionConcurrency, coordinator.HandleExpansion)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("expansion consumer failed")
+		}
+	}()
+
+	go func() {
+		consumer := taskqueue.NewConsumer(resultConsumer, resultQueue, "")
+		err := consumer.StartConsumingExpansionResults(coordinator.HandleResult)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("expansion result consumer failed")
+		}
+	}()
+
+	zlog.Info().Str("result_queue", resultQueue).Msg("distributed mode is enabled")
+
+	return coordinator, nil
+}
+
+func setupGraphSource(conf Graph, wikiClient *wikiclient.Client, scheduler *fairqueue.Scheduler, linkCache linkcache.Repository) (wikibfs.GraphSource, error) {
+	var layers []wikibfs.GraphSource
+	for _, name := range conf.Sources {
+		switch name {
+		case "csr":
+			graph, err := csrgraph.Open(conf.CSRPath)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to open the CSR snapshot")
+			}
+
+			zlog.Info().Int("pages", graph.NodeCount()).Int("links", graph.EdgeCount()).Msg("CSR snapshot has been loaded")
+
+			layers = append(layers, wikibfs.NewCSRSource(graph, conf.CSRMaxDistance))
+
+		case "store":
+			store, err := graphstore.Load(conf.StorePath)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to load the graph store")
+			}
+
+			zlog.Info().Int("pages", store.PageCount()).Int("links", store.LinkCount()).Msg("graph store has been loaded")
+
+			layers = append(layers, wikibfs.NewStoreSource(store))
+
+		case "static":
+			graph, err := wikibfs.LoadStaticGraph(conf.StaticPath)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to load the static graph")
+			}
+
+			layers = append(layers, graph)
+
+		case "cache":
+			layers = append(layers, wikibfs.NewCacheSource(linkCache, conf.CacheTTL))
+
+		case "api":
+			layers = append(layers, wikibfs.NewAPISource(wikiClient, scheduler))
+
+		default:
+			return nil, errors.Errorf("unknown graph source %q", name)
+		}
+	}
+
+	if len(layers) == 0 {
+		return nil, errors.New("no graph sources configured")
+	}
+
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+
+	return wikibfs.NewLayeredSource(layers...), nil
+}
+
+func setupCheckpointStore(conf Algorithm, db *sqlx.DB) (checkpoint.Store, error) {
+	switch conf.CheckpointStore {
+	case "postgres":
+		return checkpoint.NewRepository(db), nil
+
+	case "file":
+		return checkpoint.NewFileStore(conf.CheckpointDir)
+
+	case "none", "":
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("unknown checkpoint store %q", conf.CheckpointStore)
+	}
+}
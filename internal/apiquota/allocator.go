@@ -0,0 +1,244 @@
+// Package apiquota splits the Wikipedia API rate limit between all running workers and servers.
+//
+// Every worker holds a lease on a part of the total RPS in PostgreSQL and renews it periodically.
+// Renewals are serialized with an advisory lock, and a worker is granted at most
+// what is left after the unexpired leases of other workers, so the sum of leases never
+// exceeds the limit. Workers that hold more than their share give the excess back
+// on their next renewal, and leases of stopped workers expire.
+//
+// Shares are equal, except for leases capped by their holders (e.g. servers, which rarely fetch pages):
+// they get at most their cap, and the rest is split between the others.
+package apiquota
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Key of the advisory lock guarding the leases table.
+const advisoryLockKey = 0x77696b69
+
+// MinLeaseTTL is the shortest allowed lease TTL.
+const MinLeaseTTL = time.Second
+
+var (
+	shareGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "wikigraph_wikipedia_api_rps_share",
+		Help: "Part of the cluster-wide Wikipedia API rate limit granted to the worker.",
+	}, []string{"worker_id"})
+
+	workersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "wikigraph_wikipedia_api_quota_workers",
+		Help: "Number of workers holding a Wikipedia API quota lease.",
+	})
+
+	renewalErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "wikigraph_wikipedia_api_quota_renewal_errors_total",
+		Help: "Number of failed quota lease renewals.",
+	})
+)
+
+type Allocator struct {
+	db       *sqlx.DB
+	workerID string
+	totalRPS int
+	maxRPS   int
+	ttl      time.Duration
+
+	// Called with the granted RPS after every renewal.
+	apply func(rps int)
+
+	expiresAt time.Time
+}
+
+// NewAllocator creates an allocator of the worker's share of totalRPS, but not more than maxRPS if it's positive.
+// The lease is renewed every ttl/3, so ttl must be at least MinLeaseTTL.
+// apply is called with the granted RPS every time the lease is renewed or lost.
+func NewAllocator(db *sqlx.DB, workerID string, totalRPS, maxRPS int, ttl time.Duration, apply func(rps int)) *Allocator {
+	if ttl < MinLeaseTTL {
+		ttl = MinLeaseTTL
+	}
+
+	return &Allocator{
+		db:       db,
+		workerID: workerID,
+		totalRPS: totalRPS,
+		maxRPS:   maxRPS,
+		ttl:      ttl,
+		apply:    apply,
+	}
+}
+
+// Run renews the lease until ctx is cancelled. The lease is released afterwards.
+func (a *Allocator) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		a.renewOrPause()
+
+		select {
+		case <-ctx.Done():
+			a.release()
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Allocator) renewOrPause() {
+	rps, workers, err := a.renew()
+	if err != nil {
+		renewalErrors.Inc()
+		zlog.Error().Err(err).Str("worker_id", a.workerID).Msg("failed to renew the API quota lease")
+
+		// Other workers may have taken the share of the expired lease.
+		if time.Now().After(a.expiresAt) {
+			a.set(0)
+		}
+
+		return
+	}
+
+	a.expiresAt = time.Now().Add(a.ttl)
+	workersGauge.Set(float64(workers))
+	a.set(rps)
+}
+
+func (a *Allocator) set(rps int) {
+	shareGauge.WithLabelValues(a.workerID).Set(float64(rps))
+	a.apply(rps)
+}
+
+// renew grants the worker min(its share, what is left after other workers) and prolongs the lease.
+func (a *Allocator) renew() (rps int, workers int, err error) {
+	tx, err := a.db.Beginx()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1)`, advisoryLockKey)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to acquire lock")
+	}
+
+	_, err = tx.Exec(`DELETE FROM "api_quota_leases" WHERE expires_at < now()`)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to delete expired leases")
+	}
+
+	var leases []lease
+	err = tx.Select(&leases, `SELECT worker_id, rps, max_rps FROM "api_quota_leases" WHERE worker_id <> $1`, a.workerID)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to get leases")
+	}
+
+	othersRPS := 0
+	for _, l := range leases {
+		othersRPS += l.RPS
+	}
+
+	leases = append(leases, lease{WorkerID: a.workerID, MaxRPS: a.maxRPS})
+	rps = shares(a.totalRPS, leases)[a.workerID]
+	if left := a.totalRPS - othersRPS; left < rps {
+		rps = left
+	}
+	if rps < 0 {
+		rps = 0
+	}
+
+	query := `INSERT INTO "api_quota_leases" (worker_id, rps, max_rps, expires_at) VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+							ON CONFLICT (worker_id) DO UPDATE SET rps = excluded.rps, max_rps = excluded.max_rps, expires_at = excluded.expires_at`
+	_, err = tx.Exec(query, a.workerID, rps, a.maxRPS, a.ttl.Seconds())
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to save lease")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to commit")
+	}
+
+	return rps, len(leases), nil
+}
+
+type lease struct {
+	WorkerID string `db:"worker_id"`
+	RPS      int    `db:"rps"`
+
+	// 0 means the holder takes as much as it's given.
+	MaxRPS int `db:"max_rps"`
+}
+
+// shares splits totalRPS between the leases. Capped leases get at most their cap, and the rest is split equally
+// between the others. The remainder of the division is given out one by one in the order of worker IDs,
+// so every lease gets at least 1 RPS if there is enough for all of them.
+func shares(totalRPS int, leases []lease) map[string]int {
+	sorted := make([]lease, len(leases))
+	copy(sorted, leases)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].WorkerID < sorted[j].WorkerID
+	})
+
+	result := make(map[string]int, len(sorted))
+	left := totalRPS
+
+	// Leases capped below the equal share of the rest get their cap, which increases the share of the others.
+	for capped := true; capped; {
+		capped = false
+
+		var rest []lease
+		for _, l := range sorted {
+			if _, done := result[l.WorkerID]; !done {
+				rest = append(rest, l)
+			}
+		}
+		if len(rest) == 0 {
+			break
+		}
+
+		for _, l := range rest {
+			if l.MaxRPS > 0 && l.MaxRPS <= left/len(rest) {
+				result[l.WorkerID] = l.MaxRPS
+				left -= l.MaxRPS
+				capped = true
+			}
+		}
+	}
+
+	var rest []lease
+	for _, l := range sorted {
+		if _, done := result[l.WorkerID]; !done {
+			rest = append(rest, l)
+		}
+	}
+
+	for i, l := range rest {
+		share := left / len(rest)
+		if i < left%len(rest) {
+			share++
+		}
+
+		result[l.WorkerID] = share
+	}
+
+	return result
+}
+
+func (a *Allocator) release() {
+	_, err := a.db.Exec(`DELETE FROM "api_quota_leases" WHERE worker_id = $1`, a.workerID)
+	if err != nil {
+		zlog.Error().Err(err).Str("worker_id", a.workerID).Msg("failed to release the API quota lease")
+	}
+
+	shareGauge.DeleteLabelValues(a.workerID)
+}
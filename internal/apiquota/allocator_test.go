@@ -0,0 +1,69 @@
+package apiquota
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShares(t *testing.T) {
+	tests := []struct {
+		name     string
+		totalRPS int
+		leases   []lease
+		want     map[string]int
+	}{
+		{
+			name:     "equal",
+			totalRPS: 50,
+			leases:   []lease{{WorkerID: "a"}, {WorkerID: "b"}},
+			want:     map[string]int{"a": 25, "b": 25},
+		},
+		{
+			name:     "remainder in the order of IDs",
+			totalRPS: 50,
+			leases:   []lease{{WorkerID: "c"}, {WorkerID: "a"}, {WorkerID: "b"}},
+			want:     map[string]int{"a": 17, "b": 17, "c": 16},
+		},
+		{
+			name:     "more workers than RPS",
+			totalRPS: 2,
+			leases:   []lease{{WorkerID: "a"}, {WorkerID: "b"}, {WorkerID: "c"}},
+			want:     map[string]int{"a": 1, "b": 1, "c": 0},
+		},
+		{
+			name:     "capped server",
+			totalRPS: 50,
+			leases:   []lease{{WorkerID: "a"}, {WorkerID: "b"}, {WorkerID: "server", MaxRPS: 5}},
+			want:     map[string]int{"a": 23, "b": 22, "server": 5},
+		},
+		{
+			name:     "cap above the equal share",
+			totalRPS: 10,
+			leases:   []lease{{WorkerID: "a"}, {WorkerID: "b"}, {WorkerID: "server", MaxRPS: 8}},
+			want:     map[string]int{"a": 4, "b": 3, "server": 3},
+		},
+		{
+			name:     "caps freeing room for other caps",
+			totalRPS: 10,
+			leases:   []lease{{WorkerID: "a"}, {WorkerID: "x", MaxRPS: 1}, {WorkerID: "y", MaxRPS: 4}},
+			want:     map[string]int{"a": 5, "x": 1, "y": 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shares(tt.totalRPS, tt.leases)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shares() = %v, want %v", got, tt.want)
+			}
+
+			sum := 0
+			for _, rps := range got {
+				sum += rps
+			}
+			if sum > tt.totalRPS {
+				t.Errorf("%d RPS granted out of %d", sum, tt.totalRPS)
+			}
+		})
+	}
+}
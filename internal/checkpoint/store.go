@@ -0,0 +1,116 @@
+// Package checkpoint persists intermediate states of long-running tasks,
+// so they can be resumed after a worker restart.
+package checkpoint
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Store keeps the latest checkpoint of every task. Checkpoints are opaque blobs.
+type Store interface {
+	Save(taskID uuid.UUID, data []byte) error
+	Load(taskID uuid.UUID) ([]byte, error)
+	Delete(taskID uuid.UUID) error
+}
+
+// Repo keeps checkpoints in PostgreSQL, so a task can be resumed by any worker.
+type Repo struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Save(taskID uuid.UUID, data []byte) error {
+	query := `INSERT INTO "task_checkpoints" (task_id, updated_at, data) VALUES ($1, $2, $3)
+							ON CONFLICT (task_id) DO UPDATE SET updated_at = excluded.updated_at, data = excluded.data`
+	_, err := r.db.Exec(query, taskID, time.Now(), data)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func (r *Repo) Load(taskID uuid.UUID) ([]byte, error) {
+	var data []byte
+	err := r.db.Get(&data, `SELECT data FROM "task_checkpoints" WHERE task_id = $1`, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return data, nil
+}
+
+func (r *Repo) Delete(taskID uuid.UUID) error {
+	_, err := r.db.Exec(`DELETE FROM "task_checkpoints" WHERE task_id = $1`, taskID)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+// FileStore keeps checkpoints in a local directory.
+// Tasks can be resumed only by workers sharing the directory.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create directory")
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Save(taskID uuid.UUID, data []byte) error {
+	// Write to a temporary file first, so a crash never leaves a truncated checkpoint.
+	tmp := s.path(taskID) + ".tmp"
+	err := os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "write failed")
+	}
+
+	return errors.Wrap(os.Rename(tmp, s.path(taskID)), "rename failed")
+}
+
+func (s *FileStore) Load(taskID uuid.UUID) ([]byte, error) {
+	data, err := os.ReadFile(s.path(taskID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read failed")
+	}
+
+	return data, nil
+}
+
+func (s *FileStore) Delete(taskID uuid.UUID) error {
+	err := os.Remove(s.path(taskID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "remove failed")
+	}
+
+	return nil
+}
+
+func (s *FileStore) path(taskID uuid.UUID) string {
+	return filepath.Join(s.dir, taskID.String()+".checkpoint")
+}
@@ -0,0 +1,74 @@
+package csrgraph
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	unvisited = math.MaxUint32
+
+	// The context is checked once per this many expanded pages.
+	ctxCheckInterval = 1 << 14
+)
+
+// ShortestPath runs BFS from one page to another and returns IDs of the pages on the path,
+// or nil if the target is not reachable within maxDistance links (0 means unlimited).
+func (g *Graph) ShortestPath(ctx context.Context, from, to uint32, maxDistance uint) ([]uint32, error) {
+	if from == to {
+		return []uint32{from}, nil
+	}
+
+	prev := make([]uint32, g.NodeCount())
+	for i := range prev {
+		prev[i] = unvisited
+	}
+	prev[from] = from
+
+	queue := []uint32{from}
+	for distance := uint(1); len(queue) != 0; distance++ {
+		if maxDistance != 0 && distance > maxDistance {
+			return nil, nil
+		}
+
+		var next []uint32
+		for i, page := range queue {
+			if i%ctxCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, neighbor := range g.Neighbors(page) {
+				if prev[neighbor] != unvisited {
+					continue
+				}
+
+				prev[neighbor] = page
+				if neighbor == to {
+					return g.restorePath(prev, from, to), nil
+				}
+
+				next = append(next, neighbor)
+			}
+		}
+
+		queue = next
+	}
+
+	return nil, nil
+}
+
+func (g *Graph) restorePath(prev []uint32, from, to uint32) []uint32 {
+	path := []uint32{to}
+	for page := to; page != from; {
+		page = prev[page]
+		path = append(path, page)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
@@ -0,0 +1,188 @@
+package csrgraph
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/graphstore"
+)
+
+// Builder collects pages, links and redirects and assigns dense IDs to pages.
+type Builder struct {
+	ids      map[string]uint32
+	titles   []string
+	links    [][]uint32
+	expanded []bool
+
+	redirects map[string]string
+
+	fetchedAt time.Time
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		ids:       make(map[string]uint32),
+		redirects: make(map[string]string),
+	}
+}
+
+// AddPage registers the page and returns its ID. Adding the same title again returns the same ID.
+func (b *Builder) AddPage(title string) uint32 {
+	id, exists := b.ids[title]
+	if exists {
+		return id
+	}
+
+	id = uint32(len(b.titles))
+	b.ids[title] = id
+	b.titles = append(b.titles, title)
+	b.links = append(b.links, nil)
+	b.expanded = append(b.expanded, false)
+
+	return id
+}
+
+// AddLinks adds links of the page, registering all the pages if needed.
+// Links of the page are marked as known, even if there are none.
+func (b *Builder) AddLinks(from string, targets []string) {
+	fromID := b.AddPage(from)
+	b.expanded[fromID] = true
+
+	for _, to := range targets {
+		toID := b.AddPage(to)
+		if fromID == toID {
+			continue
+		}
+
+		b.links[fromID] = append(b.links[fromID], toID)
+	}
+}
+
+// AddRedirect makes the title an alias of the target page.
+// Redirects to pages that are never added are dropped on Build.
+func (b *Builder) AddRedirect(title, target string) {
+	b.redirects[title] = target
+}
+
+// SetFetchedAt records when the links were fetched. If it's called several times, the oldest moment is kept.
+func (b *Builder) SetFetchedAt(fetchedAt time.Time) {
+	if b.fetchedAt.IsZero() || fetchedAt.Before(b.fetchedAt) {
+		b.fetchedAt = fetchedAt
+	}
+}
+
+// Build returns the graph. The builder must not be used afterwards.
+func (b *Builder) Build() *Graph {
+	g := &Graph{
+		offsets:   make([]uint64, 1, len(b.titles)+1),
+		fetchedAt: b.fetchedAt,
+	}
+
+	g.expanded = make([]uint64, (len(b.titles)+63)/64)
+	for id, targets := range b.links {
+		targets = dedup(targets)
+		g.targets = append(g.targets, targets...)
+		g.offsets = append(g.offsets, uint64(len(g.targets)))
+
+		if b.expanded[id] {
+			g.expanded[id/64] |= 1 << (id % 64)
+		}
+	}
+
+	redirectTitles := make([]string, 0, len(b.redirects))
+	for title := range b.redirects {
+		redirectTitles = append(redirectTitles, title)
+	}
+	sort.Strings(redirectTitles)
+
+	names := append([]string(nil), b.titles...)
+	for _, title := range redirectTitles {
+		targetID, ok := b.ids[b.redirects[title]]
+		if !ok {
+			continue
+		}
+		if _, isPage := b.ids[title]; isPage {
+			continue
+		}
+
+		names = append(names, title)
+		g.redirectTargets = append(g.redirectTargets, targetID)
+	}
+
+	g.nameOffsets = make([]uint64, 0, len(names)+1)
+	g.nameOffsets = append(g.nameOffsets, 0)
+
+	var data strings.Builder
+	for _, name := range names {
+		data.WriteString(name)
+		g.nameOffsets = append(g.nameOffsets, uint64(data.Len()))
+	}
+	g.nameData = []byte(data.String())
+
+	lowered := make([]string, len(names))
+	g.sortedNames = make([]uint32, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(name)
+		g.sortedNames[i] = uint32(i)
+	}
+
+	sort.Slice(g.sortedNames, func(i, j int) bool {
+		return lowered[g.sortedNames[i]] < lowered[g.sortedNames[j]]
+	})
+
+	*b = Builder{}
+
+	return g
+}
+
+// FromStore converts the graph imported from Wikipedia dumps.
+func FromStore(store *graphstore.Store) *Graph {
+	b := NewBuilder()
+
+	store.ForEachPage(func(id uint32, title string) {
+		if !store.IsRedirect(id) {
+			b.AddPage(title)
+		}
+	})
+
+	store.ForEachPage(func(id uint32, title string) {
+		if store.IsRedirect(id) {
+			target, ok := store.ResolveRedirect(id)
+			if !ok {
+				return
+			}
+
+			targetTitle, _ := store.Title(target)
+			b.AddRedirect(title, targetTitle)
+
+			return
+		}
+
+		targets := store.OutgoingLinks(id)
+		targetTitles := make([]string, 0, len(targets))
+		for _, target := range targets {
+			targetTitle, _ := store.Title(target)
+			targetTitles = append(targetTitles, targetTitle)
+		}
+
+		b.AddLinks(title, targetTitles)
+	})
+
+	return b.Build()
+}
+
+func dedup(ids []uint32) []uint32 {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	result := ids[:0]
+	for _, id := range ids {
+		if len(result) == 0 || result[len(result)-1] != id {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,339 @@
+package csrgraph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// FormatVersion is the version of written snapshots. Snapshots of version 1
+// have no expanded section, all links in them are considered known.
+// Snapshots before version 3 have no fetch time in the header.
+const FormatVersion = 3
+
+var magic = []byte("WGCSR\x00\x00\x00")
+
+// Sections of a snapshot follow the header in this order, each one is padded to 8 bytes,
+// so they can be used in place when the file is memory-mapped.
+//
+//	header:          magic [8]byte, version uint32, reserved uint32,
+//	                 nodes uint64, edges uint64, names uint64, name bytes uint64,
+//	                 fetched at uint64 (unix seconds, 0 if unknown), since version 3
+//	offsets:         [nodes+1]uint64
+//	targets:         [edges]uint32
+//	expanded:        [(nodes+63)/64]uint64, since version 2
+//	nameOffsets:     [names+1]uint64
+//	redirectTargets: [names-nodes]uint32
+//	sortedNames:     [names]uint32
+//	nameData:        [name bytes]byte
+//
+// All numbers are little-endian.
+const (
+	headerSize   = 56
+	headerSizeV2 = 48
+)
+
+// Save writes the graph snapshot to the file.
+func (g *Graph) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	defer f.Close()
+
+	_, err = g.WriteTo(f)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// WriteTo writes the graph snapshot.
+func (g *Graph) WriteTo(out io.Writer) (int64, error) {
+	w := &countingWriter{w: bufio.NewWriterSize(out, 1<<20)}
+
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	binary.LittleEndian.PutUint32(header[8:], FormatVersion)
+	binary.LittleEndian.PutUint64(header[16:], uint64(g.NodeCount()))
+	binary.LittleEndian.PutUint64(header[24:], uint64(g.EdgeCount()))
+	binary.LittleEndian.PutUint64(header[32:], uint64(len(g.nameOffsets)-1))
+	binary.LittleEndian.PutUint64(header[40:], uint64(len(g.nameData)))
+	if !g.fetchedAt.IsZero() {
+		binary.LittleEndian.PutUint64(header[48:], uint64(g.fetchedAt.Unix()))
+	}
+	w.write(header)
+
+	w.writeUint64s(g.offsets)
+	w.writeUint32s(g.targets)
+	w.writeUint64s(g.expandedBitset())
+	w.writeUint64s(g.nameOffsets)
+	w.writeUint32s(g.redirectTargets)
+	w.writeUint32s(g.sortedNames)
+	w.write(g.nameData)
+	w.pad()
+
+	if w.err != nil {
+		return w.n, errors.Wrap(w.err, "write failed")
+	}
+
+	return w.n, errors.Wrap(w.w.Flush(), "write failed")
+}
+
+// Open loads the snapshot. The file is memory-mapped where possible, so the pages are shared between processes.
+// Offsets and IDs are validated once, so a corrupted snapshot is rejected instead of panicking on access.
+func Open(path string) (*Graph, error) {
+	data, release, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := parse(data)
+	if err != nil {
+		_ = release()
+		return nil, err
+	}
+
+	g.release = release
+
+	return g, nil
+}
+
+func parse(data []byte) (*Graph, error) {
+	if !isLittleEndian() {
+		return nil, errors.New("snapshots can be loaded only on little-endian hosts")
+	}
+
+	if len(data) < headerSizeV2 || !bytes.Equal(data[:len(magic)], magic) {
+		return nil, errors.New("not a CSR graph snapshot")
+	}
+
+	version := binary.LittleEndian.Uint32(data[8:])
+	if version < 1 || version > FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", version)
+	}
+
+	size := headerSizeV2
+	if version >= 3 {
+		size = headerSize
+	}
+	if len(data) < size {
+		return nil, errors.New("snapshot is truncated")
+	}
+
+	nodes := binary.LittleEndian.Uint64(data[16:])
+	edges := binary.LittleEndian.Uint64(data[24:])
+	names := binary.LittleEndian.Uint64(data[32:])
+	nameBytes := binary.LittleEndian.Uint64(data[40:])
+	// Every node takes at least an offset and every edge a target, so larger counts can't fit in the file.
+	dataSize := uint64(len(data))
+	if names < nodes || nodes >= dataSize/8 || edges > dataSize/4 || names >= dataSize/8 || nameBytes > dataSize {
+		return nil, errors.New("corrupted snapshot header")
+	}
+
+	r := &sectionReader{data: data, pos: uint64(size)}
+	g := &Graph{
+		offsets: r.uint64s(nodes + 1),
+		targets: r.uint32s(edges),
+	}
+	if version >= 3 {
+		if fetchedAt := binary.LittleEndian.Uint64(data[48:]); fetchedAt != 0 {
+			g.fetchedAt = time.Unix(int64(fetchedAt), 0)
+		}
+	}
+	if version >= 2 {
+		g.expanded = r.uint64s((nodes + 63) / 64)
+	}
+
+	g.nameOffsets = r.uint64s(names + 1)
+	g.redirectTargets = r.uint32s(names - nodes)
+	g.sortedNames = r.uint32s(names)
+	g.nameData = r.bytes(nameBytes)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	err := g.validate()
+	if err != nil {
+		return nil, errors.Wrap(err, "corrupted snapshot")
+	}
+
+	return g, nil
+}
+
+// validate checks that the offsets and IDs are in range, so the accessors never go out of bounds.
+func (g *Graph) validate() error {
+	err := checkOffsets(g.offsets, uint64(len(g.targets)))
+	if err != nil {
+		return errors.Wrap(err, "link offsets")
+	}
+
+	err = checkOffsets(g.nameOffsets, uint64(len(g.nameData)))
+	if err != nil {
+		return errors.Wrap(err, "name offsets")
+	}
+
+	err = checkIDs(g.targets, uint32(g.NodeCount()))
+	if err != nil {
+		return errors.Wrap(err, "link targets")
+	}
+
+	err = checkIDs(g.redirectTargets, uint32(g.NodeCount()))
+	if err != nil {
+		return errors.Wrap(err, "redirect targets")
+	}
+
+	err = checkIDs(g.sortedNames, uint32(len(g.nameOffsets)-1))
+	if err != nil {
+		return errors.Wrap(err, "sorted names")
+	}
+
+	return nil
+}
+
+// checkOffsets checks that the offsets start at 0, never decrease and end at size.
+func checkOffsets(offsets []uint64, size uint64) error {
+	if offsets[0] != 0 || offsets[len(offsets)-1] != size {
+		return errors.New("offsets don't cover the section")
+	}
+
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			return errors.Errorf("offset %d decreases", i)
+		}
+	}
+
+	return nil
+}
+
+func checkIDs(ids []uint32, limit uint32) error {
+	for i, id := range ids {
+		if id >= limit {
+			return errors.Errorf("ID %d at %d is out of range", id, i)
+		}
+	}
+
+	return nil
+}
+
+// expandedBitset returns the bitset with all pages marked if links of every page are known.
+func (g *Graph) expandedBitset() []uint64 {
+	if g.expanded != nil {
+		return g.expanded
+	}
+
+	bitset := make([]uint64, (g.NodeCount()+63)/64)
+	for i := range bitset {
+		bitset[i] = math.MaxUint64
+	}
+
+	return bitset
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *countingWriter) write(p []byte) {
+	if w.err != nil {
+		return
+	}
+
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (w *countingWriter) writeUint64s(values []uint64) {
+	buf := make([]byte, 8)
+	for _, v := range values {
+		binary.LittleEndian.PutUint64(buf, v)
+		w.write(buf)
+	}
+	w.pad()
+}
+
+func (w *countingWriter) writeUint32s(values []uint32) {
+	buf := make([]byte, 4)
+	for _, v := range values {
+		binary.LittleEndian.PutUint32(buf, v)
+		w.write(buf)
+	}
+	w.pad()
+}
+
+func (w *countingWriter) pad() {
+	if rem := w.n % 8; rem != 0 {
+		w.write(make([]byte, 8-rem))
+	}
+}
+
+// sectionReader casts sections of the snapshot to slices without copying.
+type sectionReader struct {
+	data []byte
+	pos  uint64
+	err  error
+}
+
+func (r *sectionReader) take(size uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	end := r.pos + size
+	if end > uint64(len(r.data)) || end < r.pos {
+		r.err = errors.New("snapshot is truncated")
+		return nil
+	}
+
+	section := r.data[r.pos:end]
+	r.pos = (end + 7) / 8 * 8
+
+	return section
+}
+
+func (r *sectionReader) uint64s(n uint64) []uint64 {
+	if n > uint64(len(r.data))/8 {
+		r.err = errors.New("snapshot is truncated")
+	}
+
+	section := r.take(n * 8)
+	if len(section) == 0 {
+		return []uint64{}
+	}
+
+	return unsafe.Slice((*uint64)(unsafe.Pointer(&section[0])), n)
+}
+
+func (r *sectionReader) uint32s(n uint64) []uint32 {
+	if n > uint64(len(r.data))/4 {
+		r.err = errors.New("snapshot is truncated")
+	}
+
+	section := r.take(n * 4)
+	if len(section) == 0 {
+		return []uint32{}
+	}
+
+	return unsafe.Slice((*uint32)(unsafe.Pointer(&section[0])), n)
+}
+
+func (r *sectionReader) bytes(n uint64) []byte {
+	return r.take(n)
+}
+
+func isLittleEndian() bool {
+	v := uint16(1)
+	return *(*byte)(unsafe.Pointer(&v)) == 1
+}
@@ -0,0 +1,159 @@
+package csrgraph
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testGraph() *Graph {
+	b := NewBuilder()
+	b.AddLinks("A", []string{"B", "C"})
+	b.AddLinks("B", []string{"C"})
+	b.AddLinks("C", nil)
+	b.AddPage("D")
+	b.AddRedirect("Alpha", "A")
+
+	return b.Build()
+}
+
+func TestSaveOpen(t *testing.T) {
+	g := testGraph()
+
+	path := filepath.Join(t.TempDir(), "graph.csr")
+	err := g.Save(path)
+	if err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := Open(path)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer loaded.Close()
+
+	if loaded.NodeCount() != g.NodeCount() || loaded.EdgeCount() != g.EdgeCount() {
+		t.Fatalf("got %d nodes and %d edges, want %d and %d",
+			loaded.NodeCount(), loaded.EdgeCount(), g.NodeCount(), g.EdgeCount())
+	}
+
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		if loaded.Title(id) != g.Title(id) {
+			t.Errorf("Title(%d) = %q, want %q", id, loaded.Title(id), g.Title(id))
+		}
+		if !reflect.DeepEqual(loaded.Neighbors(id), g.Neighbors(id)) {
+			t.Errorf("Neighbors(%d) = %v, want %v", id, loaded.Neighbors(id), g.Neighbors(id))
+		}
+		if loaded.HasLinks(id) != g.HasLinks(id) {
+			t.Errorf("HasLinks(%d) = %v, want %v", id, loaded.HasLinks(id), g.HasLinks(id))
+		}
+	}
+
+	if id, ok := loaded.Lookup("alpha"); !ok || loaded.Title(id) != "A" {
+		t.Errorf("Lookup(alpha) = %d, %v, want A", id, ok)
+	}
+}
+
+func TestParse_RejectsCorruptedSnapshots(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := testGraph().WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := buf.Bytes()
+
+	// Section positions of the test snapshot.
+	nodes := binary.LittleEndian.Uint64(snapshot[16:])
+	edges := binary.LittleEndian.Uint64(snapshot[24:])
+	names := binary.LittleEndian.Uint64(snapshot[32:])
+	padded := func(size uint64) uint64 { return (size + 7) / 8 * 8 }
+
+	offsetsAt := uint64(headerSize)
+	targetsAt := offsetsAt + (nodes+1)*8
+	nameOffsetsAt := targetsAt + padded(edges*4) + (nodes+63)/64*8
+	redirectsAt := nameOffsetsAt + (names+1)*8
+	sortedNamesAt := redirectsAt + padded((names-nodes)*4)
+
+	corruptions := map[string]func(data []byte) []byte{
+		"truncated": func(data []byte) []byte {
+			return data[:len(data)-16]
+		},
+		"huge node count": func(data []byte) []byte {
+			binary.LittleEndian.PutUint64(data[16:], 1<<61)
+			return data
+		},
+		"huge edge count": func(data []byte) []byte {
+			binary.LittleEndian.PutUint64(data[24:], 1<<62)
+			return data
+		},
+		"huge name bytes": func(data []byte) []byte {
+			binary.LittleEndian.PutUint64(data[40:], 1<<63)
+			return data
+		},
+		"decreasing link offsets": func(data []byte) []byte {
+			binary.LittleEndian.PutUint64(data[offsetsAt+16:], 0)
+			return data
+		},
+		"link target out of range": func(data []byte) []byte {
+			binary.LittleEndian.PutUint32(data[targetsAt:], uint32(nodes))
+			return data
+		},
+		"decreasing name offsets": func(data []byte) []byte {
+			binary.LittleEndian.PutUint64(data[nameOffsetsAt+16:], 0)
+			return data
+		},
+		"redirect target out of range": func(data []byte) []byte {
+			binary.LittleEndian.PutUint32(data[redirectsAt:], 1000)
+			return data
+		},
+		"sorted name out of range": func(data []byte) []byte {
+			binary.LittleEndian.PutUint32(data[sortedNamesAt:], uint32(names))
+			return data
+		},
+	}
+
+	for name, corrupt := range corruptions {
+		data := corrupt(append([]byte{}, snapshot...))
+		_, err := parse(data)
+		if err == nil {
+			t.Errorf("%s: corrupted snapshot was parsed", name)
+		}
+	}
+
+	_, err = parse(snapshot)
+	if err != nil {
+		t.Errorf("intact snapshot was rejected: %v", err)
+	}
+}
+
+func TestSaveOpen_FetchedAt(t *testing.T) {
+	fetchedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	b := NewBuilder()
+	b.AddLinks("A", []string{"B"})
+	b.SetFetchedAt(fetchedAt.Add(time.Hour))
+	b.SetFetchedAt(fetchedAt)
+	g := b.Build()
+
+	path := filepath.Join(t.TempDir(), "graph.csr")
+	err := g.Save(path)
+	if err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := Open(path)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer loaded.Close()
+
+	if !loaded.FetchedAt().Equal(fetchedAt) {
+		t.Errorf("FetchedAt() = %v, want %v", loaded.FetchedAt(), fetchedAt)
+	}
+	if !testGraph().FetchedAt().IsZero() {
+		t.Errorf("graph without the fetch time has one")
+	}
+}
@@ -0,0 +1,149 @@
+// Package csrgraph implements a read-only link graph in the compressed sparse row format.
+//
+// Pages are identified by dense IDs in [0, NodeCount()), links of page i are
+// targets[offsets[i]:offsets[i+1]]. Titles are kept in a single blob together with
+// redirect titles, and a case-insensitive index over them is used for lookups.
+// All arrays are flat, so a snapshot file can be memory-mapped instead of being decoded.
+package csrgraph
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Graph struct {
+	offsets []uint64
+	targets []uint32
+
+	// Bitset of pages with known links. Pages that were only seen as link targets
+	// have no links in the graph, but it doesn't mean they don't link anywhere.
+	// Nil means all links are known.
+	expanded []uint64
+
+	// Names [0, NodeCount()) are page titles, the rest are redirect titles.
+	nameOffsets []uint64
+	nameData    []byte
+
+	// Target page ID of every redirect name.
+	redirectTargets []uint32
+
+	// Name indices sorted by lowercased names.
+	sortedNames []uint32
+
+	// When the links were fetched, zero if unknown.
+	fetchedAt time.Time
+
+	// Releases the memory-mapped file, if any.
+	release func() error
+}
+
+func (g *Graph) NodeCount() int {
+	return len(g.offsets) - 1
+}
+
+func (g *Graph) EdgeCount() int {
+	return len(g.targets)
+}
+
+// Neighbors returns IDs of the pages linked from the given page. The slice must not be modified.
+func (g *Graph) Neighbors(id uint32) []uint32 {
+	return g.targets[g.offsets[id]:g.offsets[id+1]]
+}
+
+// HasLinks reports whether outgoing links of the page are known.
+func (g *Graph) HasLinks(id uint32) bool {
+	if g.expanded == nil {
+		return true
+	}
+
+	return g.expanded[id/64]&(1<<(id%64)) != 0
+}
+
+// HasAllLinks reports whether outgoing links of every page are known.
+func (g *Graph) HasAllLinks() bool {
+	if g.expanded == nil {
+		return true
+	}
+
+	for id := 0; id < g.NodeCount(); id += 64 {
+		word := g.expanded[id/64]
+		if rest := g.NodeCount() - id; rest < 64 {
+			word |= math.MaxUint64 << rest
+		}
+
+		if word != math.MaxUint64 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FetchedAt returns when links of the graph were fetched, or zero time if it's unknown.
+// If pages were fetched at different moments, the oldest one is returned.
+func (g *Graph) FetchedAt() time.Time {
+	return g.fetchedAt
+}
+
+// Title returns the title of the page with the given ID.
+func (g *Graph) Title(id uint32) string {
+	return g.name(id)
+}
+
+// Lookup returns the ID of the page with the given title.
+// Titles are matched case-insensitively, redirects are followed.
+func (g *Graph) Lookup(title string) (uint32, bool) {
+	key := strings.ToLower(title)
+
+	i := sort.Search(len(g.sortedNames), func(i int) bool {
+		return strings.ToLower(g.name(g.sortedNames[i])) >= key
+	})
+
+	// Several names may differ only in case, prefer the exact match and articles over redirects.
+	best, found := uint32(0), false
+	for ; i < len(g.sortedNames); i++ {
+		nameIdx := g.sortedNames[i]
+		name := g.name(nameIdx)
+		if strings.ToLower(name) != key {
+			break
+		}
+
+		id := g.resolve(nameIdx)
+		if name == title {
+			return id, true
+		}
+
+		if !found || (int(nameIdx) < g.NodeCount() && int(best) >= g.NodeCount()) {
+			best, found = nameIdx, true
+		}
+	}
+
+	if !found {
+		return 0, false
+	}
+
+	return g.resolve(best), true
+}
+
+// Close releases the memory-mapped snapshot. The graph must not be used afterwards.
+func (g *Graph) Close() error {
+	if g.release == nil {
+		return nil
+	}
+
+	return g.release()
+}
+
+func (g *Graph) name(idx uint32) string {
+	return string(g.nameData[g.nameOffsets[idx]:g.nameOffsets[idx+1]])
+}
+
+func (g *Graph) resolve(nameIdx uint32) uint32 {
+	if int(nameIdx) < g.NodeCount() {
+		return nameIdx
+	}
+
+	return g.redirectTargets[int(nameIdx)-g.NodeCount()]
+}
@@ -0,0 +1,19 @@
+package csrgraph
+
+import "testing"
+
+func TestHasAllLinks(t *testing.T) {
+	// D is only a link target in testGraph, so its links are unknown.
+	if testGraph().HasAllLinks() {
+		t.Error("graph with an unexpanded page reported as complete")
+	}
+
+	b := NewBuilder()
+	for i := 0; i < 70; i++ {
+		b.AddLinks(string(rune('a'+i)), nil)
+	}
+
+	if !b.Build().HasAllLinks() {
+		t.Error("graph with all pages expanded reported as incomplete")
+	}
+}
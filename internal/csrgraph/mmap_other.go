@@ -0,0 +1,30 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd
+
+package csrgraph
+
+import (
+	"os"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// mapFile reads the whole file on platforms without mmap support.
+func mapFile(path string) (data []byte, release func() error, err error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read file")
+	}
+
+	if len(raw) == 0 {
+		return nil, func() error { return nil }, nil
+	}
+
+	// Sections are cast to uint64 slices, so the buffer must be 8-byte aligned.
+	aligned := make([]uint64, (len(raw)+7)/8)
+	data = unsafe.Slice((*byte)(unsafe.Pointer(&aligned[0])), len(raw))
+	copy(data, raw)
+
+	return data, func() error { return nil }, nil
+}
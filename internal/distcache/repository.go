@@ -0,0 +1,94 @@
+// Package distcache caches results of distance queries, so repeated queries for a pair of pages are answered
+// without a search.
+package distcache
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Repository stores distances between pages. Pairs are matched case-insensitively.
+// A saved result never replaces a more informative one: known distances are kept,
+// and unknown ones are replaced only by results of deeper searches.
+type Repository interface {
+	// Get returns the latest distance between the pages. The caller decides whether ComputedAt is fresh enough.
+	Get(from, to string) (*Distance, error)
+	Save(distance *Distance) error
+}
+
+type Distance struct {
+	FromPage   string    `db:"from_page"`
+	ToPage     string    `db:"to_page"`
+	ComputedAt time.Time `db:"computed_at"`
+
+	// Nil if the target is not reachable within SearchedDistance.
+	Distance *int `db:"distance"`
+
+	// The search was limited by this distance.
+	SearchedDistance int `db:"searched_distance"`
+}
+
+// Answers reports whether the cached result answers a query limited by maxDistance.
+// Workers search at most threshold clicks, so it's the limit of queries with maxDistance 0 and of farther ones.
+// If threshold is 0 (unknown), only known distances answer queries without a limit.
+func (d *Distance) Answers(maxDistance, threshold int) bool {
+	if d.Distance != nil {
+		return true
+	}
+
+	limit := maxDistance
+	if limit == 0 || (threshold > 0 && threshold < limit) {
+		limit = threshold
+	}
+
+	return limit > 0 && limit <= d.SearchedDistance
+}
+
+type Repo struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Get(from, to string) (*Distance, error) {
+	distance := new(Distance)
+	err := r.db.Get(distance, `SELECT * FROM "page_distances" WHERE from_page = $1 AND to_page = $2`, key(from), key(to))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return distance, nil
+}
+
+func (r *Repo) Save(distance *Distance) error {
+	saved := *distance
+	saved.FromPage, saved.ToPage = key(distance.FromPage), key(distance.ToPage)
+
+	query := `INSERT INTO "page_distances" (from_page, to_page, computed_at, distance, searched_distance)
+							VALUES (:from_page, :to_page, :computed_at, :distance, :searched_distance)
+							ON CONFLICT (from_page, to_page) DO UPDATE SET computed_at = excluded.computed_at,
+								distance = excluded.distance, searched_distance = excluded.searched_distance
+							WHERE excluded.distance IS NOT NULL OR ("page_distances".distance IS NULL
+								AND excluded.searched_distance >= "page_distances".searched_distance)`
+	_, err := r.db.NamedExec(query, &saved)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func key(title string) string {
+	return strings.ToLower(title)
+}
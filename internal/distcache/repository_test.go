@@ -0,0 +1,31 @@
+package distcache
+
+import "testing"
+
+func TestDistance_Answers(t *testing.T) {
+	known := 3
+
+	tests := []struct {
+		name        string
+		distance    Distance
+		maxDistance int
+		threshold   int
+		want        bool
+	}{
+		{"known distance", Distance{Distance: &known, SearchedDistance: 3}, 0, 4, true},
+		{"limited search covers the query", Distance{SearchedDistance: 3}, 2, 4, true},
+		{"limited search doesn't cover the query", Distance{SearchedDistance: 2}, 3, 4, false},
+		{"limited search doesn't cover the worker limit", Distance{SearchedDistance: 2}, 0, 4, false},
+		{"search covers the worker limit", Distance{SearchedDistance: 4}, 0, 4, true},
+		{"query beyond the worker limit", Distance{SearchedDistance: 4}, 10, 4, true},
+		{"unknown worker limit", Distance{SearchedDistance: 6}, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.distance.Answers(tt.maxDistance, tt.threshold); got != tt.want {
+				t.Errorf("Answers(%d, %d) = %v, want %v", tt.maxDistance, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
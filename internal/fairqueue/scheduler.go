@@ -0,0 +1,204 @@
+// Package fairqueue shares a limited number of concurrent operations between flows
+// (e.g. BFS tasks) with weighted fair queuing: a flow with weight 2 gets twice
+// as many slots as a flow with weight 1 while both of them are waiting,
+// no matter how many operations each of them has queued.
+package fairqueue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+type flowKey struct{}
+
+type flow struct {
+	id     string
+	weight float64
+}
+
+// WithFlow marks operations started with the returned context as belonging to the given flow.
+// Weights must be positive. Operations without a flow belong to the default flow with weight 1.
+func WithFlow(ctx context.Context, id string, weight float64) context.Context {
+	return context.WithValue(ctx, flowKey{}, flow{id: id, weight: weight})
+}
+
+func flowFromContext(ctx context.Context) flow {
+	f, ok := ctx.Value(flowKey{}).(flow)
+	if !ok || f.weight <= 0 {
+		return flow{weight: 1}
+	}
+
+	return f
+}
+
+type Scheduler struct {
+	mu       sync.Mutex
+	capacity int
+	inFlight int
+
+	// Virtual time is the finish tag of the last admitted operation.
+	virtualTime float64
+
+	// Finish tag of the last queued operation of every flow with queued operations.
+	lastFinish map[string]float64
+	queued     map[string]int
+
+	waiting waitQueue
+	seq     uint64
+}
+
+// NewScheduler creates a scheduler that admits at most capacity operations at once.
+func NewScheduler(capacity int) *Scheduler {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Scheduler{
+		capacity:   capacity,
+		lastFinish: make(map[string]float64),
+		queued:     make(map[string]int),
+	}
+}
+
+// Acquire blocks until the operation is admitted and returns a function that must be called
+// once the operation is finished. A nil scheduler admits everything immediately.
+func (s *Scheduler) Acquire(ctx context.Context) (release func(), err error) {
+	if s == nil {
+		return func() {}, nil
+	}
+
+	f := flowFromContext(ctx)
+
+	s.mu.Lock()
+	if s.inFlight < s.capacity && len(s.waiting) == 0 {
+		s.inFlight++
+		s.mu.Unlock()
+
+		return s.release, nil
+	}
+
+	start := s.virtualTime
+	if last, ok := s.lastFinish[f.id]; ok && last > start {
+		start = last
+	}
+
+	w := &waiter{
+		flow:     f.id,
+		finish:   start + 1/f.weight,
+		seq:      s.seq,
+		admitted: make(chan struct{}),
+	}
+	s.seq++
+	s.lastFinish[f.id] = w.finish
+	s.queued[f.id]++
+	heap.Push(&s.waiting, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.admitted:
+		return s.release, nil
+
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if w.index < 0 {
+			// The operation has been admitted concurrently, give the slot back.
+			s.releaseLocked()
+		} else {
+			heap.Remove(&s.waiting, w.index)
+			s.dequeued(w.flow)
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// InFlight returns the number of admitted operations that are not finished yet.
+func (s *Scheduler) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.inFlight
+}
+
+// Waiting returns the number of queued operations.
+func (s *Scheduler) Waiting() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.waiting)
+}
+
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.releaseLocked()
+}
+
+// releaseLocked passes the freed slot to the waiter with the smallest finish tag.
+func (s *Scheduler) releaseLocked() {
+	if len(s.waiting) == 0 {
+		s.inFlight--
+		return
+	}
+
+	w := heap.Pop(&s.waiting).(*waiter)
+	s.virtualTime = w.finish
+	s.dequeued(w.flow)
+	close(w.admitted)
+}
+
+// dequeued forgets the flow when it has nothing queued, so an idle flow cannot save up credit.
+func (s *Scheduler) dequeued(flowID string) {
+	s.queued[flowID]--
+	if s.queued[flowID] == 0 {
+		delete(s.queued, flowID)
+		delete(s.lastFinish, flowID)
+	}
+}
+
+type waiter struct {
+	flow     string
+	finish   float64
+	seq      uint64
+	index    int
+	admitted chan struct{}
+}
+
+// waitQueue is a min-heap of waiters ordered by finish tags, ties are broken in FIFO order.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].finish != q[j].finish {
+		return q[i].finish < q[j].finish
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() interface{} {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*q = old[:len(old)-1]
+
+	return w
+}
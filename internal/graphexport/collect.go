@@ -0,0 +1,102 @@
+// Package graphexport moves the link graph cached by workers in and out of the cache.
+package graphexport
+
+import (
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+	"github.com/lodthe/wiki-graph/internal/linkcache"
+	"github.com/pkg/errors"
+)
+
+// FromCache collects the cached graph. If seeds are given, only the pages
+// within hops links from them are collected, otherwise the whole cache is exported.
+// The graph is marked as fetched when the oldest collected page was.
+func FromCache(cache linkcache.Repository, seeds []string, hops uint) (*csrgraph.Graph, error) {
+	b := csrgraph.NewBuilder()
+
+	if len(seeds) == 0 {
+		err := cache.ForEachPageLinks(func(links *linkcache.PageLinks) error {
+			b.AddLinks(links.PageTitle, links.Links)
+			b.SetFetchedAt(links.FetchedAt)
+
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the cache")
+		}
+
+		return b.Build(), nil
+	}
+
+	visited := make(map[string]struct{})
+	queue := make([]string, 0, len(seeds))
+	for _, seed := range seeds {
+		if _, exists := visited[seed]; exists {
+			continue
+		}
+
+		visited[seed] = struct{}{}
+		queue = append(queue, seed)
+		b.AddPage(seed)
+	}
+
+	for distance := uint(0); distance < hops && len(queue) != 0; distance++ {
+		var next []string
+		for _, title := range queue {
+			cached, err := cache.GetPageLinks(title)
+			if errors.Is(err, linkcache.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get links of %q", title)
+			}
+
+			b.AddLinks(title, cached.Links)
+			b.SetFetchedAt(cached.FetchedAt)
+
+			for _, link := range cached.Links {
+				if _, exists := visited[link]; exists {
+					continue
+				}
+
+				visited[link] = struct{}{}
+				next = append(next, link)
+			}
+		}
+
+		queue = next
+	}
+
+	return b.Build(), nil
+}
+
+// ImportSnapshot saves links of the pages with known links to the cache
+// as if they were fetched at the given moment. It returns the number of saved pages.
+func ImportSnapshot(g *csrgraph.Graph, cache linkcache.Repository, fetchedAt time.Time) (int, error) {
+	var imported int
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		if !g.HasLinks(id) {
+			continue
+		}
+
+		neighbors := g.Neighbors(id)
+		links := make([]string, 0, len(neighbors))
+		for _, neighbor := range neighbors {
+			links = append(links, g.Title(neighbor))
+		}
+
+		err := cache.SavePageLinks(&linkcache.PageLinks{
+			PageTitle: g.Title(id),
+			FetchedAt: fetchedAt,
+			Links:     links,
+		})
+		if err != nil {
+			return imported, errors.Wrapf(err, "failed to save links of %q", g.Title(id))
+		}
+
+		imported++
+	}
+
+	return imported, nil
+}
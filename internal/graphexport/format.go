@@ -0,0 +1,123 @@
+package graphexport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+	"github.com/pkg/errors"
+)
+
+type Format string
+
+const (
+	// FormatSnapshot is the versioned binary CSR snapshot, see csrgraph.
+	FormatSnapshot Format = "snapshot"
+
+	// FormatCSV is an edge list with the "from,to" header.
+	FormatCSV Format = "csv"
+
+	FormatGraphML Format = "graphml"
+	FormatDOT     Format = "dot"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatSnapshot, FormatCSV, FormatGraphML, FormatDOT:
+		return f, nil
+
+	default:
+		return "", fmt.Errorf("unknown format %q", s)
+	}
+}
+
+// Write writes the graph in the given format.
+func Write(w io.Writer, g *csrgraph.Graph, format Format) error {
+	switch format {
+	case FormatSnapshot:
+		_, err := g.WriteTo(w)
+		return err
+
+	case FormatCSV:
+		return writeCSV(w, g)
+
+	case FormatGraphML:
+		return writeGraphML(w, g)
+
+	case FormatDOT:
+		return writeDOT(w, g)
+
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func writeCSV(w io.Writer, g *csrgraph.Graph) error {
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"from", "to"})
+
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		for _, neighbor := range g.Neighbors(id) {
+			_ = out.Write([]string{g.Title(id), g.Title(neighbor)})
+		}
+	}
+
+	out.Flush()
+
+	return errors.Wrap(out.Error(), "write failed")
+}
+
+func writeGraphML(w io.Writer, g *csrgraph.Graph) error {
+	out := bufio.NewWriter(w)
+
+	_, _ = out.WriteString(xml.Header)
+	_, _ = out.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	_, _ = out.WriteString(`  <key id="title" for="node" attr.name="title" attr.type="string"/>` + "\n")
+	_, _ = out.WriteString(`  <graph id="wikigraph" edgedefault="directed">` + "\n")
+
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		_, _ = fmt.Fprintf(out, `    <node id="n%d"><data key="title">`, id)
+		_ = xml.EscapeText(out, []byte(g.Title(id)))
+		_, _ = out.WriteString("</data></node>\n")
+	}
+
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		for _, neighbor := range g.Neighbors(id) {
+			_, _ = fmt.Fprintf(out, `    <edge source="n%d" target="n%d"/>`+"\n", id, neighbor)
+		}
+	}
+
+	_, _ = out.WriteString("  </graph>\n</graphml>\n")
+
+	return errors.Wrap(out.Flush(), "write failed")
+}
+
+func writeDOT(w io.Writer, g *csrgraph.Graph) error {
+	out := bufio.NewWriter(w)
+
+	_, _ = out.WriteString("digraph wikigraph {\n")
+
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		_, _ = fmt.Fprintf(out, "  n%d [label=%s];\n", id, dotQuote(g.Title(id)))
+	}
+
+	for id := uint32(0); int(id) < g.NodeCount(); id++ {
+		for _, neighbor := range g.Neighbors(id) {
+			_, _ = fmt.Fprintf(out, "  n%d -> n%d;\n", id, neighbor)
+		}
+	}
+
+	_, _ = out.WriteString("}\n")
+
+	return errors.Wrap(out.Flush(), "write failed")
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ")
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
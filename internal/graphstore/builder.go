@@ -0,0 +1,140 @@
+package graphstore
+
+// Builder collects pages and links in the order they appear in Wikipedia dumps:
+// pages first, then redirects and link targets, then links.
+type Builder struct {
+	store *Store
+
+	// Exact title -> page ID.
+	pageIDs       map[string]uint32
+	redirectPages map[uint32]struct{}
+
+	// Link target ID -> page ID, see the linktarget table.
+	linkTargets map[uint64]uint32
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		store:         newStore(),
+		pageIDs:       make(map[string]uint32),
+		redirectPages: make(map[uint32]struct{}),
+		linkTargets:   make(map[uint64]uint32),
+	}
+}
+
+func (b *Builder) AddPage(id uint32, title string, isRedirect bool) {
+	b.store.titles[id] = title
+	b.pageIDs[title] = id
+	if isRedirect {
+		b.redirectPages[id] = struct{}{}
+	}
+}
+
+func (b *Builder) AddRedirect(from uint32, targetTitle string) {
+	if _, isRedirect := b.redirectPages[from]; !isRedirect {
+		return
+	}
+
+	target, ok := b.pageIDs[targetTitle]
+	if !ok || target == from {
+		return
+	}
+
+	b.store.redirects[from] = target
+}
+
+func (b *Builder) AddLinkTarget(id uint64, title string) {
+	page, ok := b.pageIDs[title]
+	if !ok {
+		return
+	}
+
+	b.linkTargets[id] = page
+}
+
+// AddLink adds a link from the page to the page with the given title.
+// Links from redirects and links to missing pages are skipped.
+func (b *Builder) AddLink(from uint32, targetTitle string) {
+	target, ok := b.pageIDs[targetTitle]
+	if !ok {
+		return
+	}
+
+	b.addLink(from, target)
+}
+
+// AddLinkToTarget is the same as AddLink, but the target is referenced by a link target ID.
+func (b *Builder) AddLinkToTarget(from uint32, linkTargetID uint64) {
+	target, ok := b.linkTargets[linkTargetID]
+	if !ok {
+		return
+	}
+
+	b.addLink(from, target)
+}
+
+func (b *Builder) addLink(from, to uint32) {
+	if _, exists := b.store.titles[from]; !exists {
+		return
+	}
+	if _, isRedirect := b.redirectPages[from]; isRedirect {
+		return
+	}
+
+	b.store.links[from] = append(b.store.links[from], to)
+}
+
+// Build resolves redirects, removes duplicate links and returns the store.
+// The builder must not be used afterwards.
+func (b *Builder) Build() *Store {
+	s := b.store
+
+	// Redirects without a known target are useless for the search.
+	for id := range b.redirectPages {
+		if _, ok := s.redirects[id]; !ok {
+			delete(s.titles, id)
+		}
+	}
+
+	for from, targets := range s.links {
+		resolved := targets[:0]
+		for _, target := range targets {
+			target, ok := s.resolve(target)
+			if !ok || target == from {
+				continue
+			}
+			if _, exists := s.titles[target]; !exists {
+				continue
+			}
+
+			resolved = append(resolved, target)
+		}
+
+		s.links[from] = dedup(resolved)
+		if len(s.links[from]) == 0 {
+			delete(s.links, from)
+		}
+	}
+
+	s.index()
+
+	b.store = nil
+	b.pageIDs = nil
+	b.redirectPages = nil
+	b.linkTargets = nil
+
+	return s
+}
+
+func dedup(ids []uint32) []uint32 {
+	sortIDs(ids)
+
+	result := ids[:0]
+	for _, id := range ids {
+		if len(result) == 0 || result[len(result)-1] != id {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
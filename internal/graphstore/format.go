@@ -0,0 +1,194 @@
+package graphstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const formatVersion = 1
+
+var magic = []byte("WGSTORE\x00")
+
+// Save writes the store to the file.
+//
+// All numbers are uvarint-encoded: the header (magic and version) is followed by
+// pages (ID delta and title), redirects (from and to) and adjacency lists (page ID delta
+// and deltas of the sorted target IDs).
+func (s *Store) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	defer f.Close()
+
+	err = s.write(bufio.NewWriterSize(f, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// Load reads the store saved by Save.
+func Load(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+
+	return read(bufio.NewReaderSize(f, 1<<20), info.Size())
+}
+
+func (s *Store) write(w *bufio.Writer) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	put := func(v uint64) {
+		n := binary.PutUvarint(buf, v)
+		_, _ = w.Write(buf[:n])
+	}
+
+	_, _ = w.Write(magic)
+	put(formatVersion)
+
+	var prev uint32
+	put(uint64(len(s.titles)))
+	for _, id := range sortedIDs(s.titles) {
+		put(uint64(id - prev))
+		prev = id
+
+		put(uint64(len(s.titles[id])))
+		_, _ = w.WriteString(s.titles[id])
+	}
+
+	redirectSources := make([]uint32, 0, len(s.redirects))
+	for from := range s.redirects {
+		redirectSources = append(redirectSources, from)
+	}
+	sortIDs(redirectSources)
+
+	put(uint64(len(s.redirects)))
+	for _, from := range redirectSources {
+		put(uint64(from))
+		put(uint64(s.redirects[from]))
+	}
+
+	linkSources := make([]uint32, 0, len(s.links))
+	for from := range s.links {
+		linkSources = append(linkSources, from)
+	}
+	sortIDs(linkSources)
+
+	prev = 0
+	put(uint64(len(s.links)))
+	for _, id := range linkSources {
+		put(uint64(id - prev))
+		prev = id
+
+		targets := s.links[id]
+		put(uint64(len(targets)))
+
+		var prevTarget uint32
+		for _, target := range targets {
+			put(uint64(target - prevTarget))
+			prevTarget = target
+		}
+	}
+
+	// bufio.Writer keeps the first error, so it's enough to check it once.
+	return errors.Wrap(w.Flush(), "write failed")
+}
+
+// read parses the store of the given size. Every encoded item takes at least one byte,
+// so lengths over the size are rejected before anything is allocated for them.
+func read(r *bufio.Reader, size int64) (*Store, error) {
+	header := make([]byte, len(magic))
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read header")
+	}
+	if !bytes.Equal(header, magic) {
+		return nil, errors.New("not a graph store file")
+	}
+
+	var readErr error
+	get := func() uint32 {
+		if readErr != nil {
+			return 0
+		}
+
+		var v uint64
+		v, readErr = binary.ReadUvarint(r)
+
+		return uint32(v)
+	}
+
+	getLength := func() uint32 {
+		n := get()
+		if readErr == nil && int64(n) > size {
+			readErr = errors.Errorf("length %d exceeds the file size", n)
+		}
+
+		return n
+	}
+
+	version := get()
+	if readErr == nil && version != formatVersion {
+		return nil, fmt.Errorf("unsupported format version %d", version)
+	}
+
+	s := newStore()
+
+	var id uint32
+	pageCount := getLength()
+	for i := uint32(0); i < pageCount && readErr == nil; i++ {
+		id += get()
+
+		title := make([]byte, getLength())
+		if readErr == nil {
+			_, readErr = io.ReadFull(r, title)
+		}
+
+		s.titles[id] = string(title)
+	}
+
+	redirectCount := getLength()
+	for i := uint32(0); i < redirectCount && readErr == nil; i++ {
+		from := get()
+		s.redirects[from] = get()
+	}
+
+	id = 0
+	sourceCount := getLength()
+	for i := uint32(0); i < sourceCount && readErr == nil; i++ {
+		id += get()
+
+		targets := make([]uint32, getLength())
+
+		var target uint32
+		for j := range targets {
+			target += get()
+			targets[j] = target
+		}
+
+		s.links[id] = targets
+	}
+
+	if readErr != nil {
+		return nil, errors.Wrap(readErr, "failed to read store")
+	}
+
+	s.index()
+
+	return s, nil
+}
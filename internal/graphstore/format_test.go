@@ -0,0 +1,53 @@
+package graphstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	store := importFixtures(t)
+
+	path := filepath.Join(t.TempDir(), "graph.bin")
+	err := store.Save(path)
+	if err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded.titles, store.titles) {
+		t.Errorf("titles = %v, want %v", loaded.titles, store.titles)
+	}
+	if !reflect.DeepEqual(loaded.redirects, store.redirects) {
+		t.Errorf("redirects = %v, want %v", loaded.redirects, store.redirects)
+	}
+	if !reflect.DeepEqual(loaded.links, store.links) {
+		t.Errorf("links = %v, want %v", loaded.links, store.links)
+	}
+	if id, ok := loaded.Lookup("golang"); !ok || id != 1 {
+		t.Errorf("Lookup(golang) = %d, %v, want 1", id, ok)
+	}
+}
+
+func TestLoad_RejectsLengthsOverFileSize(t *testing.T) {
+	data := append([]byte{}, magic...)
+	buf := make([]byte, binary.MaxVarintLen64)
+	// Version, one page with ID 1 and a title that can't fit in the file.
+	for _, v := range []uint64{formatVersion, 1, 1, 1 << 31} {
+		n := binary.PutUvarint(buf, v)
+		data = append(data, buf[:n]...)
+	}
+
+	_, err := read(bufio.NewReader(bytes.NewReader(data)), int64(len(data)))
+	if err == nil {
+		t.Fatal("corrupted store was loaded")
+	}
+}
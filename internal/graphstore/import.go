@@ -0,0 +1,149 @@
+package graphstore
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/wikidump"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Only articles are imported.
+const mainNamespace = "0"
+
+// DumpFiles are paths to the SQL dumps, e.g. enwiki-latest-page.sql.gz.
+type DumpFiles struct {
+	Page     string
+	Redirect string
+
+	// LinkTarget is required for dumps where pagelinks reference targets by pl_target_id.
+	LinkTarget string
+	PageLinks  string
+}
+
+// ImportDumps builds a store from the SQL dumps. Files are streamed, so only the resulting graph is kept in memory.
+func ImportDumps(files DumpFiles) (*Store, error) {
+	b := NewBuilder()
+
+	steps := []struct {
+		table  string
+		path   string
+		handle func(row wikidump.Row) error
+	}{
+		{table: "page", path: files.Page, handle: b.handlePageRow},
+		{table: "redirect", path: files.Redirect, handle: b.handleRedirectRow},
+		{table: "linktarget", path: files.LinkTarget, handle: b.handleLinkTargetRow},
+		{table: "pagelinks", path: files.PageLinks, handle: b.handlePageLinksRow},
+	}
+
+	for _, step := range steps {
+		if step.path == "" {
+			continue
+		}
+
+		startedAt := time.Now()
+		err := wikidump.ReadFile(step.path, step.table, step.handle)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to import %s", step.table)
+		}
+
+		zlog.Info().Str("table", step.table).Dur("elapsed", time.Since(startedAt)).Msg("dump imported")
+	}
+
+	return b.Build(), nil
+}
+
+// page: page_id, page_namespace, page_title, [page_restrictions,] page_is_redirect, ...
+// page_restrictions was dropped in MediaWiki 1.41, so older dumps have 13 columns.
+func (b *Builder) handlePageRow(row wikidump.Row) error {
+	if len(row) < 4 || row[1] != mainNamespace {
+		return nil
+	}
+
+	id, err := row.Int(0)
+	if err != nil {
+		return errors.Wrap(err, "invalid page_id")
+	}
+
+	isRedirectColumn := 3
+	if len(row) >= 13 {
+		isRedirectColumn = 4
+	}
+
+	b.AddPage(uint32(id), dumpTitle(row[2]), row[isRedirectColumn] == "1")
+
+	return nil
+}
+
+// redirect: rd_from, rd_namespace, rd_title, rd_interwiki, rd_fragment.
+func (b *Builder) handleRedirectRow(row wikidump.Row) error {
+	if len(row) < 3 || row[1] != mainNamespace {
+		return nil
+	}
+	if len(row) > 3 && row[3] != "" {
+		return nil
+	}
+
+	from, err := row.Int(0)
+	if err != nil {
+		return errors.Wrap(err, "invalid rd_from")
+	}
+
+	b.AddRedirect(uint32(from), dumpTitle(row[2]))
+
+	return nil
+}
+
+// linktarget: lt_id, lt_namespace, lt_title.
+func (b *Builder) handleLinkTargetRow(row wikidump.Row) error {
+	if len(row) < 3 || row[1] != mainNamespace {
+		return nil
+	}
+
+	id, err := row.Int(0)
+	if err != nil {
+		return errors.Wrap(err, "invalid lt_id")
+	}
+
+	b.AddLinkTarget(uint64(id), dumpTitle(row[2]))
+
+	return nil
+}
+
+// pagelinks: pl_from, pl_from_namespace, pl_target_id in the current schema
+// or pl_from, pl_namespace, pl_title, pl_from_namespace[, pl_target_id] in the older ones.
+func (b *Builder) handlePageLinksRow(row wikidump.Row) error {
+	from, err := row.Int(0)
+	if err != nil {
+		return errors.Wrap(err, "invalid pl_from")
+	}
+
+	if len(row) == 3 {
+		if row[1] != mainNamespace {
+			return nil
+		}
+
+		target, err := row.Int(2)
+		if err != nil {
+			return errors.Wrap(err, "invalid pl_target_id")
+		}
+
+		b.AddLinkToTarget(uint32(from), uint64(target))
+
+		return nil
+	}
+
+	if len(row) < 4 || row[1] != mainNamespace || row[3] != mainNamespace {
+		return nil
+	}
+
+	b.AddLink(uint32(from), dumpTitle(row[2]))
+
+	return nil
+}
+
+// Dumps store titles with underscores instead of spaces.
+func dumpTitle(title string) string {
+	return strings.ReplaceAll(title, "_", " ")
+}
@@ -0,0 +1,85 @@
+package graphstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func importFixtures(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := ImportDumps(DumpFiles{
+		Page:       "testdata/page.sql.gz",
+		Redirect:   "testdata/redirect.sql.gz",
+		LinkTarget: "testdata/linktarget.sql.gz",
+		PageLinks:  "testdata/pagelinks.sql.gz",
+	})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	return store
+}
+
+func TestImportDumps(t *testing.T) {
+	store := importFixtures(t)
+
+	if store.PageCount() != 4 || store.RedirectCount() != 1 {
+		t.Fatalf("got %d pages and %d redirects, want 4 and 1", store.PageCount(), store.RedirectCount())
+	}
+
+	lookups := map[string]uint32{
+		"Go (programming language)": 1,
+		"python":                    2,
+		"Golang":                    1,
+		"RUST":                      4,
+	}
+	for title, want := range lookups {
+		id, ok := store.Lookup(title)
+		if !ok || id != want {
+			t.Errorf("Lookup(%q) = %d, %v, want %d", title, id, ok, want)
+		}
+	}
+
+	if _, ok := store.Lookup("Missing"); ok {
+		t.Errorf("Lookup of a missing page succeeded")
+	}
+
+	links := map[uint32][]uint32{
+		1: {2, 4},
+		2: {1},
+		4: nil,
+	}
+	for id, want := range links {
+		got := store.OutgoingLinks(id)
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("OutgoingLinks(%d) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestImportDumps_OldPageLinksSchema(t *testing.T) {
+	b := NewBuilder()
+	b.AddPage(1, "A", false)
+	b.AddPage(2, "B", false)
+
+	rows := [][]string{
+		{"1", "0", "B", "0"},
+		{"1", "0", "C", "0"},
+		{"2", "0", "A", "1"},
+	}
+	for _, row := range rows {
+		err := b.handlePageLinksRow(row)
+		if err != nil {
+			t.Fatalf("handlePageLinksRow(%v): %v", row, err)
+		}
+	}
+
+	store := b.Build()
+	if got := store.OutgoingLinks(1); !reflect.DeepEqual(got, []uint32{2}) {
+		t.Errorf("OutgoingLinks(1) = %v, want [2]", got)
+	}
+	if got := store.OutgoingLinks(2); len(got) != 0 {
+		t.Errorf("OutgoingLinks(2) = %v, want none", got)
+	}
+}
@@ -0,0 +1,157 @@
+// Package graphstore keeps a compact read-only copy of the Wikipedia link graph keyed by page ID.
+package graphstore
+
+import (
+	"sort"
+	"strings"
+)
+
+// Maximum number of redirects followed while resolving a title.
+const maxRedirectHops = 5
+
+type Store struct {
+	titles map[uint32]string
+
+	// Lowercased title -> page ID. Lookups are case-insensitive,
+	// because the BFS algorithm normalizes titles to lower case.
+	ids map[string]uint32
+
+	redirects map[uint32]uint32
+
+	// Page ID -> sorted IDs of the linked pages.
+	links map[uint32][]uint32
+}
+
+func newStore() *Store {
+	return &Store{
+		titles:    make(map[uint32]string),
+		ids:       make(map[string]uint32),
+		redirects: make(map[uint32]uint32),
+		links:     make(map[uint32][]uint32),
+	}
+}
+
+func (s *Store) PageCount() int {
+	return len(s.titles)
+}
+
+func (s *Store) RedirectCount() int {
+	return len(s.redirects)
+}
+
+func (s *Store) LinkCount() int {
+	var count int
+	for _, targets := range s.links {
+		count += len(targets)
+	}
+
+	return count
+}
+
+// Title returns the title of the page with the given ID.
+func (s *Store) Title(id uint32) (string, bool) {
+	title, ok := s.titles[id]
+	return title, ok
+}
+
+// Lookup returns the ID of the page with the given title. Redirects are followed.
+func (s *Store) Lookup(title string) (uint32, bool) {
+	id, ok := s.ids[strings.ToLower(title)]
+	if !ok {
+		return 0, false
+	}
+
+	return s.resolve(id)
+}
+
+// OutgoingLinks returns IDs of the pages linked from the given page.
+// Links to redirects are already resolved to their targets.
+func (s *Store) OutgoingLinks(id uint32) []uint32 {
+	return s.links[id]
+}
+
+// OutgoingTitles returns titles of the pages linked from the page with the given title.
+func (s *Store) OutgoingTitles(title string) ([]string, bool) {
+	id, ok := s.Lookup(title)
+	if !ok {
+		return nil, false
+	}
+
+	targets := s.links[id]
+	titles := make([]string, 0, len(targets))
+	for _, target := range targets {
+		titles = append(titles, s.titles[target])
+	}
+
+	return titles, true
+}
+
+// ForEachPage calls fn for every page, including redirects, in the order of IDs.
+func (s *Store) ForEachPage(fn func(id uint32, title string)) {
+	for _, id := range sortedIDs(s.titles) {
+		fn(id, s.titles[id])
+	}
+}
+
+// IsRedirect reports whether the page is a redirect.
+func (s *Store) IsRedirect(id uint32) bool {
+	_, isRedirect := s.redirects[id]
+	return isRedirect
+}
+
+// ResolveRedirect returns the page the given page redirects to.
+// Redirect chains are followed, the page itself is returned if it's not a redirect.
+func (s *Store) ResolveRedirect(id uint32) (uint32, bool) {
+	return s.resolve(id)
+}
+
+func (s *Store) resolve(id uint32) (uint32, bool) {
+	for i := 0; i < maxRedirectHops; i++ {
+		target, isRedirect := s.redirects[id]
+		if !isRedirect {
+			return id, true
+		}
+
+		id = target
+	}
+
+	return 0, false
+}
+
+// index builds the title index. It must be called once titles and redirects are filled.
+func (s *Store) index() {
+	s.ids = make(map[string]uint32, len(s.titles))
+
+	for _, id := range sortedIDs(s.titles) {
+		key := strings.ToLower(s.titles[id])
+		existing, collides := s.ids[key]
+		if !collides {
+			s.ids[key] = id
+			continue
+		}
+
+		// Titles are case-sensitive in Wikipedia, prefer articles over redirects on collisions.
+		_, existingIsRedirect := s.redirects[existing]
+		_, isRedirect := s.redirects[id]
+		if existingIsRedirect && !isRedirect {
+			s.ids[key] = id
+		}
+	}
+}
+
+func sortedIDs(m map[uint32]string) []uint32 {
+	ids := make([]uint32, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+
+	sortIDs(ids)
+
+	return ids
+}
+
+func sortIDs(ids []uint32) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+}
@@ -0,0 +1,150 @@
+package hubrank
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+)
+
+// The context is checked once per this many pages.
+const ctxCheckInterval = 1 << 16
+
+// InDegrees returns the number of links to every page.
+func InDegrees(g *csrgraph.Graph) []uint32 {
+	degrees := make([]uint32, g.NodeCount())
+	for id := 0; id < g.NodeCount(); id++ {
+		for _, target := range g.Neighbors(uint32(id)) {
+			degrees[target]++
+		}
+	}
+
+	return degrees
+}
+
+// PageRank runs the power iteration for the given number of iterations. Pages without links,
+// including the ones whose links are unknown, distribute their rank evenly among all pages.
+func PageRank(ctx context.Context, g *csrgraph.Graph, iterations int, damping float64) ([]float64, error) {
+	n := g.NodeCount()
+	if n == 0 {
+		return nil, nil
+	}
+
+	ranks := make([]float64, n)
+	next := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+
+	for iteration := 0; iteration < iterations; iteration++ {
+		var dangling float64
+		for i := range next {
+			next[i] = 0
+		}
+
+		for id := 0; id < n; id++ {
+			if id%ctxCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+
+			neighbors := g.Neighbors(uint32(id))
+			if len(neighbors) == 0 {
+				dangling += ranks[id]
+				continue
+			}
+
+			share := ranks[id] / float64(len(neighbors))
+			for _, target := range neighbors {
+				next[target] += share
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for i := range next {
+			next[i] = base + damping*next[i]
+		}
+
+		ranks, next = next, ranks
+	}
+
+	return ranks, nil
+}
+
+// Betweenness approximates the betweenness centrality with Brandes' algorithm run from samples random pages.
+// The result is scaled to the whole graph. If samples is not less than the number of pages, it's exact.
+func Betweenness(ctx context.Context, g *csrgraph.Graph, samples int, seed int64) ([]float64, error) {
+	n := g.NodeCount()
+	centrality := make([]float64, n)
+	if n == 0 || samples <= 0 {
+		return centrality, nil
+	}
+
+	sources := make([]uint32, n)
+	for i := range sources {
+		sources[i] = uint32(i)
+	}
+	if samples < n {
+		random := rand.New(rand.NewSource(seed))
+		random.Shuffle(n, func(i, j int) {
+			sources[i], sources[j] = sources[j], sources[i]
+		})
+		sources = sources[:samples]
+	}
+
+	distances := make([]int32, n)
+	paths := make([]float64, n)
+	dependencies := make([]float64, n)
+	for i := range distances {
+		distances[i] = -1
+	}
+
+	var order []uint32
+	for _, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Count the shortest paths from the source in the BFS order.
+		order = append(order[:0], source)
+		distances[source], paths[source] = 0, 1
+		for head := 0; head < len(order); head++ {
+			page := order[head]
+			for _, neighbor := range g.Neighbors(page) {
+				if distances[neighbor] < 0 {
+					distances[neighbor] = distances[page] + 1
+					order = append(order, neighbor)
+				}
+				if distances[neighbor] == distances[page]+1 {
+					paths[neighbor] += paths[page]
+				}
+			}
+		}
+
+		// Accumulate the dependencies in the reverse order, so every page is processed after its successors.
+		for i := len(order) - 1; i >= 0; i-- {
+			page := order[i]
+			for _, neighbor := range g.Neighbors(page) {
+				if distances[neighbor] == distances[page]+1 {
+					dependencies[page] += paths[page] / paths[neighbor] * (1 + dependencies[neighbor])
+				}
+			}
+
+			if page != source {
+				centrality[page] += dependencies[page]
+			}
+		}
+
+		for _, page := range order {
+			distances[page], paths[page], dependencies[page] = -1, 0, 0
+		}
+	}
+
+	scale := float64(n) / float64(len(sources))
+	for i := range centrality {
+		centrality[i] *= scale
+	}
+
+	return centrality, nil
+}
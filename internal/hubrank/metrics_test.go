@@ -0,0 +1,134 @@
+package hubrank
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+)
+
+// buildGraph builds a graph from the links, pages are added in the order of titles.
+func buildGraph(titles []string, links map[string][]string) *csrgraph.Graph {
+	b := csrgraph.NewBuilder()
+	for _, title := range titles {
+		b.AddPage(title)
+	}
+	for _, title := range titles {
+		b.AddLinks(title, links[title])
+	}
+
+	return b.Build()
+}
+
+// byTitle maps the values of the pages to their titles.
+func byTitle(g *csrgraph.Graph, values []float64) map[string]float64 {
+	result := make(map[string]float64, len(values))
+	for id, value := range values {
+		result[g.Title(uint32(id))] = value
+	}
+
+	return result
+}
+
+func checkValues(t *testing.T, got, want map[string]float64) {
+	t.Helper()
+
+	for title, value := range want {
+		if math.Abs(got[title]-value) > 1e-9 {
+			t.Errorf("%s: got %.10f, want %.10f", title, got[title], value)
+		}
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	tests := []struct {
+		name   string
+		titles []string
+		links  map[string][]string
+		want   map[string]float64
+	}{
+		{
+			name:   "cycle",
+			titles: []string{"A", "B", "C"},
+			links:  map[string][]string{"A": {"B"}, "B": {"C"}, "C": {"A"}},
+			want:   map[string]float64{"A": 1.0 / 3, "B": 1.0 / 3, "C": 1.0 / 3},
+		},
+		{
+			// B has no links and gives its rank to both pages: a = 0.15/2 + 0.85*b/2, b = a + 0.85*a.
+			name:   "dangling page",
+			titles: []string{"A", "B"},
+			links:  map[string][]string{"A": {"B"}},
+			want:   map[string]float64{"A": 1 / 2.85, "B": 1.85 / 2.85},
+		},
+		{
+			// hub = 0.15/4 + 0.85*3*leaf, leaf = 0.15/4 + 0.85*hub/3, so hub = 0.0375*3.55/0.2775.
+			name:   "star",
+			titles: []string{"Hub", "X", "Y", "Z"},
+			links:  map[string][]string{"X": {"Hub"}, "Y": {"Hub"}, "Z": {"Hub"}, "Hub": {"X", "Y", "Z"}},
+			want: map[string]float64{
+				"Hub": 0.0375 * 3.55 / 0.2775,
+				"X":   (1 - 0.0375*3.55/0.2775) / 3,
+				"Y":   (1 - 0.0375*3.55/0.2775) / 3,
+				"Z":   (1 - 0.0375*3.55/0.2775) / 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildGraph(tt.titles, tt.links)
+
+			ranks, err := PageRank(context.Background(), g, 200, 0.85)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkValues(t, byTitle(g, ranks), tt.want)
+		})
+	}
+}
+
+func TestBetweenness_Exact(t *testing.T) {
+	tests := []struct {
+		name   string
+		titles []string
+		links  map[string][]string
+		want   map[string]float64
+	}{
+		{
+			// B lies on A->C and A->D, C lies on A->D and B->D.
+			name:   "path",
+			titles: []string{"A", "B", "C", "D"},
+			links:  map[string][]string{"A": {"B"}, "B": {"C"}, "C": {"D"}},
+			want:   map[string]float64{"A": 0, "B": 2, "C": 2, "D": 0},
+		},
+		{
+			// Every ordered pair of the 3 leaves is connected through the center.
+			name:   "star",
+			titles: []string{"Hub", "X", "Y", "Z"},
+			links:  map[string][]string{"X": {"Hub"}, "Y": {"Hub"}, "Z": {"Hub"}, "Hub": {"X", "Y", "Z"}},
+			want:   map[string]float64{"Hub": 6, "X": 0, "Y": 0, "Z": 0},
+		},
+		{
+			// The two shortest paths from A to D split the dependency.
+			name:   "diamond",
+			titles: []string{"A", "B", "C", "D"},
+			links:  map[string][]string{"A": {"B", "C"}, "B": {"D"}, "C": {"D"}},
+			want:   map[string]float64{"A": 0, "B": 0.5, "C": 0.5, "D": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := buildGraph(tt.titles, tt.links)
+
+			centrality, err := Betweenness(context.Background(), g, len(tt.titles), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkValues(t, byTitle(g, centrality), tt.want)
+		})
+	}
+}
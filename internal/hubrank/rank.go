@@ -0,0 +1,121 @@
+// Package hubrank finds the hub pages of the link graph: the pages most shortest paths go through.
+// Pages are ranked offline by PageRank, in-degree or approximate betweenness, and the rankings
+// are used by the search to avoid hubs or to break ties between paths of the same length.
+package hubrank
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+	"github.com/pkg/errors"
+)
+
+// Metric is the measure pages are ranked by.
+type Metric string
+
+const (
+	MetricPageRank    Metric = "pagerank"
+	MetricInDegree    Metric = "in_degree"
+	MetricBetweenness Metric = "betweenness"
+)
+
+func ParseMetric(s string) (Metric, error) {
+	switch metric := Metric(s); metric {
+	case MetricPageRank, MetricInDegree, MetricBetweenness:
+		return metric, nil
+
+	default:
+		return "", errors.Errorf("unknown metric %q, expected pagerank, in_degree or betweenness", s)
+	}
+}
+
+type Config struct {
+	// Pages are ranked by this metric, and only the Top pages are kept.
+	By  Metric
+	Top int
+
+	// Parameters of the power iteration.
+	PageRankIterations int
+	Damping            float64
+
+	// Number of source pages betweenness is estimated from, and the seed they are sampled with.
+	BetweennessSamples int
+	Seed               int64
+}
+
+// Ranking is the place of a page among the hubs. Rank 1 is the biggest hub.
+type Ranking struct {
+	PageKey   string `db:"page_key"`
+	PageTitle string `db:"page_title"`
+
+	Rank  int     `db:"rank"`
+	Score float64 `db:"score"`
+
+	PageRank    float64 `db:"pagerank"`
+	InDegree    int     `db:"in_degree"`
+	Betweenness float64 `db:"betweenness"`
+
+	ComputedAt time.Time `db:"computed_at"`
+}
+
+// Compute ranks the pages of the graph and returns the top ones, the biggest hub first.
+// Score is the value of the metric the pages are ranked by.
+func Compute(ctx context.Context, g *csrgraph.Graph, cfg Config) ([]Ranking, error) {
+	inDegrees := InDegrees(g)
+
+	pageRanks, err := PageRank(ctx, g, cfg.PageRankIterations, cfg.Damping)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute PageRank")
+	}
+
+	betweenness, err := Betweenness(ctx, g, cfg.BetweennessSamples, cfg.Seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute betweenness")
+	}
+
+	score := func(id uint32) float64 {
+		switch cfg.By {
+		case MetricInDegree:
+			return float64(inDegrees[id])
+		case MetricBetweenness:
+			return betweenness[id]
+		default:
+			return pageRanks[id]
+		}
+	}
+
+	ids := make([]uint32, g.NodeCount())
+	for i := range ids {
+		ids[i] = uint32(i)
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		return score(ids[i]) > score(ids[j])
+	})
+
+	if cfg.Top > 0 && len(ids) > cfg.Top {
+		ids = ids[:cfg.Top]
+	}
+
+	computedAt := time.Now()
+	rankings := make([]Ranking, 0, len(ids))
+	for i, id := range ids {
+		title := g.Title(id)
+
+		rankings = append(rankings, Ranking{
+			PageKey:     strings.ToLower(title),
+			PageTitle:   title,
+			Rank:        i + 1,
+			Score:       score(id),
+			PageRank:    pageRanks[id],
+			InDegree:    int(inDegrees[id]),
+			Betweenness: betweenness[id],
+			ComputedAt:  computedAt,
+		})
+	}
+
+	return rankings, nil
+}
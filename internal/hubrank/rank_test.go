@@ -0,0 +1,55 @@
+package hubrank
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	// In-degrees: C 3, B 2, the rest 0. Only the shortest path from E to C goes through another page, B.
+	g := buildGraph([]string{"A", "B", "C", "D", "E"}, map[string][]string{
+		"A": {"B", "C"},
+		"B": {"C"},
+		"D": {"C"},
+		"E": {"B"},
+	})
+
+	tests := []struct {
+		name   string
+		cfg    Config
+		titles []string
+	}{
+		{"top pages", Config{By: MetricInDegree, Top: 2}, []string{"C", "B"}},
+		{"all pages", Config{By: MetricInDegree}, []string{"C", "B", "A", "D", "E"}},
+		{"more than the pages", Config{By: MetricInDegree, Top: 10}, []string{"C", "B", "A", "D", "E"}},
+		{"pagerank", Config{By: MetricPageRank, Top: 2, PageRankIterations: 50, Damping: 0.85}, []string{"C", "B"}},
+		{"betweenness", Config{By: MetricBetweenness, Top: 1, BetweennessSamples: 5}, []string{"B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankings, err := Compute(context.Background(), g, tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(rankings) != len(tt.titles) {
+				t.Fatalf("got %d rankings, want %d", len(rankings), len(tt.titles))
+			}
+
+			for i, ranking := range rankings {
+				if ranking.PageTitle != tt.titles[i] || ranking.Rank != i+1 {
+					t.Errorf("ranking %d is %s with rank %d, want %s with rank %d",
+						i, ranking.PageTitle, ranking.Rank, tt.titles[i], i+1)
+				}
+				if i > 0 && ranking.Score > rankings[i-1].Score {
+					t.Errorf("%s scores %f, more than %s ranked above it", ranking.PageTitle, ranking.Score, rankings[i-1].PageTitle)
+				}
+			}
+
+			if top := rankings[0]; tt.cfg.By == MetricInDegree && (top.Score != 3 || top.InDegree != 3 || top.PageKey != "c") {
+				t.Errorf("top ranking = %+v, want C with in-degree 3", top)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package hubrank
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// insertBatchSize keeps the number of query parameters under the PostgreSQL limit.
+const insertBatchSize = 1000
+
+type Repository interface {
+	// Replace removes the previous rankings and saves the new ones.
+	Replace(rankings []Ranking) error
+
+	// Top returns up to limit biggest hubs, the biggest first. 0 means all ranked pages.
+	Top(limit int) ([]Ranking, error)
+}
+
+type Repo struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Replace(rankings []Ranking) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin a transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM "page_hub_rankings"`)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	query := `INSERT INTO "page_hub_rankings" (page_key, page_title, rank, score, pagerank, in_degree, betweenness, computed_at)
+							VALUES (:page_key, :page_title, :rank, :score, :pagerank, :in_degree, :betweenness, :computed_at)
+							ON CONFLICT (page_key) DO NOTHING`
+	for start := 0; start < len(rankings); start += insertBatchSize {
+		end := start + insertBatchSize
+		if end > len(rankings) {
+			end = len(rankings)
+		}
+
+		_, err = tx.NamedExec(query, rankings[start:end])
+		if err != nil {
+			return errors.Wrap(err, "database error")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit the rankings")
+	}
+
+	return nil
+}
+
+func (r *Repo) Top(limit int) ([]Ranking, error) {
+	var rankings []Ranking
+	var err error
+	if limit > 0 {
+		err = r.db.Select(&rankings, `SELECT * FROM "page_hub_rankings" ORDER BY rank ASC LIMIT $1`, limit)
+	} else {
+		err = r.db.Select(&rankings, `SELECT * FROM "page_hub_rankings" ORDER BY rank ASC`)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return rankings, nil
+}
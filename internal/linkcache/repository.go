@@ -0,0 +1,146 @@
+package linkcache
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Repository stores links of the fetched pages, so they can be shared between workers.
+type Repository interface {
+	// GetRevisionLinks returns links of the given page revision.
+	// Revisions are immutable, so cached links never become stale.
+	GetRevisionLinks(revisionID int64) (*RevisionLinks, error)
+	SaveRevisionLinks(links *RevisionLinks) error
+
+	// GetPageLinks returns the latest known links of the page.
+	// The caller decides whether FetchedAt is fresh enough.
+	GetPageLinks(title string) (*PageLinks, error)
+	SavePageLinks(links *PageLinks) error
+
+	// ForEachPageLinks streams all cached pages to fn, stopping at the first error.
+	ForEachPageLinks(fn func(links *PageLinks) error) error
+}
+
+type PageLinks struct {
+	PageTitle string    `db:"page_title"`
+	FetchedAt time.Time `db:"fetched_at"`
+
+	Links Titles `db:"links"`
+}
+
+type RevisionLinks struct {
+	RevisionID int64     `db:"revision_id"`
+	CreatedAt  time.Time `db:"created_at"`
+
+	PageTitle string `db:"page_title"`
+	Links     Titles `db:"links"`
+}
+
+type Titles []string
+
+func (t Titles) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal([]string(t))
+}
+
+func (t *Titles) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+type Repo struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) GetRevisionLinks(revisionID int64) (*RevisionLinks, error) {
+	links := new(RevisionLinks)
+	err := r.db.Get(links, `SELECT * FROM "revision_links" WHERE revision_id = $1`, revisionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return links, nil
+}
+
+func (r *Repo) SaveRevisionLinks(links *RevisionLinks) error {
+	links.CreatedAt = time.Now()
+
+	query := `INSERT INTO "revision_links" (revision_id, created_at, page_title, links)
+							VALUES (:revision_id, :created_at, :page_title, :links)
+							ON CONFLICT (revision_id) DO NOTHING`
+	_, err := r.db.NamedExec(query, links)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func (r *Repo) GetPageLinks(title string) (*PageLinks, error) {
+	links := new(PageLinks)
+	err := r.db.Get(links, `SELECT * FROM "page_links" WHERE page_title = $1`, title)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return links, nil
+}
+
+func (r *Repo) SavePageLinks(links *PageLinks) error {
+	query := `INSERT INTO "page_links" (page_title, fetched_at, links)
+							VALUES (:page_title, :fetched_at, :links)
+							ON CONFLICT (page_title) DO UPDATE SET fetched_at = excluded.fetched_at, links = excluded.links`
+	_, err := r.db.NamedExec(query, links)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func (r *Repo) ForEachPageLinks(fn func(links *PageLinks) error) error {
+	rows, err := r.db.Queryx(`SELECT * FROM "page_links" ORDER BY page_title`)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		links := new(PageLinks)
+		err = rows.StructScan(links)
+		if err != nil {
+			return errors.Wrap(err, "scan failed")
+		}
+
+		err = fn(links)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(rows.Err(), "database error")
+}
@@ -9,13 +9,41 @@ import (
 	"github.com/pkg/errors"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound = errors.New("not found")
+
+	// ErrTooManyUnfinished is returned when the caller already has the maximum number of unfinished tasks.
+	ErrTooManyUnfinished = errors.New("too many unfinished tasks")
+)
 
 type Repository interface {
-	Create(from, to string) (*Task, error)
+	Create(from, to, caller string, priority Priority, options Options) (*Task, error)
+
+	// CreateWithinLimit creates the task only if the caller has less than maxUnfinished unfinished tasks,
+	// ErrTooManyUnfinished is returned otherwise. Concurrent calls of the same caller cannot exceed the limit.
+	// 0 means unlimited. Tasks of sampling runs and the other tasks are counted separately.
+	CreateWithinLimit(from, to, caller string, priority Priority, options Options, maxUnfinished int) (*Task, error)
+
+	// CreateManyWithinLimit creates a task for every pair of pages, either all of them or none.
+	// ErrTooManyUnfinished is returned if the caller would have more than maxUnfinished unfinished tasks.
+	CreateManyWithinLimit(pairs [][2]string, caller string, priority Priority, options Options, maxUnfinished int) ([]*Task, error)
 	Get(id uuid.UUID) (*Task, error)
+
+	// GetMany returns the existing tasks with the given IDs in no particular order.
+	GetMany(ids []uuid.UUID) ([]*Task, error)
+
 	UpdateStatus(id uuid.UUID, oldStatus, newStatus Status) error
+
+	// StartAttempt increments the number of processing attempts of the task and returns the new value.
+	StartAttempt(id uuid.UUID) (int, error)
+
+	SetProgress(id uuid.UUID, progress *Progress) error
 	SetResult(id uuid.UUID, result *Result) error
+
+	// ListUnverified returns up to limit DONE tasks whose paths have not been verified since the given moment,
+	// the least recently verified first. Tasks without paths, historical tasks and tasks of other kinds are skipped.
+	ListUnverified(since time.Time, limit int) ([]*Task, error)
+	SetVerification(id uuid.UUID, verifiedAt time.Time, verification *Verification) error
 }
 
 type Repo struct {
@@ -26,7 +54,7 @@ func NewRepository(db *sqlx.DB) *Repo {
 	return &Repo{db: db}
 }
 
-func (r *Repo) Create(from, to string) (*Task, error) {
+func (r *Repo) Create(from, to, caller string, priority Priority, options Options) (*Task, error) {
 	task := &Task{
 		ID:        uuid.New(),
 		CreatedAt: time.Now(),
@@ -34,10 +62,13 @@ func (r *Repo) Create(from, to string) (*Task, error) {
 		From:      from,
 		To:        to,
 		Status:    StatusPending,
+		Priority:  priority,
+		Caller:    caller,
+		Options:   options,
 	}
 
-	query := `INSERT INTO "tasks" (id, created_at, updated_at, from_page, to_page, status) 
-							VALUES (:id, :created_at, :updated_at, :from_page, :to_page, :status)`
+	query := `INSERT INTO "tasks" (id, created_at, updated_at, from_page, to_page, status, priority, caller, options) 
+							VALUES (:id, :created_at, :updated_at, :from_page, :to_page, :status, :priority, :caller, :options)`
 	_, err := r.db.NamedExec(query, task)
 	if err != nil {
 		return nil, errors.Wrap(err, "database error")
@@ -46,6 +77,76 @@ func (r *Repo) Create(from, to string) (*Task, error) {
 	return task, nil
 }
 
+func (r *Repo) CreateWithinLimit(from, to, caller string, priority Priority, options Options, maxUnfinished int) (*Task, error) {
+	tasks, err := r.CreateManyWithinLimit([][2]string{{from, to}}, caller, priority, options, maxUnfinished)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks[0], nil
+}
+
+func (r *Repo) CreateManyWithinLimit(pairs [][2]string, caller string, priority Priority, options Options, maxUnfinished int) ([]*Task, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if maxUnfinished > 0 {
+		// Concurrent inserts don't see each other, so inserts of the same caller are serialized.
+		_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, "tasks:"+caller)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to lock the caller")
+		}
+
+		query := `SELECT count(*) FROM "tasks" WHERE caller = $1 AND status NOT IN ($2, $3)
+					AND (options->>'sampling_run_id' IS NOT NULL) = $4`
+
+		var unfinished int
+		err = tx.Get(&unfinished, query, caller, StatusDone, StatusFailed, options.SamplingRunID != nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "database error")
+		}
+
+		if unfinished+len(pairs) > maxUnfinished {
+			return nil, ErrTooManyUnfinished
+		}
+	}
+
+	query := `INSERT INTO "tasks" (id, created_at, updated_at, from_page, to_page, status, priority, caller, options) 
+							VALUES (:id, :created_at, :updated_at, :from_page, :to_page, :status, :priority, :caller, :options)`
+
+	tasks := make([]*Task, 0, len(pairs))
+	for _, pair := range pairs {
+		task := &Task{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			From:      pair[0],
+			To:        pair[1],
+			Status:    StatusPending,
+			Priority:  priority,
+			Caller:    caller,
+			Options:   options,
+		}
+
+		_, err = tx.NamedExec(query, task)
+		if err != nil {
+			return nil, errors.Wrap(err, "database error")
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to commit")
+	}
+
+	return tasks, nil
+}
+
 func (r *Repo) Get(id uuid.UUID) (*Task, error) {
 	task := new(Task)
 	err := r.db.Get(task, `SELECT * FROM "tasks" WHERE id = $1`, id)
@@ -59,14 +160,74 @@ func (r *Repo) Get(id uuid.UUID) (*Task, error) {
 	return task, nil
 }
 
+func (r *Repo) GetMany(ids []uuid.UUID) ([]*Task, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM "tasks" WHERE id IN (?)`, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the query")
+	}
+
+	var tasks []*Task
+	err = r.db.Select(&tasks, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return tasks, nil
+}
+
 func (r *Repo) UpdateStatus(id uuid.UUID, oldStatus, newStatus Status) error {
 	_, err := r.db.Exec(`UPDATE "tasks" SET status = $1 WHERE id = $2 AND status = $3`, newStatus, id, oldStatus)
 
 	return err
 }
 
+func (r *Repo) StartAttempt(id uuid.UUID) (int, error) {
+	var attempts int
+	err := r.db.Get(&attempts, `UPDATE "tasks" SET attempts = attempts + 1 WHERE id = $1 RETURNING attempts`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "database error")
+	}
+
+	return attempts, nil
+}
+
+func (r *Repo) SetProgress(id uuid.UUID, progress *Progress) error {
+	_, err := r.db.Exec(`UPDATE "tasks" SET progress = $1 WHERE id = $2`, progress, id)
+
+	return err
+}
+
 func (r *Repo) SetResult(id uuid.UUID, result *Result) error {
 	_, err := r.db.Exec(`UPDATE "tasks" SET result = $1 WHERE id = $2`, result, id)
 
 	return err
 }
+
+func (r *Repo) ListUnverified(since time.Time, limit int) ([]*Task, error) {
+	var tasks []*Task
+	query := `SELECT * FROM "tasks"
+				WHERE status = $1 AND (verified_at IS NULL OR verified_at < $2)
+					AND COALESCE(options->>'kind', $3) = $3 AND options->>'as_of' IS NULL
+					AND result->'shortest_path'->1 IS NOT NULL
+				ORDER BY verified_at ASC NULLS FIRST, updated_at ASC
+				LIMIT $4`
+	err := r.db.Select(&tasks, query, StatusDone, since, KindPath, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return tasks, nil
+}
+
+func (r *Repo) SetVerification(id uuid.UUID, verifiedAt time.Time, verification *Verification) error {
+	_, err := r.db.Exec(`UPDATE "tasks" SET verified_at = $1, verification = $2 WHERE id = $3`, verifiedAt, verification, id)
+
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +16,35 @@ const (
 	StatusPending Status = iota + 1
 	StatusProcessing
 	StatusDone
+
+	// StatusFailed means the task cannot be completed, Result.Error contains the reason.
+	StatusFailed
+)
+
+// Priority defines the order of processing: tasks with higher priorities go first.
+type Priority uint8
+
+const (
+	PriorityBatch Priority = iota + 1
+	PriorityNormal
+	PriorityInteractive
 )
 
+// MaxPriority is the highest valid priority.
+const MaxPriority = PriorityInteractive
+
+// FetchWeight is the share of page fetches a task gets compared to concurrent tasks of normal priority.
+func (p Priority) FetchWeight() float64 {
+	switch p {
+	case PriorityBatch:
+		return 0.5
+	case PriorityInteractive:
+		return 4
+	default:
+		return 1
+	}
+}
+
 type Task struct {
 	ID        uuid.UUID `db:"id"`
 	CreatedAt time.Time `db:"created_at"`
@@ -27,11 +55,285 @@ type Task struct {
 
 	Status Status `db:"status"`
 
-	Result *Result `db:"result"`
+	Priority Priority `db:"priority"`
+
+	// Caller identifies the client that created the task.
+	Caller string `db:"caller"`
+
+	Options  Options   `db:"options"`
+	Progress *Progress `db:"progress"`
+	Result   *Result   `db:"result"`
+
+	// Number of times workers started processing the task, including the redeliveries.
+	Attempts int `db:"attempts"`
+
+	// The last check of the found path against the current links, nil if the path has not been verified.
+	VerifiedAt   *time.Time    `db:"verified_at"`
+	Verification *Verification `db:"verification"`
+}
+
+// FetchFlow identifies the share of page fetches the task belongs to. Fetches are shared between callers
+// rather than tasks, so a caller running many tasks gets no more than a caller running one.
+// Priorities of a caller are separate flows, so its batch tasks don't hold back its interactive ones.
+func (t *Task) FetchFlow() string {
+	return t.Caller + "/" + strconv.Itoa(int(t.Priority))
+}
+
+// Kind defines what the task looks for.
+type Kind string
+
+const (
+	// KindPath tasks look for the shortest path.
+	KindPath Kind = "path"
+
+	// KindDistance tasks only look for the distance between the pages.
+	KindDistance Kind = "distance"
+
+	// KindNeighborhood tasks list the pages within MaxDistance clicks from the From page.
+	KindNeighborhood Kind = "neighborhood"
+)
+
+// SearchMode defines how the path is searched for.
+type SearchMode string
+
+const (
+	// ModeBFS finds the shortest path with the breadth-first search.
+	ModeBFS SearchMode = "bfs"
+
+	// ModeBestFirst expands pages similar to the target first.
+	// The path is found faster, but it might be longer than the shortest one.
+	ModeBestFirst SearchMode = "best_first"
+)
+
+// TieBreak defines which of the shortest paths of the same length is returned.
+type TieBreak string
+
+const (
+	// TieBreakNone returns the path the target is discovered by first.
+	TieBreakNone TieBreak = ""
+
+	// TieBreakPreferHubs returns the path with the highest total hub score of its pages.
+	TieBreakPreferHubs TieBreak = "prefer_hubs"
+
+	// TieBreakAvoidHubs returns the path with the lowest total hub score of its pages.
+	TieBreakAvoidHubs TieBreak = "avoid_hubs"
+)
+
+// Options are optional search parameters provided by the user.
+type Options struct {
+	// If set, links are taken from the page revisions that were current at this moment.
+	AsOf *time.Time `json:"as_of,omitempty"`
+
+	// Empty mode means ModeBFS.
+	Mode SearchMode `json:"mode,omitempty"`
+
+	// If set, the path found in ModeBestFirst is saved as a preliminary result,
+	// and then the breadth-first search looks for a shorter one.
+	ProveOptimal bool `json:"prove_optimal,omitempty"`
+
+	// Number of paths to find, up to MaxNumPaths. Values below 2 mean only the shortest path.
+	NumPaths int `json:"num_paths,omitempty"`
+
+	// Empty kind means KindPath.
+	Kind Kind `json:"kind,omitempty"`
+
+	// Only for KindPath: explain every hop of the shortest path with the sentence containing the link.
+	Explain bool `json:"explain,omitempty"`
+
+	// For KindDistance: the target is reported as unreachable if it's farther than this.
+	// For KindNeighborhood: the number of hops to explore.
+	// 0 means the limit of the worker.
+	MaxDistance uint `json:"max_distance,omitempty"`
+
+	// Only for KindPath in ModeBFS: the path doesn't go through the top AvoidHubs hub pages.
+	AvoidHubs uint `json:"avoid_hubs,omitempty"`
+
+	// Only for KindPath in ModeBFS with a single path: hub scores break ties between the shortest paths.
+	TieBreak TieBreak `json:"tie_break,omitempty"`
+
+	// Set for the distance tasks of a sampling run. They have their own limit of unfinished tasks.
+	SamplingRunID *uuid.UUID `json:"sampling_run_id,omitempty"`
+}
+
+// MaxNumPaths is the maximum number of paths a task can ask for.
+const MaxNumPaths = 10
+
+// MaxAvoidHubs is the maximum number of hubs a task can avoid.
+const MaxAvoidHubs = 1000
+
+// UsesHubs reports whether the search depends on the hub rankings.
+func (o Options) UsesHubs() bool {
+	return o.AvoidHubs > 0 || o.TieBreak != TieBreakNone
+}
+
+func (o Options) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+
+func (o *Options) Scan(value interface{}) error {
+	if value == nil {
+		*o = Options{}
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, o)
+}
+
+// Progress of a task being processed. It's updated by the worker at a throttled rate.
+type Progress struct {
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Distance of the layer being expanded.
+	Distance    uint `json:"distance"`
+	MaxDistance uint `json:"max_distance"`
+
+	// Number of pages in the layer being expanded and how many of them are fetched.
+	FrontierSize      int `json:"frontier_size"`
+	LayerPagesFetched int `json:"layer_pages_fetched"`
+
+	// Pages fetched since the start of the search.
+	PagesFetched int `json:"pages_fetched"`
+
+	// Rough estimate of the fetches left in this and the next layer,
+	// based on the number of pages discovered so far.
+	EstimatedRemainingFetches int `json:"estimated_remaining_fetches"`
+}
+
+func (p *Progress) Value() (driver.Value, error) {
+	return json.Marshal(*p)
+}
+
+func (p *Progress) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, p)
 }
 
 type Result struct {
 	ShortestPath []string `json:"shortest_path"`
+
+	// Loopless paths in the explored part of the graph ordered by length, starting with ShortestPath.
+	// Set only if more than one path was requested.
+	Paths [][]string `json:"paths,omitempty"`
+
+	// Complete is false if some pages could not be fetched, and a shorter path
+	// (or any path, if none was found) might go through them.
+	Complete    bool     `json:"complete"`
+	FailedPages []string `json:"failed_pages,omitempty"`
+
+	// Heuristic is true if the path was found by the best-first search and might be longer than the shortest one.
+	Heuristic bool `json:"heuristic,omitempty"`
+
+	// Results of KindDistance tasks. Distance is nil if the target is not reachable within SearchedDistance.
+	Distance         *int `json:"distance,omitempty"`
+	SearchedDistance uint `json:"searched_distance,omitempty"`
+
+	// Explanations of the hops of ShortestPath if they were requested.
+	Explanations []HopExplanation `json:"explanations,omitempty"`
+
+	// Results of KindNeighborhood tasks ordered by distance. If Truncated is set, only the closest pages are listed.
+	Neighborhood []Neighbor `json:"neighborhood,omitempty"`
+	Truncated    bool       `json:"truncated,omitempty"`
+
+	Stats *Stats `json:"stats,omitempty"`
+
+	// Reason of the failure if the task has failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Stats describe the cost of the search.
+type Stats struct {
+	// Pages whose links were requested from the graph source.
+	PagesFetched int `json:"pages_fetched"`
+	FetchErrors  int `json:"fetch_errors"`
+
+	// Requests sent to the Wikipedia API, including the ones sent by other workers in the distributed mode.
+	APIRequests int64 `json:"api_requests"`
+
+	// Pages of the last layer left unfetched, because the target had been discovered.
+	SkippedFetches int `json:"skipped_fetches"`
+
+	// Pages fetched by the best-first search, they are included in PagesFetched.
+	HeuristicPagesFetched int `json:"heuristic_pages_fetched,omitempty"`
+
+	ElapsedMs int64 `json:"elapsed_ms"`
+
+	Layers []LayerStats `json:"layers,omitempty"`
+}
+
+// Verification tells whether the found path still exists in Wikipedia.
+type Verification struct {
+	Valid bool `json:"valid"`
+
+	// Hops of the path whose links have been removed.
+	BrokenHops []Hop `json:"broken_hops,omitempty"`
+
+	// If the path is broken and the search was run again, ID of the new task.
+	RerunTaskID *uuid.UUID `json:"rerun_task_id,omitempty"`
+}
+
+func (v *Verification) Value() (driver.Value, error) {
+	return json.Marshal(*v)
+}
+
+func (v *Verification) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// Hop is a link between two consecutive pages of a path.
+type Hop struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// HopExplanation tells why the From page links to the To page.
+// Sentence is empty if the link cannot be found in the text of the page, e.g. it comes from a template.
+type HopExplanation struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Section  string `json:"section,omitempty"`
+	Sentence string `json:"sentence,omitempty"`
+}
+
+// Neighbor is a page within a few clicks from the root of a neighborhood.
+type Neighbor struct {
+	Title    string `json:"title"`
+	Distance uint   `json:"distance"`
+}
+
+type LayerStats struct {
+	Distance uint `json:"distance"`
+
+	// Set only for KindDistance tasks: forward layers follow outgoing links from the start page,
+	// backward layers follow incoming links from the target.
+	Direction string `json:"direction,omitempty"`
+
+	// Number of pages to be expanded.
+	FrontierSize int `json:"frontier_size"`
+
+	PagesFetched int `json:"pages_fetched"`
+	FetchErrors  int `json:"fetch_errors"`
+
+	// Pages that could not be fetched after all retries.
+	FailedPages int `json:"failed_pages"`
+
+	// Number of new pages found in the layer.
+	Discovered int `json:"discovered"`
+
+	ElapsedMs int64 `json:"elapsed_ms"`
 }
 
 func (r *Result) Value() (driver.Value, error) {
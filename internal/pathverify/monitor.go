@@ -0,0 +1,67 @@
+package pathverify
+
+import (
+	"context"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type MonitorConfig struct {
+	// Period between checks of the stored paths.
+	Interval time.Duration
+
+	// Paths verified within this period are not checked again.
+	MaxAge time.Duration
+
+	// Maximum number of paths checked per period.
+	BatchSize int
+
+	// If set, searches of broken paths are run again with the batch priority.
+	Rerun bool
+}
+
+// Run periodically verifies the least recently verified paths until ctx is cancelled.
+// Failures are logged, and the paths are checked again in the next period.
+func (v *Verifier) Run(ctx context.Context, config MonitorConfig) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+
+		v.verifyBatch(ctx, config)
+	}
+}
+
+func (v *Verifier) verifyBatch(ctx context.Context, config MonitorConfig) {
+	tasks, err := v.repo.ListUnverified(time.Now().Add(-config.MaxAge), config.BatchSize)
+	if err != nil {
+		zlog.Error().Err(err).Msg("failed to list paths to verify")
+		return
+	}
+
+	var broken int
+	for _, task := range tasks {
+		verification, err := v.Verify(ctx, task, config.Rerun, task.Caller, pathtask.PriorityBatch)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to verify the path")
+			continue
+		}
+
+		if !verification.Valid {
+			broken++
+		}
+	}
+
+	zlog.Info().Int("verified", len(tasks)).Int("broken", broken).Msg("stored paths verified")
+}
@@ -0,0 +1,115 @@
+// Package pathverify checks whether the found paths still exist in Wikipedia:
+// pages are edited, and links the paths go through may be removed.
+package pathverify
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// ErrNotVerifiable is returned for tasks without a path to verify:
+// unfinished tasks, tasks of other kinds, historical tasks and tasks whose target was not reached.
+var ErrNotVerifiable = errors.New("task has no path to verify")
+
+// Verifier checks every hop of a path against the current links of the pages.
+type Verifier struct {
+	repo       pathtask.Repository
+	producer   *taskqueue.Producer
+	wikiClient *wikiclient.Client
+
+	// Maximum number of unfinished tasks of the caller a rerun is created for, 0 means unlimited.
+	maxUnfinished int
+}
+
+func NewVerifier(repo pathtask.Repository, producer *taskqueue.Producer, wikiClient *wikiclient.Client, maxUnfinished int) *Verifier {
+	return &Verifier{
+		repo:          repo,
+		producer:      producer,
+		wikiClient:    wikiClient,
+		maxUnfinished: maxUnfinished,
+	}
+}
+
+// Verify checks the path of the task and saves the verification.
+// If rerun is set and the path is broken, the search is run again in a new task of the given caller
+// with the given priority, unless an earlier verification has already done it. pathtask.ErrTooManyUnfinished
+// is returned if the caller already has too many unfinished tasks.
+func (v *Verifier) Verify(
+	ctx context.Context,
+	task *pathtask.Task,
+	rerun bool,
+	caller string,
+	priority pathtask.Priority,
+) (*pathtask.Verification, error) {
+	if !Verifiable(task) {
+		return nil, ErrNotVerifiable
+	}
+
+	path := task.Result.ShortestPath
+	verification := &pathtask.Verification{Valid: true}
+	verifiedAt := time.Now()
+
+	for i := 0; i+1 < len(path); i++ {
+		links, err := v.wikiClient.GetMentionedPages(ctx, path[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch links of %s", path[i])
+		}
+
+		if !wikiclient.ContainsTitle(links, path[i+1]) {
+			verification.Valid = false
+			verification.BrokenHops = append(verification.BrokenHops, pathtask.Hop{From: path[i], To: path[i+1]})
+		}
+	}
+
+	// The search is run again only once, later verifications of the broken path refer to the same task.
+	if !verification.Valid && rerun && task.Verification != nil && task.Verification.RerunTaskID != nil {
+		verification.RerunTaskID = task.Verification.RerunTaskID
+	} else if !verification.Valid && rerun {
+		id, err := v.rerun(task, caller, priority)
+		if err != nil {
+			return nil, err
+		}
+
+		verification.RerunTaskID = &id
+	}
+
+	err := v.repo.SetVerification(task.ID, verifiedAt, verification)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save the verification")
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"id":          task.ID.String(),
+		"valid":       verification.Valid,
+		"broken_hops": len(verification.BrokenHops),
+	}).Msg("path verified")
+
+	return verification, nil
+}
+
+// Verifiable reports whether the task has a path that can be checked against the current links.
+func Verifiable(task *pathtask.Task) bool {
+	return task.Status == pathtask.StatusDone &&
+		(task.Options.Kind == "" || task.Options.Kind == pathtask.KindPath) &&
+		task.Options.AsOf == nil &&
+		task.Result != nil && len(task.Result.ShortestPath) > 1
+}
+
+// rerun creates a task with the same pages and options on behalf of the caller.
+func (v *Verifier) rerun(task *pathtask.Task, caller string, priority pathtask.Priority) (uuid.UUID, error) {
+	created, err := taskqueue.Enqueue(v.repo, v.producer, task.From, task.To, caller, priority, task.Options, v.maxUnfinished)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	zlog.Info().Str("id", created.ID.String()).Str("broken_task_id", task.ID.String()).Msg("the search is run again")
+
+	return created.ID, nil
+}
@@ -0,0 +1,83 @@
+package separation
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type Repository interface {
+	Create(run *Run) error
+	Get(id uuid.UUID) (*Run, error)
+
+	// List returns up to limit runs, the most recent first.
+	List(limit int) ([]*Run, error)
+
+	// ListUnfinished returns the runs whose tasks are still being processed.
+	ListUnfinished() ([]*Run, error)
+
+	Finish(id uuid.UUID, finishedAt time.Time, stats *Stats) error
+}
+
+type Repo struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Create(run *Run) error {
+	query := `INSERT INTO "sampling_runs" (id, created_at, caller, pairs, max_distance, task_ids)
+							VALUES (:id, :created_at, :caller, :pairs, :max_distance, :task_ids)`
+	_, err := r.db.NamedExec(query, run)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func (r *Repo) Get(id uuid.UUID) (*Run, error) {
+	run := new(Run)
+	err := r.db.Get(run, `SELECT * FROM "sampling_runs" WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return run, nil
+}
+
+func (r *Repo) List(limit int) ([]*Run, error) {
+	var runs []*Run
+	err := r.db.Select(&runs, `SELECT * FROM "sampling_runs" ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return runs, nil
+}
+
+func (r *Repo) ListUnfinished() ([]*Run, error) {
+	var runs []*Run
+	err := r.db.Select(&runs, `SELECT * FROM "sampling_runs" WHERE finished_at IS NULL ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return runs, nil
+}
+
+func (r *Repo) Finish(id uuid.UUID, finishedAt time.Time, stats *Stats) error {
+	_, err := r.db.Exec(`UPDATE "sampling_runs" SET finished_at = $1, stats = $2 WHERE id = $3`, finishedAt, stats, id)
+
+	return err
+}
@@ -0,0 +1,168 @@
+// Package separation estimates the degrees of separation in Wikipedia: it samples random pairs of articles,
+// computes the distances between them with distance tasks and aggregates the results into a histogram.
+package separation
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+)
+
+// Run is a sample of random page pairs. It's finished when all its distance tasks are done or failed.
+type Run struct {
+	ID         uuid.UUID  `db:"id"`
+	CreatedAt  time.Time  `db:"created_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+
+	// Caller identifies the client that started the run.
+	Caller string `db:"caller"`
+
+	// Number of sampled pairs and the search limit of their distance tasks, 0 for the worker limit.
+	Pairs       int  `db:"pairs"`
+	MaxDistance uint `db:"max_distance"`
+
+	// Distance tasks of the pairs.
+	TaskIDs TaskIDs `db:"task_ids"`
+
+	// Saved when the run is finished, aggregated from the tasks on every request before that.
+	Stats *Stats `db:"stats"`
+}
+
+// Stats summarize the distances between the pages of the sampled pairs.
+type Stats struct {
+	// Pairs whose distance tasks are done or failed. The rest are still being processed.
+	Done   int `json:"done"`
+	Failed int `json:"failed"`
+
+	// Histogram[d] is the number of pairs at distance d.
+	Histogram []int `json:"histogram"`
+
+	// Pairs with no path within SearchedDistance clicks.
+	Unreachable int `json:"unreachable"`
+
+	// Pairs with no path found because some pages could not be fetched. They are neither reachable nor unreachable.
+	Incomplete int `json:"incomplete"`
+
+	// The longest distance the tasks searched within.
+	SearchedDistance uint `json:"searched_distance"`
+
+	// Over the reachable pairs.
+	MeanDistance   float64 `json:"mean_distance"`
+	MedianDistance int     `json:"median_distance"`
+}
+
+// Reachable returns the number of pairs with a known distance.
+func (s *Stats) Reachable() int {
+	var reachable int
+	for _, count := range s.Histogram {
+		reachable += count
+	}
+
+	return reachable
+}
+
+// UnreachableRatio returns the share of unreachable pairs among the pairs with a definite answer.
+func (s *Stats) UnreachableRatio() float64 {
+	answered := s.Reachable() + s.Unreachable
+	if answered == 0 {
+		return 0
+	}
+
+	return float64(s.Unreachable) / float64(answered)
+}
+
+func (s *Stats) Value() (driver.Value, error) {
+	return json.Marshal(*s)
+}
+
+func (s *Stats) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, s)
+}
+
+type TaskIDs []uuid.UUID
+
+func (t TaskIDs) Value() (driver.Value, error) {
+	return json.Marshal([]uuid.UUID(t))
+}
+
+func (t *TaskIDs) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, (*[]uuid.UUID)(t))
+}
+
+// aggregate computes the stats of the run from its distance tasks. Missing tasks are counted as failed.
+func aggregate(run *Run, tasks []*pathtask.Task) *Stats {
+	stats := &Stats{
+		Failed: len(run.TaskIDs) - len(tasks),
+	}
+
+	var distances []int
+	for _, task := range tasks {
+		switch task.Status {
+		case pathtask.StatusFailed:
+			stats.Failed++
+			continue
+
+		case pathtask.StatusDone:
+			stats.Done++
+
+		default:
+			continue
+		}
+
+		result := task.Result
+		if result == nil {
+			stats.Incomplete++
+			continue
+		}
+
+		if result.SearchedDistance > stats.SearchedDistance {
+			stats.SearchedDistance = result.SearchedDistance
+		}
+
+		switch {
+		case result.Distance != nil:
+			distances = append(distances, *result.Distance)
+
+		case result.Complete:
+			stats.Unreachable++
+
+		default:
+			stats.Incomplete++
+		}
+	}
+
+	if len(distances) == 0 {
+		return stats
+	}
+
+	sort.Ints(distances)
+
+	var sum int
+	for _, distance := range distances {
+		for len(stats.Histogram) <= distance {
+			stats.Histogram = append(stats.Histogram, 0)
+		}
+		stats.Histogram[distance]++
+		sum += distance
+	}
+
+	stats.MeanDistance = float64(sum) / float64(len(distances))
+	stats.MedianDistance = distances[len(distances)/2]
+
+	return stats
+}
@@ -0,0 +1,205 @@
+package separation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// MaxPairs is the maximum number of pairs sampled in a run.
+const MaxPairs = 1000
+
+// Sampler starts sampling runs and aggregates their results.
+type Sampler struct {
+	repo       Repository
+	tasks      pathtask.Repository
+	producer   taskqueue.TaskProducer
+	wikiClient *wikiclient.Client
+
+	// Maximum number of unfinished tasks of sampling runs of a caller including the tasks of a new run,
+	// 0 means unlimited. Other tasks of the caller are not counted.
+	maxUnfinished int
+}
+
+func NewSampler(
+	repo Repository,
+	tasks pathtask.Repository,
+	producer taskqueue.TaskProducer,
+	wikiClient *wikiclient.Client,
+	maxUnfinished int,
+) *Sampler {
+	return &Sampler{
+		repo:          repo,
+		tasks:         tasks,
+		producer:      producer,
+		wikiClient:    wikiClient,
+		maxUnfinished: maxUnfinished,
+	}
+}
+
+// Start samples pairs of random articles and enqueues a distance task with the given priority for every pair.
+// Distances are searched within maxDistance clicks, 0 means the worker limit.
+// The tasks are created at once and only if the caller stays within the limit of unfinished tasks of sampling runs,
+// pathtask.ErrTooManyUnfinished is returned otherwise. The run is saved before the tasks are published,
+// so every published task belongs to it. If a task cannot be published, it and the rest fail.
+func (s *Sampler) Start(ctx context.Context, caller string, pairs int, maxDistance uint, priority pathtask.Priority) (*Run, error) {
+	if pairs <= 0 || pairs > MaxPairs {
+		return nil, errors.Errorf("the number of pairs must be from 1 to %d", MaxPairs)
+	}
+
+	sampled, err := s.samplePairs(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	runID := uuid.New()
+	tasks, err := s.tasks.CreateManyWithinLimit(sampled, caller, priority, pathtask.Options{
+		Kind:          pathtask.KindDistance,
+		MaxDistance:   maxDistance,
+		SamplingRunID: &runID,
+	}, s.maxUnfinished)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the distance tasks")
+	}
+
+	run := &Run{
+		ID:          runID,
+		CreatedAt:   time.Now(),
+		Caller:      caller,
+		Pairs:       pairs,
+		MaxDistance: maxDistance,
+		TaskIDs:     make(TaskIDs, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		run.TaskIDs = append(run.TaskIDs, task.ID)
+	}
+
+	err = s.repo.Create(run)
+	if err != nil {
+		taskqueue.Discard(s.tasks, tasks)
+		return nil, errors.Wrap(err, "failed to save the run")
+	}
+
+	err = taskqueue.Publish(s.tasks, s.producer, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"id":           run.ID.String(),
+		"caller":       caller,
+		"pairs":        pairs,
+		"max_distance": maxDistance,
+	}).Msg("sampling run started")
+
+	return run, nil
+}
+
+// Get returns the run with its stats. The stats of an unfinished run are aggregated from its tasks,
+// and the run is finished once none of them are pending.
+func (s *Sampler) Get(id uuid.UUID) (*Run, error) {
+	run, err := s.repo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refresh(run)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// List returns up to limit runs, the most recent first. Their stats are refreshed as by Get.
+func (s *Sampler) List(limit int) ([]*Run, error) {
+	runs, err := s.repo.List(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, run := range runs {
+		err = s.refresh(run)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return runs, nil
+}
+
+func (s *Sampler) refresh(run *Run) error {
+	if run.FinishedAt != nil {
+		return nil
+	}
+
+	tasks, err := s.tasks.GetMany(run.TaskIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the distance tasks")
+	}
+
+	run.Stats = aggregate(run, tasks)
+
+	if run.Stats.Done+run.Stats.Failed < len(run.TaskIDs) {
+		return nil
+	}
+
+	finishedAt := time.Now()
+	err = s.repo.Finish(run.ID, finishedAt, run.Stats)
+	if err != nil {
+		return errors.Wrap(err, "failed to save the stats")
+	}
+	run.FinishedAt = &finishedAt
+
+	zlog.Info().Fields(map[string]interface{}{
+		"id":                run.ID.String(),
+		"reachable":         run.Stats.Reachable(),
+		"unreachable":       run.Stats.Unreachable,
+		"failed":            run.Stats.Failed,
+		"mean_distance":     run.Stats.MeanDistance,
+		"searched_distance": run.Stats.SearchedDistance,
+	}).Msg("sampling run finished")
+
+	return nil
+}
+
+// samplePairs returns pairs of distinct random articles.
+func (s *Sampler) samplePairs(ctx context.Context, count int) ([][2]string, error) {
+	pairs := make([][2]string, 0, count)
+
+	var pending string
+	for len(pairs) < count {
+		limit := 2 * (count - len(pairs))
+		if limit > wikiclient.MaxRandomPages {
+			limit = wikiclient.MaxRandomPages
+		}
+
+		titles, err := s.wikiClient.GetRandomPages(ctx, limit)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get random pages")
+		}
+		if len(titles) == 0 {
+			return nil, errors.New("no random pages returned")
+		}
+
+		for _, title := range titles {
+			switch {
+			case len(pairs) == count:
+			case pending == "":
+				pending = title
+			case !strings.EqualFold(pending, title):
+				pairs = append(pairs, [2]string{pending, title})
+				pending = ""
+			}
+		}
+	}
+
+	return pairs, nil
+}
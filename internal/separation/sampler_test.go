@@ -0,0 +1,268 @@
+package separation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+)
+
+// fakeTasks keeps tasks in memory. Methods the sampler doesn't use are not implemented.
+type fakeTasks struct {
+	pathtask.Repository
+
+	tasks         []*pathtask.Task
+	maxUnfinished int
+}
+
+func (f *fakeTasks) CreateManyWithinLimit(pairs [][2]string, caller string, priority pathtask.Priority, options pathtask.Options, maxUnfinished int) ([]*pathtask.Task, error) {
+	if maxUnfinished > 0 && len(f.tasks)+len(pairs) > maxUnfinished {
+		return nil, pathtask.ErrTooManyUnfinished
+	}
+
+	var created []*pathtask.Task
+	for _, pair := range pairs {
+		task := &pathtask.Task{
+			ID:       uuid.New(),
+			From:     pair[0],
+			To:       pair[1],
+			Status:   pathtask.StatusPending,
+			Priority: priority,
+			Caller:   caller,
+			Options:  options,
+		}
+
+		f.tasks = append(f.tasks, task)
+		created = append(created, task)
+	}
+
+	return created, nil
+}
+
+func (f *fakeTasks) GetMany(ids []uuid.UUID) ([]*pathtask.Task, error) {
+	var found []*pathtask.Task
+	for _, id := range ids {
+		for _, task := range f.tasks {
+			if task.ID == id {
+				copied := *task
+				found = append(found, &copied)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+func (f *fakeTasks) UpdateStatus(id uuid.UUID, oldStatus, newStatus pathtask.Status) error {
+	for _, task := range f.tasks {
+		if task.ID == id && task.Status == oldStatus {
+			task.Status = newStatus
+		}
+	}
+
+	return nil
+}
+
+// fakeRuns keeps runs in memory. Methods the sampler doesn't use are not implemented.
+type fakeRuns struct {
+	Repository
+
+	runs map[uuid.UUID]Run
+}
+
+func (f *fakeRuns) Create(run *Run) error {
+	f.runs[run.ID] = *run
+	return nil
+}
+
+func (f *fakeRuns) Get(id uuid.UUID) (*Run, error) {
+	run, ok := f.runs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &run, nil
+}
+
+func (f *fakeRuns) Finish(id uuid.UUID, finishedAt time.Time, stats *Stats) error {
+	run := f.runs[id]
+	run.FinishedAt = &finishedAt
+	run.Stats = stats
+	f.runs[id] = run
+
+	return nil
+}
+
+// fakeProducer records the published tasks and fails after failAfter of them if it's positive.
+type fakeProducer struct {
+	published []uuid.UUID
+	failAfter int
+}
+
+func (p *fakeProducer) Produce(task taskqueue.Task) error {
+	if p.failAfter > 0 && len(p.published) == p.failAfter {
+		return errors.New("connection is closed")
+	}
+
+	p.published = append(p.published, task.ID)
+
+	return nil
+}
+
+// randomPagesServer serves the titles as random pages.
+func randomPagesServer(t *testing.T, titles ...string) *wikiclient.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type page struct {
+			Title string `json:"title"`
+		}
+
+		var response struct {
+			Query struct {
+				Random []page `json:"random"`
+			} `json:"query"`
+		}
+		for _, title := range titles {
+			response.Query.Random = append(response.Query.Random, page{Title: title})
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	return wikiclient.New(server.URL, 1000)
+}
+
+func newTestSampler(t *testing.T, producer *fakeProducer, maxUnfinished int) (*Sampler, *fakeTasks, *fakeRuns) {
+	tasks := new(fakeTasks)
+	runs := &fakeRuns{runs: make(map[uuid.UUID]Run)}
+	wikiClient := randomPagesServer(t, "Apple", "apple", "Banana", "Cherry", "Date")
+
+	return NewSampler(runs, tasks, producer, wikiClient, maxUnfinished), tasks, runs
+}
+
+func TestSampler_RunLifecycle(t *testing.T) {
+	producer := new(fakeProducer)
+	sampler, tasks, runs := newTestSampler(t, producer, 0)
+
+	run, err := sampler.Start(context.Background(), "alice", 2, 3, pathtask.PriorityBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Titles differing only in case are not paired.
+	var pairs [][2]string
+	for _, task := range tasks.tasks {
+		pairs = append(pairs, [2]string{task.From, task.To})
+
+		if task.Caller != "alice" || task.Priority != pathtask.PriorityBatch || task.Options.Kind != pathtask.KindDistance ||
+			task.Options.MaxDistance != 3 || task.Options.SamplingRunID == nil || *task.Options.SamplingRunID != run.ID {
+			t.Errorf("unexpected task %+v with options %+v", task, task.Options)
+		}
+	}
+	if want := [][2]string{{"Apple", "Banana"}, {"Cherry", "Date"}}; !reflect.DeepEqual(pairs, want) {
+		t.Errorf("pairs = %v, want %v", pairs, want)
+	}
+
+	if !reflect.DeepEqual(producer.published, []uuid.UUID(run.TaskIDs)) {
+		t.Errorf("published %v, want the tasks of the run %v", producer.published, run.TaskIDs)
+	}
+	if saved, ok := runs.runs[run.ID]; !ok || !reflect.DeepEqual(saved.TaskIDs, run.TaskIDs) {
+		t.Errorf("saved run = %+v, want the run with its tasks", saved)
+	}
+
+	pending, err := sampler.Get(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.FinishedAt != nil || pending.Stats.Done != 0 {
+		t.Fatalf("run with pending tasks: finished at %v, stats %+v", pending.FinishedAt, pending.Stats)
+	}
+
+	distance := 2
+	tasks.tasks[0].Status = pathtask.StatusDone
+	tasks.tasks[0].Result = &pathtask.Result{Distance: &distance, SearchedDistance: 3, Complete: true}
+	tasks.tasks[1].Status = pathtask.StatusDone
+	tasks.tasks[1].Result = &pathtask.Result{SearchedDistance: 3, Complete: true}
+
+	finished, err := sampler.Get(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if finished.FinishedAt == nil {
+		t.Fatal("the run is not finished after all its tasks are done")
+	}
+
+	want := &Stats{
+		Done:             2,
+		Histogram:        []int{0, 0, 1},
+		Unreachable:      1,
+		SearchedDistance: 3,
+		MeanDistance:     2,
+		MedianDistance:   2,
+	}
+	if !reflect.DeepEqual(finished.Stats, want) {
+		t.Errorf("stats = %+v, want %+v", finished.Stats, want)
+	}
+	if saved := runs.runs[run.ID]; saved.FinishedAt == nil || !reflect.DeepEqual(saved.Stats, want) {
+		t.Errorf("saved run = %+v, want the finished run with its stats", saved)
+	}
+}
+
+func TestSampler_DiscardsUnpublishedTasks(t *testing.T) {
+	producer := &fakeProducer{failAfter: 1}
+	sampler, tasks, runs := newTestSampler(t, producer, 0)
+
+	_, err := sampler.Start(context.Background(), "alice", 2, 0, pathtask.PriorityBatch)
+	if err == nil {
+		t.Fatal("the run was started although a task could not be published")
+	}
+
+	if len(runs.runs) != 1 || len(tasks.tasks) != 2 {
+		t.Fatalf("%d runs and %d tasks were saved, want 1 and 2", len(runs.runs), len(tasks.tasks))
+	}
+	if tasks.tasks[0].Status != pathtask.StatusPending || tasks.tasks[1].Status != pathtask.StatusFailed {
+		t.Errorf("statuses are %d and %d, want the published task pending and the other one failed",
+			tasks.tasks[0].Status, tasks.tasks[1].Status)
+	}
+
+	var runID uuid.UUID
+	for id := range runs.runs {
+		runID = id
+	}
+
+	tasks.tasks[0].Status = pathtask.StatusDone
+
+	run, err := sampler.Get(runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.FinishedAt == nil || run.Stats.Done != 1 || run.Stats.Failed != 1 {
+		t.Errorf("run finished at %v with stats %+v, want it finished with 1 done and 1 failed task", run.FinishedAt, run.Stats)
+	}
+}
+
+func TestSampler_RejectsRunsOverLimit(t *testing.T) {
+	producer := new(fakeProducer)
+	sampler, tasks, runs := newTestSampler(t, producer, 1)
+
+	_, err := sampler.Start(context.Background(), "alice", 2, 0, pathtask.PriorityBatch)
+	if !errors.Is(err, pathtask.ErrTooManyUnfinished) {
+		t.Fatalf("err = %v, want %v", err, pathtask.ErrTooManyUnfinished)
+	}
+
+	if len(runs.runs) != 0 || len(tasks.tasks) != 0 || len(producer.published) != 0 {
+		t.Errorf("%d runs, %d tasks and %d published tasks after the rejection, want none",
+			len(runs.runs), len(tasks.tasks), len(producer.published))
+	}
+}
@@ -0,0 +1,62 @@
+package separation
+
+import (
+	"context"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// ScheduleCaller identifies the tasks of the scheduled runs.
+const ScheduleCaller = "sampler"
+
+type ScheduleConfig struct {
+	// Period between runs.
+	Interval time.Duration
+
+	// Number of pairs sampled in a run and the search limit of their distance tasks.
+	Pairs       int
+	MaxDistance uint
+}
+
+// Run starts a sampling run with the batch priority every period until ctx is cancelled.
+// Unfinished runs are aggregated every period as well, so their stats are saved without being requested.
+func (s *Sampler) Run(ctx context.Context, config ScheduleConfig) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+
+		s.refreshUnfinished()
+
+		_, err := s.Start(ctx, ScheduleCaller, config.Pairs, config.MaxDistance, pathtask.PriorityBatch)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			zlog.Error().Err(err).Msg("failed to start a scheduled sampling run")
+		}
+	}
+}
+
+func (s *Sampler) refreshUnfinished() {
+	runs, err := s.repo.ListUnfinished()
+	if err != nil {
+		zlog.Error().Err(err).Msg("failed to list unfinished sampling runs")
+		return
+	}
+
+	for _, run := range runs {
+		err = s.refresh(run)
+		if err != nil {
+			zlog.Error().Err(err).Str("id", run.ID.String()).Msg("failed to aggregate the sampling run")
+		}
+	}
+}
@@ -24,10 +24,13 @@ func NewConsumer(consumer rabbitmq.Consumer, queueName, routingKey string) *Cons
 }
 
 // StartConsuming consumes tasks and calls handler for each of them.
+// Up to concurrency tasks are handled simultaneously, and no more are prefetched,
+// so the rest of the queue is left to other workers.
+// The queue is declared as a priority queue with MaxPriority.
 // If a message cannot be unmarshalled, it's discarded.
 // If handler fails, the message is requeued.
 // Otherwise, the message is acked.
-func (c *Consumer) StartConsuming(handler func(taskID uuid.UUID) error) error {
+func (c *Consumer) StartConsuming(concurrency int, handler func(taskID uuid.UUID) error) error {
 	return c.consumer.StartConsuming(
 		func(d rabbitmq.Delivery) rabbitmq.Action {
 			var task Task
@@ -51,7 +54,61 @@ func (c *Consumer) StartConsuming(handler func(taskID uuid.UUID) error) error {
 		},
 		c.queueName,
 		[]string{c.routingKey},
-		rabbitmq.WithConsumeOptionsConcurrency(1),
+		rabbitmq.WithConsumeOptionsConcurrency(concurrency),
+		rabbitmq.WithConsumeOptionsQOSPrefetch(concurrency),
 		rabbitmq.WithConsumeOptionsQueueDurable,
+		rabbitmq.WithConsumeOptionsQueueArgs(rabbitmq.Table{"x-max-priority": int32(MaxPriority)}),
+	)
+}
+
+// StartConsumingExpansions consumes expansion requests with the given concurrency.
+// Failed requests are requeued, so another worker can pick them up.
+func (c *Consumer) StartConsumingExpansions(concurrency int, handler func(request ExpansionRequest) error) error {
+	return c.consumer.StartConsuming(
+		func(d rabbitmq.Delivery) rabbitmq.Action {
+			var request ExpansionRequest
+			err := json.Unmarshal(d.Body, &request)
+			if err != nil {
+				zlog.Error().Err(err).Interface("message", d).Msg("failed to unmarshal received expansion request")
+				return rabbitmq.NackDiscard
+			}
+
+			err = handler(request)
+			if err != nil {
+				zlog.Error().Err(err).Str("id", request.ID.String()).Msg("failed to process the expansion request")
+				return rabbitmq.NackRequeue
+			}
+
+			return rabbitmq.Ack
+		},
+		c.queueName,
+		nil,
+		rabbitmq.WithConsumeOptionsConcurrency(concurrency),
+		rabbitmq.WithConsumeOptionsQOSPrefetch(concurrency),
+		rabbitmq.WithConsumeOptionsQueueDurable,
+	)
+}
+
+// StartConsumingExpansionResults consumes results addressed to this worker.
+// The queue is exclusive and is deleted when the worker disconnects:
+// coordinators resend requests that were not answered in time.
+func (c *Consumer) StartConsumingExpansionResults(handler func(result ExpansionResult)) error {
+	return c.consumer.StartConsuming(
+		func(d rabbitmq.Delivery) rabbitmq.Action {
+			var result ExpansionResult
+			err := json.Unmarshal(d.Body, &result)
+			if err != nil {
+				zlog.Error().Err(err).Interface("message", d).Msg("failed to unmarshal received expansion result")
+				return rabbitmq.NackDiscard
+			}
+
+			handler(result)
+
+			return rabbitmq.Ack
+		},
+		c.queueName,
+		nil,
+		rabbitmq.WithConsumeOptionsQueueExclusive,
+		rabbitmq.WithConsumeOptionsQueueAutoDelete,
 	)
 }
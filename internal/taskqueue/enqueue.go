@@ -0,0 +1,62 @@
+package taskqueue
+
+import (
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// TaskProducer publishes tasks for the workers, Producer publishes them to RabbitMQ.
+type TaskProducer interface {
+	Produce(task Task) error
+}
+
+// Enqueue saves a new task and publishes it for the workers.
+// pathtask.ErrTooManyUnfinished is returned if the caller already has maxUnfinished unfinished tasks, 0 means unlimited.
+func Enqueue(
+	repo pathtask.Repository,
+	producer TaskProducer,
+	from, to, caller string,
+	priority pathtask.Priority,
+	options pathtask.Options,
+	maxUnfinished int,
+) (*pathtask.Task, error) {
+	task, err := repo.CreateWithinLimit(from, to, caller, priority, options, maxUnfinished)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a task")
+	}
+
+	err = Publish(repo, producer, []*pathtask.Task{task})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// Publish publishes the saved tasks for the workers. If a task cannot be published, it and the rest
+// are marked as FAILED, so they don't stay unfinished tasks of the caller forever.
+func Publish(repo pathtask.Repository, producer TaskProducer, tasks []*pathtask.Task) error {
+	for i, task := range tasks {
+		err := producer.Produce(Task{ID: task.ID, Priority: uint8(task.Priority)})
+		if err == nil {
+			continue
+		}
+
+		Discard(repo, tasks[i:])
+
+		return errors.Wrap(err, "failed to enqueue the task")
+	}
+
+	return nil
+}
+
+// Discard marks the saved tasks that won't be published as FAILED.
+func Discard(repo pathtask.Repository, tasks []*pathtask.Task) {
+	for _, task := range tasks {
+		err := repo.UpdateStatus(task.ID, pathtask.StatusPending, pathtask.StatusFailed)
+		if err != nil {
+			zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to discard the unpublished task")
+		}
+	}
+}
@@ -35,6 +35,7 @@ func (p *Producer) Produce(task Task) error {
 		rabbitmq.WithPublishOptionsContentType("application/json"),
 		rabbitmq.WithPublishOptionsPersistentDelivery,
 		rabbitmq.WithPublishOptionsExchange(p.exchangeName),
+		rabbitmq.WithPublishOptionsPriority(task.Priority),
 	)
 	if err != nil {
 		return errors.Wrap(err, "AMQP publish failed")
@@ -42,9 +43,30 @@ func (p *Producer) Produce(task Task) error {
 
 	zlog.Debug().Fields(map[string]interface{}{
 		"id":          task.ID.String(),
+		"priority":    task.Priority,
 		"exchange":    p.exchangeName,
 		"routing_key": p.routingKey,
 	}).Msg("a task was enqueued")
 
 	return nil
 }
+
+// PublishToQueue publishes the message directly to the queue via the default exchange.
+func (p *Producer) PublishToQueue(queue string, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return errors.Wrap(err, "json marshalling failed")
+	}
+
+	err = p.publisher.Publish(
+		data,
+		[]string{queue},
+		rabbitmq.WithPublishOptionsContentType("application/json"),
+		rabbitmq.WithPublishOptionsPersistentDelivery,
+	)
+	if err != nil {
+		return errors.Wrap(err, "AMQP publish failed")
+	}
+
+	return nil
+}
@@ -1,7 +1,53 @@
 package taskqueue
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxPriority is the maximum priority of the tasks queue (x-max-priority).
+// RabbitMQ treats messages with higher priorities as having the maximum one.
+const MaxPriority = 3
 
 type Task struct {
 	ID uuid.UUID `json:"id"`
+
+	// Messages with higher priorities are delivered first.
+	Priority uint8 `json:"priority,omitempty"`
+}
+
+// ExpansionRequest asks any worker to fetch outgoing links of the pages
+// on behalf of the worker coordinating the task.
+type ExpansionRequest struct {
+	ID     uuid.UUID `json:"id"`
+	TaskID uuid.UUID `json:"task_id"`
+
+	Titles []string `json:"titles"`
+
+	// If set, links are taken from the page revisions current at this moment.
+	AsOf *time.Time `json:"as_of,omitempty"`
+
+	// Flow of page fetches of the task on the worker and its share compared to other flows.
+	// The task ID is used as the flow if it's not set.
+	Flow   string  `json:"flow,omitempty"`
+	Weight float64 `json:"weight,omitempty"`
+
+	// Name of the queue the result must be published to.
+	ReplyTo string `json:"reply_to"`
+}
+
+type ExpansionResult struct {
+	// ID of the request.
+	ID     uuid.UUID `json:"id"`
+	TaskID uuid.UUID `json:"task_id"`
+
+	// Title -> outgoing links.
+	Links map[string][]string `json:"links"`
+
+	// Title -> error message for the pages that cannot be fetched.
+	Failed map[string]string `json:"failed,omitempty"`
+
+	// Number of Wikipedia API requests sent to expand the pages.
+	Requests int64 `json:"requests,omitempty"`
 }
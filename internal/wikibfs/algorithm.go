@@ -6,7 +6,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/checkpoint"
+	"github.com/lodthe/wiki-graph/internal/fairqueue"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
 	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	zlog "github.com/rs/zerolog/log"
 )
 
@@ -16,8 +22,52 @@ type BFSConfig struct {
 
 	// Number of workers to parse pages.
 	WorkerCount int
+
+	// The state is checkpointed after a layer if the previous checkpoint is older than this.
+	CheckpointInterval time.Duration
+
+	// Minimum interval between progress reports.
+	ProgressInterval time.Duration
+
+	// Number of times pages that cannot be fetched are retried at the end of a layer.
+	PageRetries    int
+	PageRetryDelay time.Duration
+
+	// Number of frontier pages whose titles are loaded and expanded at once, 0 means the whole layer.
+	ExpandBatchSize int
+
+	// Estimated size of the search state after which titles and the visited set are moved to temporary files in SpillDir.
+	// 0 disables spilling.
+	SpillThreshold int64
+	SpillDir       string
+
+	// The search fails with ErrMemoryBudgetExceeded when its state grows over this, 0 means unlimited.
+	MemoryBudget int64
+
+	// The best-first search gives up after this many page fetches.
+	HeuristicMaxFetches int
+
+	// Number of the most promising pages expanded at once by the best-first search.
+	HeuristicBeamWidth int
+
+	// If set, pages sharing categories with the target are preferred. It costs an extra request per page.
+	HeuristicCategories bool
+
+	// Maximum allowed distance for distance queries.
+	DistanceQueryThreshold uint
+
+	// Neighborhoods are not expanded further after they have this many pages, 0 means unlimited.
+	NeighborhoodMaxPages int
+
+	// A task that keeps failing or crashing the worker is marked as failed after this many attempts, 0 means unlimited.
+	MaxTaskAttempts int
 }
 
+var skippedFetches = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "wikigraph_bfs_skipped_page_fetches_total",
+	Help: "Number of page fetches saved by stopping BFS layers as soon as the target is discovered.",
+})
+
 type parseResult struct {
 	title           string
 	mentionedTitles []string
@@ -25,45 +75,155 @@ type parseResult struct {
 	err error
 }
 
+// expander fetches outgoing links of the layer pages.
+type expander interface {
+	// expand sends exactly one result for every page unless ctx is cancelled.
+	// It returns the number of pages whose fetches were started, the rest were never requested.
+	expand(ctx context.Context, titles []string, results chan<- parseResult) (dispatched int)
+}
+
 type algorithm struct {
-	wikiClient *wikiclient.Client
-	cfg        BFSConfig
+	source   GraphSource
+	expander expander
+	cfg      BFSConfig
+
+	// Flow of page fetches of the task and its share compared to concurrent flows.
+	// The task ID is used as the flow if it's not set.
+	fetchFlow   string
+	fetchWeight float64
+
+	// Statistics of the search, including the attempts before the last checkpoint.
+	stats pathtask.Stats
+
+	// Pages that could not be fetched after all retries.
+	failedPages []string
+
+	// False if failed pages could hide a shorter path, or any path if none was found.
+	complete bool
+
+	// Called with the progress of the search at most once per ProgressInterval. Not called if nil.
+	onProgress   func(progress pathtask.Progress)
+	lastProgress time.Time
+
+	// Checkpoints are not made if nil.
+	checkpoints    checkpoint.Store
+	lastCheckpoint time.Time
+
+	// Pages discovered by the search.
+	state *searchState
+
+	// Number of paths to find. If it's more than one, links of the expanded pages are recorded,
+	// and the layer of the target is expanded completely to find alternative paths of the same length.
+	numPaths int
+
+	// The shortest path followed by the alternative ones, ordered by length.
+	paths [][]string
 
-	visited map[string]struct{}
-	prev    map[string]string
+	// Hubs the search avoids or breaks ties with, nil if the task doesn't use them.
+	hubs *hubRanking
 }
 
-func newAlgorithm(wikiClient *wikiclient.Client, cfg BFSConfig) *algorithm {
+func newAlgorithm(source GraphSource, cfg BFSConfig, checkpoints checkpoint.Store) *algorithm {
 	return &algorithm{
-		wikiClient: wikiClient,
-		cfg:        cfg,
-		visited:    make(map[string]struct{}),
-		prev:       make(map[string]string),
+		source:         source,
+		expander:       newLocalExpander(source, cfg.WorkerCount),
+		cfg:            cfg,
+		fetchWeight:    1,
+		complete:       true,
+		checkpoints:    checkpoints,
+		lastCheckpoint: time.Now(),
+		state:          newSearchState(cfg),
 	}
 }
 
-func (a *algorithm) findShortestPath(taskID uuid.UUID, from, to string) ([]string, error) {
-	pagesToParse := make(chan string, 1024)
-	parseResults := make(chan parseResult, 1024)
+// withFetchFlow makes page fetches of the task share the API fairly with the tasks of other callers.
+func (a *algorithm) withFetchFlow(ctx context.Context, taskID uuid.UUID) context.Context {
+	flow := a.fetchFlow
+	if flow == "" {
+		flow = taskID.String()
+	}
+
+	return fairqueue.WithFlow(ctx, flow, a.fetchWeight)
+}
 
+func (a *algorithm) findShortestPath(taskID uuid.UUID, from, to string) ([]string, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	defer close(pagesToParse)
 
-	for range make([]struct{}, a.cfg.WorkerCount) {
-		go a.parseWorker(ctx, pagesToParse, parseResults)
+	// Page fetches of concurrently processed tasks of different callers share the API fairly.
+	ctx = a.withFetchFlow(ctx, taskID)
+
+	startedAt := time.Now()
+	requests := new(wikiclient.RequestCounter)
+	ctx = wikiclient.WithRequestCounter(ctx, requests)
+
+	// Statistics restored from a checkpoint already include the work of the previous attempts,
+	// and statistics of a fresh search include the preceding heuristic search, if any.
+	restoredElapsedMs, restoredRequests := a.stats.ElapsedMs, a.stats.APIRequests
+	defer func() {
+		a.stats.ElapsedMs = restoredElapsedMs + time.Since(startedAt).Milliseconds()
+		a.stats.APIRequests = restoredRequests + requests.Count()
+	}()
+
+	// The state is replaced if a corrupted checkpoint is partially restored.
+	defer func() {
+		a.state.close()
+	}()
+
+	var queue []uint32
+	var distance uint
+
+	state, err := a.restore(taskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to restore the checkpoint")
 	}
 
-	to = a.normalize(to)
-	from = a.normalize(from)
+	if state != nil {
+		from, to, distance, queue = state.From, state.To, state.Distance, state.Queue
 
-	a.visited[from] = struct{}{}
-	queue := []string{from}
+		for from, links := range state.Links {
+			for _, to := range links {
+				err := a.state.addLink(from, to)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to restore the checkpoint")
+				}
+			}
+		}
+
+		a.stats = state.Stats
+		a.failedPages = state.FailedPages
+		restoredElapsedMs, restoredRequests = state.Stats.ElapsedMs, state.Stats.APIRequests
+
+		zlog.Info().Fields(map[string]interface{}{
+			"task_id":      taskID.String(),
+			"distance":     distance,
+			"queue_length": len(queue),
+		}).Msg("BFS resumed from checkpoint")
+	} else {
+		var exist bool
+		var err error
+		from, to, exist, err = a.canonicalizePages(ctx, from, to)
+		if err != nil || !exist {
+			return nil, err
+		}
+
+		id, _, err := a.state.add(a.normalize(from), from, noParent)
+		if err != nil {
+			return nil, err
+		}
+
+		queue = []uint32{id}
+	}
+
+	targetKey := a.normalize(to)
 
 	var reached bool
-	var distance uint
 	for {
-		_, reached = a.visited[to]
+		var err error
+		_, reached, err = a.state.lookup(targetKey)
+		if err != nil {
+			return nil, err
+		}
 		if reached {
 			break
 		}
@@ -73,57 +233,64 @@ func (a *algorithm) findShortestPath(taskID uuid.UUID, from, to string) ([]strin
 			break
 		}
 
-		startedAt := time.Now()
+		layerStartedAt := time.Now()
 		zlog.Info().Int("queue_length", len(queue)).Msg("started a new BFS iteration")
 
-		go func() {
-			for _, title := range queue {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+		layer := pathtask.LayerStats{
+			Distance:     distance,
+			FrontierSize: len(queue),
+		}
 
-				pagesToParse <- title
-			}
-		}()
-
-		newQueue := make([]string, 0, len(queue))
-		for range queue {
-			result := <-parseResults
-			if result.err != nil {
-				zlog.Error().Err(result.err).Fields(map[string]interface{}{
-					"task_id":           taskID.String(),
-					"from":              from,
-					"to":                to,
-					"current_distance":  distance,
-					"faulty_page_title": result.title,
-				}).Msg("page cannot be parsed")
+		newQueue := make([]uint32, 0, len(queue))
 
-				continue
-			}
+		reached, err = a.expandLayer(ctx, taskID, queue, targetKey, &newQueue, &layer)
 
-			for _, title := range result.mentionedTitles {
-				title = a.normalize(title)
-				_, visited := a.visited[title]
-				if visited {
-					continue
-				}
+		layer.Discovered = len(newQueue)
+		layer.ElapsedMs = time.Since(layerStartedAt).Milliseconds()
+		a.addLayerStats(layer)
 
-				a.visited[title] = struct{}{}
-				a.prev[title] = result.title
-				newQueue = append(newQueue, title)
-			}
+		if err != nil {
+			return nil, err
+		}
+
+		if reached {
+			skippedFetches.Add(float64(a.stats.SkippedFetches))
+
+			zlog.Info().Fields(map[string]interface{}{
+				"task_id":         taskID.String(),
+				"distance":        distance,
+				"skipped_fetches": a.stats.SkippedFetches,
+				"elapsed":         time.Since(layerStartedAt).String(),
+			}).Msg("target discovered")
+
+			break
 		}
 
 		queue = newQueue
 
+		stats := a.stats
+		stats.ElapsedMs = restoredElapsedMs + time.Since(startedAt).Milliseconds()
+		stats.APIRequests = restoredRequests + requests.Count()
+
 		zlog.Info().
-			Dur("elapsed", time.Since(startedAt)).
+			Dur("elapsed", time.Since(layerStartedAt)).
 			Uint("distance", distance).
 			Msgf("found %d new pages", len(queue))
+
+		a.checkpoint(taskID, &checkpointState{
+			From:     from,
+			To:       to,
+			Distance: distance,
+			Queue:    queue,
+			Links:    a.state.links,
+			Stats:    stats,
+
+			FailedPages: a.failedPages,
+		})
 	}
 
+	a.complete = a.isComplete(distance, reached)
+
 	if !reached {
 		zlog.Info().Str("from", from).Str("to", to).Msg("page is not reachable")
 		return nil, nil
@@ -136,35 +303,362 @@ func (a *algorithm) findShortestPath(taskID uuid.UUID, from, to string) ([]strin
 		"distance": distance,
 	}).Msg("BFS finished successfully")
 
-	path := make([]string, 0, distance+1)
-	for to != "" {
-		path = append(path, to)
-		to = a.prev[to]
+	targetID, _, err := a.state.lookup(targetKey)
+	if err != nil {
+		return nil, err
 	}
 
-	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
-		path[i], path[j] = path[j], path[i]
+	if a.numPaths > 1 {
+		return a.alternativePaths(targetID, to)
 	}
 
-	return path, nil
+	if a.recordsLinks() {
+		return a.tieBrokenPath(targetID, to)
+	}
+
+	return a.state.path(targetID, to)
+}
+
+// alternativePaths finds up to numPaths loopless paths in the explored subgraph and returns the shortest one.
+func (a *algorithm) alternativePaths(targetID uint32, to string) ([]string, error) {
+	// The start page always gets the first ID.
+	paths := kShortestPaths(a.state.links, 0, targetID, a.numPaths)
+	for _, ids := range paths {
+		titles, err := a.state.titlesOf(ids[:len(ids)-1])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to restore the path")
+		}
+
+		a.paths = append(a.paths, append(titles, to))
+	}
+
+	// The explored subgraph always contains the path the target was discovered by.
+	if len(a.paths) == 0 {
+		path, err := a.state.path(targetID, to)
+		if err != nil {
+			return nil, err
+		}
+
+		a.paths = [][]string{path}
+	}
+
+	return a.paths[0], nil
+}
+
+// expandLayer expands the frontier in batches of ExpandBatchSize pages, so titles of only one batch
+// are loaded at once. Pages that cannot be fetched are retried at the end of the layer.
+func (a *algorithm) expandLayer(
+	ctx context.Context,
+	taskID uuid.UUID,
+	queue []uint32,
+	targetKey string,
+	next *[]uint32,
+	layer *pathtask.LayerStats,
+) (reached bool, err error) {
+	pending := queue
+	for attempt := 0; ; attempt++ {
+		batchSize := a.cfg.ExpandBatchSize
+		if batchSize <= 0 {
+			batchSize = len(pending)
+		}
+
+		var failed []uint32
+		for start := 0; start < len(pending); start += batchSize {
+			end := start + batchSize
+			if end > len(pending) {
+				end = len(pending)
+			}
+
+			titles, err := a.state.titlesOf(pending[start:end])
+			if err != nil {
+				return false, errors.Wrap(err, "failed to load the frontier")
+			}
+
+			batchFailed, skipped, batchReached, err := a.expandPages(ctx, taskID, titles, targetKey, next, layer)
+			failed = append(failed, batchFailed...)
+			if err != nil {
+				return false, err
+			}
+
+			if batchReached && !a.recordsLinks() {
+				a.stats.SkippedFetches = skipped + len(pending) - end
+				return true, nil
+			}
+
+			reached = reached || batchReached
+		}
+
+		if len(failed) == 0 {
+			return reached, nil
+		}
+
+		if attempt == a.cfg.PageRetries {
+			titles, err := a.state.titlesOf(failed)
+			if err != nil {
+				return false, errors.Wrap(err, "failed to load failed pages")
+			}
+
+			layer.FailedPages = len(failed)
+			a.failedPages = append(a.failedPages, titles...)
+
+			zlog.Error().Fields(map[string]interface{}{
+				"task_id":      taskID.String(),
+				"distance":     layer.Distance,
+				"failed_pages": len(failed),
+			}).Msg("pages cannot be fetched, the result may be incomplete")
+
+			return reached, nil
+		}
+
+		zlog.Warn().Fields(map[string]interface{}{
+			"task_id":      taskID.String(),
+			"distance":     layer.Distance,
+			"failed_pages": len(failed),
+			"attempt":      attempt + 1,
+		}).Msg("retrying failed pages")
+
+		time.Sleep(a.cfg.PageRetryDelay)
+		pending = failed
+	}
+}
+
+// expandPages fetches links of the pages and appends undiscovered ones to next.
+// Unless alternative paths are searched for, it stops as soon as the target is discovered,
+// and the rest of the fetches are cancelled.
+// Pages that cannot be fetched are returned, skipped is the number of pages that were never requested.
+func (a *algorithm) expandPages(
+	ctx context.Context,
+	taskID uuid.UUID,
+	titles []string,
+	targetKey string,
+	next *[]uint32,
+	layer *pathtask.LayerStats,
+) (failed []uint32, skipped int, reached bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan parseResult, 1024)
+	dispatched := make(chan int, 1)
+	go func() {
+		dispatched <- a.expander.expand(ctx, titles, results)
+	}()
+
+	for range titles {
+		a.reportProgress(layer, len(*next))
+
+		result := <-results
+		layer.PagesFetched++
+
+		parent, _, err := a.state.lookup(a.normalize(result.title))
+		if err != nil {
+			return failed, 0, false, err
+		}
+
+		if result.err != nil {
+			layer.FetchErrors++
+			failed = append(failed, parent)
+
+			zlog.Warn().Err(result.err).Fields(map[string]interface{}{
+				"task_id":           taskID.String(),
+				"current_distance":  layer.Distance,
+				"faulty_page_title": result.title,
+			}).Msg("page cannot be parsed")
+
+			continue
+		}
+
+		for _, title := range result.mentionedTitles {
+			key := a.normalize(title)
+			if key != targetKey && a.avoids(key) {
+				continue
+			}
+
+			id, added, err := a.state.add(key, title, parent)
+			if err != nil {
+				return failed, 0, false, err
+			}
+
+			if a.recordsLinks() {
+				err = a.state.addLink(parent, id)
+				if err != nil {
+					return failed, 0, false, err
+				}
+			}
+
+			if !added {
+				continue
+			}
+
+			*next = append(*next, id)
+
+			if key == targetKey {
+				if a.recordsLinks() {
+					reached = true
+					continue
+				}
+
+				// Only the pages that were never requested are saved, the started fetches are wasted anyway.
+				cancel()
+
+				return failed, len(titles) - <-dispatched, true, nil
+			}
+		}
+	}
+
+	return failed, 0, reached, nil
+}
+
+// reportProgress estimates the remaining fetches assuming the rest of the layer
+// discovers new pages at the same rate as the fetched part.
+func (a *algorithm) reportProgress(layer *pathtask.LayerStats, discovered int) {
+	if a.onProgress == nil || time.Since(a.lastProgress) < a.cfg.ProgressInterval {
+		return
+	}
+
+	a.lastProgress = time.Now()
+
+	remaining := layer.FrontierSize - layer.PagesFetched
+	if remaining < 0 {
+		remaining = 0
+	}
+	if layer.Distance < a.cfg.DistanceThreshold && layer.PagesFetched > 0 {
+		remaining += discovered * layer.FrontierSize / layer.PagesFetched
+	}
+
+	a.onProgress(pathtask.Progress{
+		UpdatedAt:                 a.lastProgress,
+		Distance:                  layer.Distance,
+		MaxDistance:               a.cfg.DistanceThreshold,
+		FrontierSize:              layer.FrontierSize,
+		LayerPagesFetched:         layer.PagesFetched,
+		PagesFetched:              a.stats.PagesFetched + layer.PagesFetched,
+		EstimatedRemainingFetches: remaining,
+	})
+}
+
+// isComplete reports whether the result is reliable despite failed pages.
+// Pages failed in the layer where the target was discovered could only lead to paths of the same length.
+func (a *algorithm) isComplete(distance uint, reached bool) bool {
+	for _, layer := range a.stats.Layers {
+		if layer.FailedPages > 0 && (!reached || layer.Distance < distance) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *algorithm) addLayerStats(layer pathtask.LayerStats) {
+	a.stats.PagesFetched += layer.PagesFetched
+	a.stats.FetchErrors += layer.FetchErrors
+	a.stats.Layers = append(a.stats.Layers, layer)
+}
+
+// restore loads the last checkpoint of the task and adds the discovered pages to the search state.
+// Nil is returned if there is no usable checkpoint, and the error is returned only if the pages cannot be added.
+func (a *algorithm) restore(taskID uuid.UUID) (*checkpointState, error) {
+	if a.checkpoints == nil {
+		return nil, nil
+	}
+
+	data, err := a.checkpoints.Load(taskID)
+	if errors.Is(err, checkpoint.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("task_id", taskID.String()).Msg("failed to load checkpoint")
+		return nil, nil
+	}
+
+	var addErr error
+	state, err := decodeCheckpoint(data, func(title string, parent uint32) error {
+		_, _, addErr = a.state.add(a.normalize(title), title, parent)
+		return addErr
+	})
+	if addErr != nil {
+		return nil, addErr
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("task_id", taskID.String()).Msg("failed to decode checkpoint")
+
+		// Pages of the corrupted checkpoint might have been added already.
+		a.state.close()
+		a.state = newSearchState(a.cfg)
+
+		return nil, nil
+	}
+
+	return state, nil
+}
+
+// checkpoint saves the state along with the discovered pages if the previous checkpoint is old enough.
+// Failures are only logged: the search can go on without checkpoints.
+func (a *algorithm) checkpoint(taskID uuid.UUID, state *checkpointState) {
+	if a.checkpoints == nil || time.Since(a.lastCheckpoint) < a.cfg.CheckpointInterval {
+		return
+	}
+
+	startedAt := time.Now()
+
+	state.TitleCount = a.state.len()
+	state.Parents = a.state.parents
+
+	data, err := encodeCheckpoint(state, a.state.forEachTitle)
+	if err != nil {
+		zlog.Error().Err(err).Str("task_id", taskID.String()).Msg("failed to encode checkpoint")
+		return
+	}
+
+	err = a.checkpoints.Save(taskID, data)
+	if err != nil {
+		zlog.Error().Err(err).Str("task_id", taskID.String()).Msg("failed to save checkpoint")
+		return
+	}
+
+	a.lastCheckpoint = time.Now()
+
+	zlog.Info().Fields(map[string]interface{}{
+		"task_id":  taskID.String(),
+		"distance": state.Distance,
+		"size":     len(data),
+		"elapsed":  time.Since(startedAt).String(),
+	}).Msg("checkpoint saved")
 }
 
 func (a *algorithm) normalize(s string) string {
 	return strings.ToLower(s)
 }
 
-func (a *algorithm) parseWorker(ctx context.Context, pageTitles <-chan string, results chan<- parseResult) {
-	for title := range pageTitles {
-		mentioned, err := a.wikiClient.GetMentionedPages(title)
+// canonicalizePages canonicalizes the start and target pages. exist is false if any of them doesn't exist.
+func (a *algorithm) canonicalizePages(ctx context.Context, from, to string) (canonicalFrom, canonicalTo string, exist bool, err error) {
+	canonicalFrom, err = a.canonicalize(ctx, from)
+	if errors.Is(err, ErrPageNotFound) {
+		zlog.Info().Str("from", from).Msg("start page does not exist")
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, errors.Wrap(err, "failed to canonicalize from")
+	}
 
-		select {
-		case <-ctx.Done():
-			return
-		case results <- parseResult{
-			title:           title,
-			mentionedTitles: mentioned,
-			err:             err,
-		}:
-		}
+	canonicalTo, err = a.canonicalize(ctx, to)
+	if errors.Is(err, ErrPageNotFound) {
+		zlog.Info().Str("to", to).Msg("target page does not exist")
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, errors.Wrap(err, "failed to canonicalize to")
 	}
+
+	return canonicalFrom, canonicalTo, true, nil
+}
+
+// canonicalize asks the source for the canonical title.
+// If no source knows the page, the title is used as is.
+func (a *algorithm) canonicalize(ctx context.Context, title string) (string, error) {
+	canonical, err := a.source.Canonicalize(ctx, title)
+	if errors.Is(err, ErrUnknownPage) {
+		return title, nil
+	}
+
+	return canonical, err
 }
@@ -0,0 +1,77 @@
+package wikibfs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// A -> B -> D -> F and A -> C -> {D, E} -> F, G only links to A.
+var testLinks = map[string][]string{
+	"A": {"B", "C"},
+	"B": {"D"},
+	"C": {"D", "E"},
+	"D": {"F"},
+	"E": {"F"},
+	"F": {},
+	"G": {"A"},
+}
+
+func newTestAlgorithm() *algorithm {
+	return newAlgorithm(NewStaticGraph(testLinks), BFSConfig{
+		DistanceThreshold:      6,
+		WorkerCount:            2,
+		DistanceQueryThreshold: 6,
+	}, nil)
+}
+
+// checkPath verifies that the path goes from `from` to `to` by the links of the test graph.
+func checkPath(t *testing.T, path []string, from, to string) {
+	t.Helper()
+
+	if len(path) == 0 || path[0] != from || path[len(path)-1] != to {
+		t.Fatalf("path %v doesn't go from %s to %s", path, from, to)
+	}
+
+	for i := 1; i < len(path); i++ {
+		linked := false
+		for _, next := range testLinks[path[i-1]] {
+			linked = linked || next == path[i]
+		}
+
+		if !linked {
+			t.Fatalf("path %v uses a missing link %s -> %s", path, path[i-1], path[i])
+		}
+	}
+}
+
+func TestFindShortestPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		length   int
+	}{
+		{name: "same page", from: "A", to: "A", length: 1},
+		{name: "direct link", from: "A", to: "B", length: 2},
+		{name: "several layers", from: "A", to: "F", length: 4},
+		{name: "case-insensitive titles", from: "g", to: "f", length: 5},
+		{name: "unreachable", from: "F", to: "A", length: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := newTestAlgorithm().findShortestPath(uuid.New(), tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(path) != tt.length {
+				t.Fatalf("path %v has %d pages, want %d", path, len(path), tt.length)
+			}
+			if tt.length > 0 {
+				checkPath(t, path, strings.ToUpper(tt.from), strings.ToUpper(tt.to))
+			}
+		})
+	}
+}
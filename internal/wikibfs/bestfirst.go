@@ -0,0 +1,200 @@
+package wikibfs
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	zlog "github.com/rs/zerolog/log"
+)
+
+type candidate struct {
+	id       uint32
+	distance uint
+	score    float64
+}
+
+// candidateQueue is a max-heap of candidates by score. Closer candidates go first among equal ones.
+type candidateQueue []candidate
+
+func (q candidateQueue) Len() int { return len(q) }
+
+func (q candidateQueue) Less(i, j int) bool {
+	if q[i].score != q[j].score {
+		return q[i].score > q[j].score
+	}
+
+	return q[i].distance < q[j].distance
+}
+
+func (q candidateQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *candidateQueue) Push(x interface{}) { *q = append(*q, x.(candidate)) }
+
+func (q *candidateQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return c
+}
+
+// findGoodPath runs a best-first search: the most promising pages, scored by similarity to the target,
+// are expanded first, HeuristicBeamWidth pages at a time. The found path is not necessarily the shortest one.
+// Nil is returned if the target is not discovered within HeuristicMaxFetches page fetches.
+func (a *algorithm) findGoodPath(taskID uuid.UUID, from, to string) ([]string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = a.withFetchFlow(ctx, taskID)
+
+	startedAt := time.Now()
+	requests := new(wikiclient.RequestCounter)
+	ctx = wikiclient.WithRequestCounter(ctx, requests)
+
+	defer func() {
+		a.stats.ElapsedMs += time.Since(startedAt).Milliseconds()
+		a.stats.APIRequests += requests.Count()
+	}()
+
+	from, to, exist, err := a.canonicalizePages(ctx, from, to)
+	if err != nil || !exist {
+		return nil, err
+	}
+
+	state := newSearchState(a.cfg)
+	defer state.close()
+
+	fromID, _, err := state.add(a.normalize(from), from, noParent)
+	if err != nil {
+		return nil, err
+	}
+
+	targetKey := a.normalize(to)
+	if targetKey == a.normalize(from) {
+		return []string{to}, nil
+	}
+
+	sim := newSimilarity(ctx, a.source, to, a.cfg.HeuristicCategories)
+
+	// Distances of the discovered pages indexed by their IDs.
+	distances := []uint{0}
+
+	beamWidth := a.cfg.HeuristicBeamWidth
+	if beamWidth <= 0 {
+		beamWidth = 1
+	}
+
+	queue := &candidateQueue{{id: fromID}}
+	for queue.Len() != 0 && a.stats.HeuristicPagesFetched < a.cfg.HeuristicMaxFetches {
+		var batch []uint32
+		for queue.Len() != 0 && len(batch) < beamWidth {
+			c := heap.Pop(queue).(candidate)
+			if c.distance < a.cfg.DistanceThreshold {
+				batch = append(batch, c.id)
+			}
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		titles, err := state.titlesOf(batch)
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := a.expandCandidates(ctx, state, sim, titles, targetKey, &distances, queue)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			targetID, _, err := state.lookup(targetKey)
+			if err != nil {
+				return nil, err
+			}
+
+			zlog.Info().Fields(map[string]interface{}{
+				"task_id":       taskID.String(),
+				"distance":      distances[targetID],
+				"pages_fetched": a.stats.HeuristicPagesFetched,
+			}).Msg("best-first search discovered the target")
+
+			return state.path(targetID, to)
+		}
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"task_id":       taskID.String(),
+		"pages_fetched": a.stats.HeuristicPagesFetched,
+	}).Msg("best-first search didn't discover the target")
+
+	return nil, nil
+}
+
+// expandCandidates fetches links of the pages and pushes undiscovered ones to the queue.
+// Pages that cannot be fetched are skipped: the search doesn't have to be exhaustive.
+func (a *algorithm) expandCandidates(
+	ctx context.Context,
+	state *searchState,
+	sim *similarity,
+	titles []string,
+	targetKey string,
+	distances *[]uint,
+	queue *candidateQueue,
+) (found bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan parseResult, len(titles))
+	go a.expander.expand(ctx, titles, results)
+
+	for range titles {
+		result := <-results
+		a.stats.PagesFetched++
+		a.stats.HeuristicPagesFetched++
+
+		if result.err != nil {
+			a.stats.FetchErrors++
+			zlog.Warn().Err(result.err).Str("title", result.title).Msg("page cannot be parsed")
+
+			continue
+		}
+
+		parent, _, err := state.lookup(a.normalize(result.title))
+		if err != nil {
+			return false, err
+		}
+
+		distance := (*distances)[parent] + 1
+		parentScore := sim.pageScore(ctx, result.title, result.mentionedTitles)
+
+		for _, title := range result.mentionedTitles {
+			key := a.normalize(title)
+			id, added, err := state.add(key, title, parent)
+			if err != nil {
+				return false, err
+			}
+			if !added {
+				continue
+			}
+
+			*distances = append(*distances, distance)
+
+			if key == targetKey {
+				return true, nil
+			}
+
+			heap.Push(queue, candidate{
+				id:       id,
+				distance: distance,
+				score:    sim.score(title, parentScore),
+			})
+		}
+	}
+
+	return false, nil
+}
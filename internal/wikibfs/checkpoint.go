@@ -0,0 +1,139 @@
+package wikibfs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/pkg/errors"
+)
+
+// Titles are much shorter, longer lengths mean the checkpoint is corrupted.
+const maxTitleLength = 1 << 16
+
+// checkpointState is enough to continue the search from the last completed layer.
+// Discovered pages are stored in the order of their IDs, so the frontier and parents stay valid after restoring.
+// Their titles follow the encoded state, so they are streamed instead of being collected in memory.
+type checkpointState struct {
+	From string
+	To   string
+
+	// Number of completed layers.
+	Distance uint
+
+	// Titles are set only in checkpoints made before titles were streamed, TitleCount is used otherwise.
+	Titles     []string
+	TitleCount int
+
+	Parents []uint32
+	Queue   []uint32
+
+	// Links of the expanded pages, recorded only if alternative paths are searched for.
+	Links map[uint32][]uint32
+
+	// Statistics of the completed layers.
+	Stats pathtask.Stats
+
+	FailedPages []string
+}
+
+// encodeCheckpoint encodes the state followed by the titles passed by forEachTitle to its callback.
+func encodeCheckpoint(state *checkpointState, forEachTitle func(fn func(title string) error) error) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	err := gob.NewEncoder(w).Encode(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode failed")
+	}
+
+	var count int
+	length := make([]byte, binary.MaxVarintLen64)
+	err = forEachTitle(func(title string) error {
+		n := binary.PutUvarint(length, uint64(len(title)))
+		_, err := w.Write(length[:n])
+		if err == nil {
+			_, err = io.WriteString(w, title)
+		}
+
+		count++
+
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode titles")
+	}
+	if count != state.TitleCount {
+		return nil, errors.Errorf("%d titles encoded instead of %d", count, state.TitleCount)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "compression failed")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeCheckpoint decodes the state and passes the titles with their parents to addTitle in the order of IDs.
+func decodeCheckpoint(data []byte, addTitle func(title string, parent uint32) error) (*checkpointState, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "decompression failed")
+	}
+	defer gz.Close()
+
+	// gob doesn't read ahead of the state from a ByteReader, so the titles can be read after it.
+	r := bufio.NewReader(gz)
+
+	state := new(checkpointState)
+	err = gob.NewDecoder(r).Decode(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode failed")
+	}
+
+	if len(state.Titles)+state.TitleCount != len(state.Parents) {
+		return nil, errors.New("titles don't match parents")
+	}
+
+	for id, title := range state.Titles {
+		err = addTitle(title, state.Parents[id])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var title []byte
+	for id := len(state.Titles); id < len(state.Parents); id++ {
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode titles")
+		}
+		if length > maxTitleLength {
+			return nil, errors.Errorf("title length %d is too big", length)
+		}
+
+		if uint64(cap(title)) < length {
+			title = make([]byte, length)
+		}
+		title = title[:length]
+
+		_, err = io.ReadFull(r, title)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode titles")
+		}
+
+		err = addTitle(string(title), state.Parents[id])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state.Titles = nil
+
+	return state, nil
+}
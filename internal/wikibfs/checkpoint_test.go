@@ -0,0 +1,71 @@
+package wikibfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckpoint_StreamsSpilledTitles(t *testing.T) {
+	titles := []string{"Apple", "Fruit", "Tree", "Plant"}
+
+	state := newSearchState(BFSConfig{SpillDir: t.TempDir()})
+	defer state.close()
+
+	for i, title := range titles {
+		parent := noParent
+		if i > 0 {
+			parent = 0
+		}
+
+		_, _, err := state.add(title, title, parent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Half of the titles are appended to the spill file after spilling.
+		if i == 1 {
+			err = state.spill()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	data, err := encodeCheckpoint(&checkpointState{
+		From:       "Apple",
+		To:         "Plant",
+		Distance:   1,
+		TitleCount: state.len(),
+		Parents:    state.parents,
+		Queue:      []uint32{1, 2, 3},
+	}, state.forEachTitle)
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+
+	var restored []string
+	var parents []uint32
+	decoded, err := decodeCheckpoint(data, func(title string, parent uint32) error {
+		restored = append(restored, title)
+		parents = append(parents, parent)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(restored, titles) {
+		t.Errorf("titles = %v, want %v", restored, titles)
+	}
+	if !reflect.DeepEqual(parents, state.parents) {
+		t.Errorf("parents = %v, want %v", parents, state.parents)
+	}
+	if decoded.To != "Plant" || !reflect.DeepEqual(decoded.Queue, []uint32{1, 2, 3}) {
+		t.Errorf("decoded state = %+v", decoded)
+	}
+
+	_, err = decodeCheckpoint(data[:len(data)/2], func(string, uint32) error { return nil })
+	if err == nil {
+		t.Error("truncated checkpoint was decoded")
+	}
+}
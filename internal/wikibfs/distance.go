@@ -0,0 +1,216 @@
+package wikibfs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+const (
+	directionForward  = "forward"
+	directionBackward = "backward"
+)
+
+// searchSide is one direction of the bidirectional search.
+// Only distances of the visited pages are kept, predecessors are not tracked.
+type searchSide struct {
+	direction string
+	expander  expander
+
+	// Keyed by normalized titles.
+	visited  map[string]uint
+	frontier []string
+	depth    uint
+
+	keyBytes int64
+}
+
+func newSearchSide(direction string, expander expander, root, rootKey string) *searchSide {
+	return &searchSide{
+		direction: direction,
+		expander:  expander,
+		visited:   map[string]uint{rootKey: 0},
+		frontier:  []string{root},
+		keyBytes:  int64(len(rootKey)),
+	}
+}
+
+// findDistance returns the length of the shortest path, or nil if the target is not reachable within maxDistance.
+// The search goes from both ends: outgoing links are followed from the start page and incoming links
+// from the target, and the smaller frontier is expanded first. The shortest path is found as soon as
+// the sides meet. If the source cannot provide incoming links, only the forward side is expanded.
+func (a *algorithm) findDistance(taskID uuid.UUID, from, to string, maxDistance uint) (*int, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = a.withFetchFlow(ctx, taskID)
+
+	startedAt := time.Now()
+	requests := new(wikiclient.RequestCounter)
+	ctx = wikiclient.WithRequestCounter(ctx, requests)
+
+	defer func() {
+		a.stats.ElapsedMs = time.Since(startedAt).Milliseconds()
+		a.stats.APIRequests = requests.Count()
+		a.complete = len(a.failedPages) == 0
+	}()
+
+	from, to, exist, err := a.canonicalizePages(ctx, from, to)
+	if err != nil || !exist {
+		return nil, err
+	}
+
+	if a.normalize(from) == a.normalize(to) {
+		distance := 0
+		return &distance, nil
+	}
+
+	forward := newSearchSide(directionForward, a.expander, from, a.normalize(from))
+	backward := newSearchSide(directionBackward, newIncomingExpander(a.source, a.cfg.WorkerCount), to, a.normalize(to))
+
+	bidirectional := true
+	for forward.depth+backward.depth < maxDistance {
+		side, other := forward, backward
+		if bidirectional && len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+
+		if len(side.frontier) == 0 {
+			break
+		}
+
+		layer := pathtask.LayerStats{
+			Distance:     side.depth + 1,
+			Direction:    side.direction,
+			FrontierSize: len(side.frontier),
+		}
+
+		layerStartedAt := time.Now()
+		distance, supported, err := a.expandSide(ctx, taskID, side, other, &layer)
+
+		layer.ElapsedMs = time.Since(layerStartedAt).Milliseconds()
+		a.addLayerStats(layer)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !supported {
+			zlog.Info().Str("task_id", taskID.String()).Msg("incoming links are not supported, the search goes forward only")
+
+			bidirectional = false
+			continue
+		}
+
+		if distance != nil {
+			zlog.Info().Fields(map[string]interface{}{
+				"task_id":  taskID.String(),
+				"from":     from,
+				"to":       to,
+				"distance": *distance,
+			}).Msg("distance found")
+
+			return distance, nil
+		}
+	}
+
+	zlog.Info().Str("from", from).Str("to", to).Uint("max_distance", maxDistance).Msg("page is not reachable")
+
+	return nil, nil
+}
+
+// expandSide expands the frontier of the side by one layer and stops as soon as it meets the other side.
+// supported is false if the source cannot expand the side.
+func (a *algorithm) expandSide(
+	ctx context.Context,
+	taskID uuid.UUID,
+	side, other *searchSide,
+	layer *pathtask.LayerStats,
+) (distance *int, supported bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan parseResult, 1024)
+	go side.expander.expand(ctx, side.frontier, results)
+
+	var next []string
+	for i := range side.frontier {
+		a.reportProgress(layer, len(next))
+
+		result := <-results
+		layer.PagesFetched++
+
+		if errors.Is(result.err, ErrNotSupported) {
+			return nil, false, nil
+		}
+
+		if result.err != nil {
+			layer.FetchErrors++
+			layer.FailedPages++
+			a.failedPages = append(a.failedPages, result.title)
+
+			zlog.Warn().Err(result.err).Fields(map[string]interface{}{
+				"task_id":           taskID.String(),
+				"direction":         side.direction,
+				"faulty_page_title": result.title,
+			}).Msg("page cannot be parsed")
+
+			continue
+		}
+
+		for _, title := range result.mentionedTitles {
+			key := a.normalize(title)
+			if _, visited := side.visited[key]; visited {
+				continue
+			}
+
+			side.visited[key] = side.depth + 1
+			side.keyBytes += int64(len(key))
+			layer.Discovered++
+			next = append(next, title)
+
+			// Without failed pages, the sides can only meet at the last layer of the other side,
+			// so the first meeting gives the shortest distance.
+			if otherDepth, met := other.visited[key]; met {
+				d := int(side.depth + 1 + otherDepth)
+				a.stats.SkippedFetches = len(side.frontier) - i - 1
+
+				return &d, true, nil
+			}
+
+			err = a.checkSidesBudget(side, other)
+			if err != nil {
+				return nil, true, err
+			}
+		}
+	}
+
+	side.depth++
+	side.frontier = next
+
+	return nil, true, nil
+}
+
+func (a *algorithm) checkSidesBudget(sides ...*searchSide) error {
+	if a.cfg.MemoryBudget <= 0 {
+		return nil
+	}
+
+	var pages int
+	var size int64
+	for _, side := range sides {
+		pages += len(side.visited)
+		size += side.keyBytes + int64(len(side.visited))*inMemoryPageOverhead
+	}
+
+	if size > a.cfg.MemoryBudget {
+		return errors.Wrapf(ErrMemoryBudgetExceeded, "%d pages discovered, the search state takes about %d MB", pages, size>>20)
+	}
+
+	return nil
+}
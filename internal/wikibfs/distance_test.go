@@ -0,0 +1,76 @@
+package wikibfs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFindDistance(t *testing.T) {
+	tests := []struct {
+		name        string
+		from, to    string
+		maxDistance uint
+		want        *int
+	}{
+		{name: "same page", from: "A", to: "a", maxDistance: 6, want: intPtr(0)},
+		{name: "direct link", from: "A", to: "C", maxDistance: 6, want: intPtr(1)},
+		{name: "several layers", from: "G", to: "F", maxDistance: 6, want: intPtr(4)},
+		{name: "over the limit", from: "G", to: "F", maxDistance: 3, want: nil},
+		{name: "unreachable", from: "F", to: "A", maxDistance: 6, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, err := newTestAlgorithm().findDistance(uuid.New(), tt.from, tt.to, tt.maxDistance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(distance, tt.want) {
+				t.Errorf("distance = %v, want %v", deref(distance), deref(tt.want))
+			}
+		})
+	}
+}
+
+func TestFindDistance_ForwardOnly(t *testing.T) {
+	// Sources without incoming links are searched from the start page only.
+	source := NewLayeredSource(forwardOnlySource{NewStaticGraph(testLinks)})
+	algo := newAlgorithm(source, BFSConfig{DistanceThreshold: 6, WorkerCount: 2}, nil)
+
+	distance, err := algo.findDistance(uuid.New(), "A", "F", 6)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if distance == nil || *distance != 3 {
+		t.Errorf("distance = %v, want 3", deref(distance))
+	}
+}
+
+type forwardOnlySource struct {
+	graph *StaticGraph
+}
+
+func (s forwardOnlySource) OutgoingLinks(ctx context.Context, title string) ([]string, error) {
+	return s.graph.OutgoingLinks(ctx, title)
+}
+
+func (s forwardOnlySource) Canonicalize(ctx context.Context, title string) (string, error) {
+	return s.graph.Canonicalize(ctx, title)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func deref(v *int) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	return *v
+}
@@ -0,0 +1,312 @@
+package wikibfs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/fairqueue"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Publisher publishes messages directly to queues.
+type Publisher interface {
+	PublishToQueue(queue string, message interface{}) error
+}
+
+type DistributedConfig struct {
+	// Queue consumed by all workers for expansion requests.
+	ExpansionQueue string
+
+	// Queue this worker receives expansion results to.
+	ResultQueue string
+
+	// Maximum number of pages in one expansion request.
+	ShardSize int
+
+	// Requests that are not answered in time are published again.
+	ShardTimeout time.Duration
+
+	// Pages of a request are reported as failed after this many attempts.
+	MaxShardAttempts int
+
+	// Maximum number of unanswered requests of a layer, 0 means unlimited.
+	// The rest are published as the answers come, so they are not fetched once the target is found.
+	MaxInFlightShards int
+}
+
+// Coordinator spreads BFS layers over the fleet. The worker coordinating a task shards
+// its frontier into expansion requests, any worker fetches links of the pages in a request
+// and publishes them back, and the coordinator merges the results into the layer.
+type Coordinator struct {
+	publisher   Publisher
+	sources     Sources
+	cfg         DistributedConfig
+	workerCount int
+
+	mu      sync.Mutex
+	pending map[uuid.UUID]chan<- taskqueue.ExpansionResult
+}
+
+// NewCoordinator creates a coordinator. workerCount is the number of goroutines
+// used to fetch pages of a single expansion request.
+func NewCoordinator(publisher Publisher, sources Sources, cfg DistributedConfig, workerCount int) *Coordinator {
+	return &Coordinator{
+		publisher:   publisher,
+		sources:     sources,
+		cfg:         cfg,
+		workerCount: workerCount,
+		pending:     make(map[uuid.UUID]chan<- taskqueue.ExpansionResult),
+	}
+}
+
+// HandleExpansion fetches links of the requested pages and publishes the result to the coordinator.
+func (c *Coordinator) HandleExpansion(request taskqueue.ExpansionRequest) error {
+	weight := request.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+
+	requests := new(wikiclient.RequestCounter)
+	ctx := wikiclient.WithRequestCounter(context.Background(), requests)
+
+	flow := request.Flow
+	if flow == "" {
+		flow = request.TaskID.String()
+	}
+
+	ctx, cancel := context.WithCancel(fairqueue.WithFlow(ctx, flow, weight))
+	defer cancel()
+
+	results := make(chan parseResult, len(request.Titles))
+	go newLocalExpander(c.sources.forTime(request.AsOf), c.workerCount).expand(ctx, request.Titles, results)
+
+	response := taskqueue.ExpansionResult{
+		ID:     request.ID,
+		TaskID: request.TaskID,
+		Links:  make(map[string][]string, len(request.Titles)),
+	}
+
+	for range request.Titles {
+		result := <-results
+		if result.err != nil {
+			if response.Failed == nil {
+				response.Failed = make(map[string]string)
+			}
+
+			response.Failed[result.title] = result.err.Error()
+
+			continue
+		}
+
+		response.Links[result.title] = result.mentionedTitles
+	}
+
+	response.Requests = requests.Count()
+
+	err := c.publisher.PublishToQueue(request.ReplyTo, response)
+	if err != nil {
+		return errors.Wrap(err, "failed to publish result")
+	}
+
+	zlog.Debug().Fields(map[string]interface{}{
+		"id":      request.ID.String(),
+		"task_id": request.TaskID.String(),
+		"pages":   len(request.Titles),
+		"failed":  len(response.Failed),
+	}).Msg("expansion request handled")
+
+	return nil
+}
+
+// HandleResult passes the result to the task waiting for it.
+// Results of unknown requests (e.g. duplicates of resent requests) are dropped.
+func (c *Coordinator) HandleResult(result taskqueue.ExpansionResult) {
+	c.mu.Lock()
+	inbox, ok := c.pending[result.ID]
+	delete(c.pending, result.ID)
+	c.mu.Unlock()
+
+	if !ok {
+		zlog.Debug().Str("id", result.ID.String()).Msg("dropped expansion result of unknown request")
+		return
+	}
+
+	inbox <- result
+}
+
+func (c *Coordinator) expanderFor(taskID uuid.UUID, asOf *time.Time, flow string, weight float64) expander {
+	return &distributedExpander{
+		coordinator: c,
+		taskID:      taskID,
+		asOf:        asOf,
+		flow:        flow,
+		weight:      weight,
+	}
+}
+
+func (c *Coordinator) register(requestID uuid.UUID, inbox chan<- taskqueue.ExpansionResult) {
+	c.mu.Lock()
+	c.pending[requestID] = inbox
+	c.mu.Unlock()
+}
+
+func (c *Coordinator) unregister(requestID uuid.UUID) {
+	c.mu.Lock()
+	delete(c.pending, requestID)
+	c.mu.Unlock()
+}
+
+type distributedExpander struct {
+	coordinator *Coordinator
+	taskID      uuid.UUID
+	asOf        *time.Time
+	flow        string
+	weight      float64
+}
+
+type shard struct {
+	request  taskqueue.ExpansionRequest
+	attempts int
+	deadline time.Time
+}
+
+// expand publishes shards of the titles keeping at most MaxInFlightShards of them unanswered,
+// so shards that are not published yet are never fetched if ctx is cancelled.
+func (e *distributedExpander) expand(ctx context.Context, titles []string, results chan<- parseResult) (dispatched int) {
+	c := e.coordinator
+	shards := make(map[uuid.UUID]*shard)
+
+	// Every shard is answered at most once, so the inbox never blocks HandleResult.
+	inbox := make(chan taskqueue.ExpansionResult, (len(titles)+c.cfg.ShardSize-1)/c.cfg.ShardSize)
+
+	defer func() {
+		for id := range shards {
+			c.unregister(id)
+		}
+	}()
+
+	// publishNext publishes shards of the rest of the titles while the window is not full.
+	publishNext := func() {
+		for dispatched < len(titles) && (c.cfg.MaxInFlightShards <= 0 || len(shards) < c.cfg.MaxInFlightShards) {
+			end := dispatched + c.cfg.ShardSize
+			if end > len(titles) {
+				end = len(titles)
+			}
+
+			s := &shard{
+				request: taskqueue.ExpansionRequest{
+					ID:      uuid.New(),
+					TaskID:  e.taskID,
+					Titles:  titles[dispatched:end],
+					AsOf:    e.asOf,
+					Flow:    e.flow,
+					Weight:  e.weight,
+					ReplyTo: c.cfg.ResultQueue,
+				},
+			}
+
+			shards[s.request.ID] = s
+			c.register(s.request.ID, inbox)
+			e.publish(s)
+
+			dispatched = end
+		}
+	}
+
+	publishNext()
+
+	ticker := time.NewTicker(c.cfg.ShardTimeout / 4)
+	defer ticker.Stop()
+
+	for len(shards) != 0 {
+		select {
+		case <-ctx.Done():
+			return dispatched
+
+		case response := <-inbox:
+			s, ok := shards[response.ID]
+			if !ok {
+				continue
+			}
+
+			delete(shards, response.ID)
+			wikiclient.RequestCounterFromContext(ctx).Add(response.Requests)
+
+			for _, title := range s.request.Titles {
+				result := parseResult{title: title, mentionedTitles: response.Links[title]}
+				if message, failed := response.Failed[title]; failed {
+					result.err = errors.New(message)
+				}
+
+				if !e.send(ctx, results, result) {
+					return dispatched
+				}
+			}
+
+			publishNext()
+
+		case now := <-ticker.C:
+			var expired []*shard
+			for id, s := range shards {
+				if now.Before(s.deadline) {
+					continue
+				}
+
+				if s.attempts < c.cfg.MaxShardAttempts {
+					e.publish(s)
+					continue
+				}
+
+				delete(shards, id)
+				c.unregister(id)
+				expired = append(expired, s)
+			}
+
+			for _, s := range expired {
+				zlog.Error().Fields(map[string]interface{}{
+					"id":       s.request.ID.String(),
+					"task_id":  e.taskID.String(),
+					"attempts": s.attempts,
+				}).Msg("expansion request was not answered")
+
+				err := fmt.Errorf("expansion request was not answered after %d attempts", s.attempts)
+				for _, title := range s.request.Titles {
+					if !e.send(ctx, results, parseResult{title: title, err: err}) {
+						return dispatched
+					}
+				}
+			}
+
+			publishNext()
+		}
+	}
+
+	return dispatched
+}
+
+// publish sends the request and schedules the next attempt.
+// Publish failures are retried on the next attempt as well.
+func (e *distributedExpander) publish(s *shard) {
+	s.attempts++
+	s.deadline = time.Now().Add(e.coordinator.cfg.ShardTimeout)
+
+	err := e.coordinator.publisher.PublishToQueue(e.coordinator.cfg.ExpansionQueue, s.request)
+	if err != nil {
+		zlog.Error().Err(err).Str("id", s.request.ID.String()).Msg("failed to publish expansion request")
+	}
+}
+
+func (e *distributedExpander) send(ctx context.Context, results chan<- parseResult, result parseResult) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case results <- result:
+		return true
+	}
+}
@@ -0,0 +1,61 @@
+package wikibfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+)
+
+// loopbackPublisher handles expansion requests and results in place.
+type loopbackPublisher struct {
+	coordinator *Coordinator
+}
+
+func (p *loopbackPublisher) PublishToQueue(_ string, message interface{}) error {
+	switch m := message.(type) {
+	case taskqueue.ExpansionRequest:
+		go func() {
+			_ = p.coordinator.HandleExpansion(m)
+		}()
+
+	case taskqueue.ExpansionResult:
+		p.coordinator.HandleResult(m)
+	}
+
+	return nil
+}
+
+func TestDistributedExpander_PublishesWithinWindow(t *testing.T) {
+	publisher := new(loopbackPublisher)
+	publisher.coordinator = NewCoordinator(publisher, Sources{Latest: NewStaticGraph(testLinks)}, DistributedConfig{
+		ShardSize:         1,
+		ShardTimeout:      time.Minute,
+		MaxShardAttempts:  1,
+		MaxInFlightShards: 1,
+	}, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	titles := []string{"A", "B", "C", "D", "E", "F"}
+	results := make(chan parseResult)
+	dispatched := make(chan int, 1)
+	go func() {
+		dispatched <- publisher.coordinator.expanderFor(uuid.New(), nil, "", 1).expand(ctx, titles, results)
+	}()
+
+	result := <-results
+	if result.err != nil || result.title != "A" {
+		t.Fatalf("first result = %+v, want links of A", result)
+	}
+
+	cancel()
+
+	// The second shard may have been published after the first one was answered, but not the rest.
+	if n := <-dispatched; n > 2 {
+		t.Errorf("%d pages were requested after the first result, want at most 2", n)
+	}
+}
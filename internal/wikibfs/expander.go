@@ -0,0 +1,102 @@
+package wikibfs
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// localExpander fetches pages from the source with a pool of goroutines.
+type localExpander struct {
+	source      GraphSource
+	workerCount int
+
+	// If set, pages linking to the given ones are fetched instead of the linked ones.
+	incoming bool
+}
+
+func newLocalExpander(source GraphSource, workerCount int) *localExpander {
+	return &localExpander{
+		source:      source,
+		workerCount: workerCount,
+	}
+}
+
+// newIncomingExpander creates an expander that fetches incoming links.
+// Pages fail with ErrNotSupported if the source cannot provide them.
+func newIncomingExpander(source GraphSource, workerCount int) *localExpander {
+	return &localExpander{
+		source:      source,
+		workerCount: workerCount,
+		incoming:    true,
+	}
+}
+
+func (e *localExpander) expand(ctx context.Context, titles []string, results chan<- parseResult) (dispatched int) {
+	pageTitles := make(chan string)
+
+	workerCount := e.workerCount
+	if workerCount > len(titles) {
+		workerCount = len(titles)
+	}
+
+	for i := 0; i < workerCount; i++ {
+		go e.parseWorker(ctx, pageTitles, results)
+	}
+
+	defer close(pageTitles)
+
+	for _, title := range titles {
+		select {
+		case <-ctx.Done():
+			return dispatched
+		case pageTitles <- title:
+			dispatched++
+		}
+	}
+
+	return dispatched
+}
+
+func (e *localExpander) parseWorker(ctx context.Context, pageTitles <-chan string, results chan<- parseResult) {
+	for title := range pageTitles {
+		var result parseResult
+		if e.incoming {
+			result = fetchIncoming(ctx, e.source, title)
+		} else {
+			result = fetchPage(ctx, e.source, title)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case results <- result:
+		}
+	}
+}
+
+func fetchIncoming(ctx context.Context, source GraphSource, title string) parseResult {
+	linking, err := IncomingLinks(ctx, source, title)
+	if errors.Is(err, ErrUnknownPage) {
+		linking, err = nil, nil
+	}
+
+	return parseResult{
+		title:           title,
+		mentionedTitles: linking,
+		err:             err,
+	}
+}
+
+func fetchPage(ctx context.Context, source GraphSource, title string) parseResult {
+	mentioned, err := source.OutgoingLinks(ctx, title)
+	if errors.Is(err, ErrUnknownPage) {
+		mentioned, err = nil, nil
+	}
+
+	return parseResult{
+		title:           title,
+		mentionedTitles: mentioned,
+		err:             err,
+	}
+}
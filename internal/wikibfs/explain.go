@@ -0,0 +1,62 @@
+package wikibfs
+
+import (
+	"context"
+
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// explainPath finds the sentence containing the link to the next page for every hop of the path.
+// Links to redirects are recognized too. Hops that cannot be explained are returned without a sentence.
+// ErrNotSupported is returned if the source cannot provide the content of the pages.
+func explainPath(ctx context.Context, source GraphSource, path []string) ([]pathtask.HopExplanation, error) {
+	if _, ok := source.(ContentSource); !ok {
+		return nil, ErrNotSupported
+	}
+
+	explanations := make([]pathtask.HopExplanation, 0, len(path))
+	for i := 0; i+1 < len(path); i++ {
+		explanation := pathtask.HopExplanation{
+			From: path[i],
+			To:   path[i+1],
+		}
+
+		linkContext, err := explainHop(ctx, source, path[i], path[i+1])
+		if err != nil {
+			zlog.Warn().Err(err).Str("from", path[i]).Str("to", path[i+1]).Msg("failed to explain the hop")
+		}
+		if linkContext != nil {
+			explanation.Section = linkContext.Section
+			explanation.Sentence = linkContext.Sentence
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations, nil
+}
+
+// explainHop returns nil if the link cannot be found in the wikitext.
+func explainHop(ctx context.Context, source GraphSource, from, to string) (*wikiclient.LinkContext, error) {
+	text, err := Wikitext(ctx, source, from)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get wikitext")
+	}
+
+	linkContext, found := wikiclient.FindLinkContext(text, to)
+	if found {
+		return linkContext, nil
+	}
+
+	redirects, err := Redirects(ctx, source, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get redirects")
+	}
+
+	linkContext, _ = wikiclient.FindLinkContext(text, redirects...)
+
+	return linkContext, nil
+}
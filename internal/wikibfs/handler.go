@@ -1,24 +1,67 @@
 package wikibfs
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/checkpoint"
+	"github.com/lodthe/wiki-graph/internal/distcache"
+	"github.com/lodthe/wiki-graph/internal/fairqueue"
+	"github.com/lodthe/wiki-graph/internal/hubrank"
 	"github.com/lodthe/wiki-graph/internal/pathtask"
-	"github.com/lodthe/wiki-graph/pkg/wikiclient"
 	"github.com/pkg/errors"
 	zlog "github.com/rs/zerolog/log"
 )
 
+// Sources are graph sources the handler can run the algorithm on.
+type Sources struct {
+	// Latest is used for regular tasks.
+	Latest GraphSource
+
+	// AsOf returns a source of the graph at the given moment.
+	// If nil, historical tasks are processed with the latest graph.
+	AsOf func(asOf time.Time) GraphSource
+}
+
+func (s Sources) forTime(asOf *time.Time) GraphSource {
+	if asOf != nil && s.AsOf != nil {
+		return s.AsOf(*asOf)
+	}
+
+	return s.Latest
+}
+
 type Handler struct {
-	repository pathtask.Repository
-	wikiClient *wikiclient.Client
-	bfsConfig  BFSConfig
+	repository  pathtask.Repository
+	distances   distcache.Repository
+	hubs        hubrank.Repository
+	checkpoints checkpoint.Store
+	sources     Sources
+	coordinator *Coordinator
+	bfsConfig   BFSConfig
 }
 
-func NewHandler(repo pathtask.Repository, wiki *wikiclient.Client, config BFSConfig) *Handler {
+// NewHandler creates a handler. If checkpoints is nil, interrupted tasks are restarted from scratch.
+// If coordinator is nil, pages are fetched by this worker only. If distances is nil, distances are not cached.
+// If hubs is nil, the options referring to hub rankings are ignored.
+func NewHandler(
+	repo pathtask.Repository,
+	distances distcache.Repository,
+	hubs hubrank.Repository,
+	checkpoints checkpoint.Store,
+	sources Sources,
+	coordinator *Coordinator,
+	config BFSConfig,
+) *Handler {
 	return &Handler{
-		repository: repo,
-		wikiClient: wiki,
-		bfsConfig:  config,
+		repository:  repo,
+		distances:   distances,
+		hubs:        hubs,
+		checkpoints: checkpoints,
+		sources:     sources,
+		coordinator: coordinator,
+		bfsConfig:   config,
 	}
 }
 
@@ -29,31 +72,65 @@ func (h *Handler) HandleTask(taskID uuid.UUID) error {
 		return errors.Wrap(err, "fetch failed")
 	}
 
-	if task.Status != pathtask.StatusPending {
+	switch task.Status {
+	case pathtask.StatusPending:
+		zlog.Info().Str("id", taskID.String()).Msg("start processing task")
+
+		err = h.repository.UpdateStatus(task.ID, pathtask.StatusPending, pathtask.StatusProcessing)
+		if err != nil {
+			zlog.Error().Err(err).Str("id", taskID.String()).Msg("failed to update task status to PROCESSING")
+			return errors.Wrap(err, "failed to update status")
+		}
+
+	case pathtask.StatusProcessing:
+		// The task is redelivered because the previous attempt failed or the worker was stopped.
+		zlog.Info().Str("id", taskID.String()).Msg("resume processing task")
+
+	default:
 		zlog.Info().Str("id", task.ID.String()).Uint("status", uint(task.Status)).Msg("task has invalid status")
 		return nil
 	}
 
-	zlog.Info().Str("id", taskID.String()).Msg("start processing task")
-
-	err = h.repository.UpdateStatus(task.ID, pathtask.StatusPending, pathtask.StatusProcessing)
+	// Failed attempts are requeued, so a task that fails every time would be redelivered forever.
+	attempts, err := h.repository.StartAttempt(task.ID)
 	if err != nil {
-		zlog.Error().Err(err).Str("id", taskID.String()).Msg("failed to update task status to PROCESSING")
-		return errors.Wrap(err, "failed to update status")
+		zlog.Error().Err(err).Str("id", taskID.String()).Msg("failed to count the attempt")
+		return errors.Wrap(err, "failed to count the attempt")
+	}
+	if h.bfsConfig.MaxTaskAttempts != 0 && attempts > h.bfsConfig.MaxTaskAttempts {
+		return h.fail(task, &pathtask.Result{}, errors.Errorf("gave up after %d attempts", h.bfsConfig.MaxTaskAttempts))
 	}
 
-	algo := newAlgorithm(h.wikiClient, h.bfsConfig)
-	path, err := algo.findShortestPath(task.ID, task.From, task.To)
+	source := h.sources.forTime(task.Options.AsOf)
+
+	var result *pathtask.Result
+	switch task.Options.Kind {
+	case pathtask.KindDistance:
+		result, err = h.findDistance(source, task)
+
+	case pathtask.KindNeighborhood:
+		result, err = h.findNeighborhood(source, task)
+
+	default:
+		result, err = h.findShortestPath(source, task)
+		if err == nil && task.Options.Explain {
+			h.explain(source, task, result)
+		}
+	}
+	if errors.Is(err, ErrMemoryBudgetExceeded) {
+		// Retrying would exceed the budget again.
+		return h.fail(task, result, err)
+	}
 	if err != nil {
 		zlog.Error().Err(err).Str("id", taskID.String()).Msg("algorithm failed")
 		return errors.Wrap(err, "algorithm failed")
 	}
 
-	err = h.repository.SetResult(task.ID, &pathtask.Result{ShortestPath: path})
+	err = h.repository.SetResult(task.ID, result)
 	if err != nil {
 		zlog.Error().Err(err).Fields(map[string]interface{}{
 			"id":   task.ID.String(),
-			"path": path,
+			"path": result.ShortestPath,
 		}).Msg("failed to set result")
 
 		return errors.Wrap(err, "setting result failed")
@@ -65,5 +142,282 @@ func (h *Handler) HandleTask(taskID uuid.UUID) error {
 		return errors.Wrap(err, "failed to update status")
 	}
 
+	if task.Options.Kind == pathtask.KindDistance {
+		h.cacheDistance(task, result)
+	}
+
+	if h.checkpoints != nil {
+		err = h.checkpoints.Delete(task.ID)
+		if err != nil {
+			zlog.Error().Err(err).Str("id", taskID.String()).Msg("failed to delete checkpoint")
+		}
+	}
+
+	return nil
+}
+
+// findDistance runs the bidirectional search, or the whole search in the source if it's a PathFinder.
+func (h *Handler) findDistance(source GraphSource, task *pathtask.Task) (*pathtask.Result, error) {
+	maxDistance := h.bfsConfig.DistanceQueryThreshold
+	if task.Options.MaxDistance > 0 && task.Options.MaxDistance < maxDistance {
+		maxDistance = task.Options.MaxDistance
+	}
+
+	if finder, ok := source.(PathFinder); ok {
+		startedAt := time.Now()
+
+		path, err := finder.FindShortestPath(context.Background(), task.From, task.To)
+		if err == nil {
+			result := &pathtask.Result{
+				SearchedDistance: maxDistance,
+				Complete:         true,
+				Stats:            &pathtask.Stats{ElapsedMs: time.Since(startedAt).Milliseconds()},
+			}
+
+			if path != nil && uint(len(path)-1) <= maxDistance {
+				distance := len(path) - 1
+				result.Distance = &distance
+			}
+
+			return result, nil
+		}
+		if !errors.Is(err, ErrNotSupported) {
+			return nil, err
+		}
+	}
+
+	algo := h.newAlgorithm(source, task)
+
+	distance, err := algo.findDistance(task.ID, task.From, task.To, maxDistance)
+	if err != nil && !errors.Is(err, ErrMemoryBudgetExceeded) {
+		return nil, err
+	}
+
+	return &pathtask.Result{
+		Distance:         distance,
+		SearchedDistance: maxDistance,
+		Complete:         algo.complete,
+		FailedPages:      algo.failedPages,
+		Stats:            &algo.stats,
+	}, err
+}
+
+// findNeighborhood explores the pages within MaxDistance hops of the From page, but not farther than DistanceThreshold.
+func (h *Handler) findNeighborhood(source GraphSource, task *pathtask.Task) (*pathtask.Result, error) {
+	hops := h.bfsConfig.DistanceThreshold
+	if task.Options.MaxDistance > 0 && task.Options.MaxDistance < hops {
+		hops = task.Options.MaxDistance
+	}
+
+	algo := h.newAlgorithm(source, task)
+
+	neighbors, truncated, err := algo.findNeighborhood(task.ID, task.From, hops)
+	if err != nil && !errors.Is(err, ErrMemoryBudgetExceeded) {
+		return nil, err
+	}
+
+	return &pathtask.Result{
+		Neighborhood:     neighbors,
+		Truncated:        truncated,
+		SearchedDistance: hops,
+		Complete:         algo.complete,
+		FailedPages:      algo.failedPages,
+		Stats:            &algo.stats,
+	}, err
+}
+
+// explain adds explanations of the hops of the shortest path to the result.
+// Failures are only logged: the path is still worth returning without them.
+func (h *Handler) explain(source GraphSource, task *pathtask.Task, result *pathtask.Result) {
+	if len(result.ShortestPath) < 2 {
+		return
+	}
+
+	ctx := fairqueue.WithFlow(context.Background(), task.FetchFlow(), task.Priority.FetchWeight())
+
+	explanations, err := explainPath(ctx, source, result.ShortestPath)
+	if err != nil {
+		zlog.Warn().Err(err).Str("id", task.ID.String()).Msg("failed to explain the path")
+		return
+	}
+
+	result.Explanations = explanations
+}
+
+// cacheDistance saves the result of a distance query, unless it's historical or incomplete.
+func (h *Handler) cacheDistance(task *pathtask.Task, result *pathtask.Result) {
+	if h.distances == nil || task.Options.AsOf != nil || !result.Complete {
+		return
+	}
+
+	err := h.distances.Save(&distcache.Distance{
+		FromPage:         task.From,
+		ToPage:           task.To,
+		ComputedAt:       time.Now(),
+		Distance:         result.Distance,
+		SearchedDistance: int(result.SearchedDistance),
+	})
+	if err != nil {
+		zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to cache distance")
+	}
+}
+
+// newAlgorithm creates an algorithm reporting progress of the task.
+func (h *Handler) newAlgorithm(source GraphSource, task *pathtask.Task) *algorithm {
+	algo := newAlgorithm(source, h.bfsConfig, h.checkpoints)
+	algo.fetchFlow = task.FetchFlow()
+	algo.fetchWeight = task.Priority.FetchWeight()
+	algo.onProgress = func(progress pathtask.Progress) {
+		err := h.repository.SetProgress(task.ID, &progress)
+		if err != nil {
+			zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to update progress")
+		}
+	}
+	if h.coordinator != nil {
+		algo.expander = h.coordinator.expanderFor(task.ID, task.Options.AsOf, algo.fetchFlow, algo.fetchWeight)
+	}
+
+	return algo
+}
+
+// fail saves the reason of the failure with the result and marks the task as failed.
+func (h *Handler) fail(task *pathtask.Task, result *pathtask.Result, reason error) error {
+	zlog.Error().Err(reason).Str("id", task.ID.String()).Msg("task failed")
+
+	result.Error = reason.Error()
+
+	err := h.repository.SetResult(task.ID, result)
+	if err != nil {
+		zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to set result")
+		return errors.Wrap(err, "setting result failed")
+	}
+
+	err = h.repository.UpdateStatus(task.ID, pathtask.StatusProcessing, pathtask.StatusFailed)
+	if err != nil {
+		zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to update task status to FAILED")
+		return errors.Wrap(err, "failed to update status")
+	}
+
+	if h.checkpoints != nil {
+		err = h.checkpoints.Delete(task.ID)
+		if err != nil {
+			zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to delete checkpoint")
+		}
+	}
+
 	return nil
 }
+
+// findGoodPath runs the best-first search. Its result is final unless the optimality has to be proven.
+// In that case, the found path is saved as a preliminary result and limits the breadth-first search,
+// and the preliminary result is returned to be used if the breadth-first search fails to find a path.
+func (h *Handler) findGoodPath(algo *algorithm, task *pathtask.Task) (result *pathtask.Result, final bool, err error) {
+	var path []string
+	if task.Result != nil && task.Result.Heuristic {
+		// The task is redelivered after the preliminary result has been saved.
+		path = task.Result.ShortestPath
+		if task.Result.Stats != nil {
+			algo.stats = *task.Result.Stats
+		}
+	} else {
+		path, err = algo.findGoodPath(task.ID, task.From, task.To)
+		if errors.Is(err, ErrMemoryBudgetExceeded) {
+			return &pathtask.Result{Stats: &algo.stats}, true, err
+		}
+		if err != nil {
+			return nil, true, err
+		}
+	}
+
+	stats := algo.stats
+	result = &pathtask.Result{
+		ShortestPath: path,
+		Complete:     path != nil,
+		Heuristic:    len(path) > 1,
+		Stats:        &stats,
+	}
+
+	if !task.Options.ProveOptimal || len(path) == 1 {
+		return result, true, nil
+	}
+
+	if path == nil {
+		return nil, false, nil
+	}
+
+	err = h.repository.SetResult(task.ID, result)
+	if err != nil {
+		zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to set preliminary result")
+	}
+
+	if distance := uint(len(path) - 1); distance < algo.cfg.DistanceThreshold {
+		algo.cfg.DistanceThreshold = distance
+	}
+
+	return result, false, nil
+}
+
+// findShortestPath returns the statistics of the search along with ErrMemoryBudgetExceeded.
+func (h *Handler) findShortestPath(source GraphSource, task *pathtask.Task) (*pathtask.Result, error) {
+	hubs, err := h.loadHubs(task)
+	if err != nil {
+		return nil, err
+	}
+
+	// Path finders run a plain BFS for a single path: they know nothing about hubs, search modes and alternative paths.
+	usesFinder := hubs == nil && task.Options.Mode != pathtask.ModeBestFirst && !task.Options.ProveOptimal &&
+		task.Options.NumPaths <= 1
+	if finder, ok := source.(PathFinder); ok && usesFinder {
+		startedAt := time.Now()
+
+		path, err := finder.FindShortestPath(context.Background(), task.From, task.To)
+		if err == nil {
+			return &pathtask.Result{
+				ShortestPath: path,
+				Complete:     true,
+				Stats:        &pathtask.Stats{ElapsedMs: time.Since(startedAt).Milliseconds()},
+			}, nil
+		}
+		if !errors.Is(err, ErrNotSupported) {
+			return nil, err
+		}
+	}
+
+	algo := h.newAlgorithm(source, task)
+	algo.numPaths = task.Options.NumPaths
+	algo.hubs = hubs
+
+	var preliminary *pathtask.Result
+	if task.Options.Mode == pathtask.ModeBestFirst {
+		result, final, err := h.findGoodPath(algo, task)
+		if err != nil || final {
+			return result, err
+		}
+
+		preliminary = result
+	}
+
+	path, err := algo.findShortestPath(task.ID, task.From, task.To)
+	if errors.Is(err, ErrMemoryBudgetExceeded) {
+		return &pathtask.Result{FailedPages: algo.failedPages, Stats: &algo.stats}, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The shorter path could not be found only if some pages failed.
+	if path == nil && preliminary != nil {
+		preliminary.FailedPages = algo.failedPages
+		preliminary.Stats = &algo.stats
+
+		return preliminary, nil
+	}
+
+	return &pathtask.Result{
+		ShortestPath: path,
+		Paths:        algo.paths,
+		Complete:     algo.complete,
+		FailedPages:  algo.failedPages,
+		Stats:        &algo.stats,
+	}, nil
+}
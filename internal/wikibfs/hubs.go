@@ -0,0 +1,156 @@
+package wikibfs
+
+import (
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/pkg/errors"
+)
+
+// hubRanking is the part of the hub rankings a task depends on.
+type hubRanking struct {
+	// Normalized titles of the hubs the path must not go through.
+	avoided map[string]struct{}
+
+	// Hub scores by normalized titles, unranked pages score 0. Loaded only for tie-breaking.
+	scores   map[string]float64
+	tieBreak pathtask.TieBreak
+}
+
+// loadHubs loads the rankings the options of the task refer to. Nil is returned if the task doesn't use them.
+func (h *Handler) loadHubs(task *pathtask.Task) (*hubRanking, error) {
+	if !task.Options.UsesHubs() || h.hubs == nil {
+		return nil, nil
+	}
+
+	limit := int(task.Options.AvoidHubs)
+	if task.Options.TieBreak != pathtask.TieBreakNone {
+		limit = 0
+	}
+
+	rankings, err := h.hubs.Top(limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load hub rankings")
+	}
+
+	hubs := &hubRanking{
+		avoided:  make(map[string]struct{}),
+		tieBreak: task.Options.TieBreak,
+	}
+	if hubs.tieBreak != pathtask.TieBreakNone {
+		hubs.scores = make(map[string]float64, len(rankings))
+	}
+
+	for _, ranking := range rankings {
+		if ranking.Rank <= int(task.Options.AvoidHubs) {
+			hubs.avoided[ranking.PageKey] = struct{}{}
+		}
+
+		if hubs.scores != nil {
+			hubs.scores[ranking.PageKey] = ranking.Score
+		}
+	}
+
+	return hubs, nil
+}
+
+// avoids reports whether the page must not be visited.
+func (a *algorithm) avoids(key string) bool {
+	if a.hubs == nil {
+		return false
+	}
+
+	_, avoided := a.hubs.avoided[key]
+
+	return avoided
+}
+
+// recordsLinks reports whether links of the expanded pages are recorded and the layer of the target
+// is expanded completely, so all shortest paths are known.
+func (a *algorithm) recordsLinks() bool {
+	return a.numPaths > 1 || (a.hubs != nil && a.hubs.tieBreak != pathtask.TieBreakNone)
+}
+
+// tieBrokenPath returns the shortest path with the best total hub score in the explored subgraph.
+func (a *algorithm) tieBrokenPath(targetID uint32, to string) ([]string, error) {
+	var scoreErr error
+	score := func(id uint32) float64 {
+		title, err := a.state.title(id)
+		if err != nil {
+			scoreErr = err
+			return 0
+		}
+
+		return a.hubs.scores[a.normalize(title)]
+	}
+
+	// The start page always gets the first ID.
+	ids := bestShortestPath(a.state.links, 0, targetID, score, a.hubs.tieBreak == pathtask.TieBreakPreferHubs)
+	if scoreErr != nil {
+		return nil, errors.Wrap(scoreErr, "failed to load the explored pages")
+	}
+
+	// The explored subgraph always contains the path the target was discovered by.
+	if ids == nil {
+		return a.state.path(targetID, to)
+	}
+
+	titles, err := a.state.titlesOf(ids[:len(ids)-1])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to restore the path")
+	}
+
+	return append(titles, to), nil
+}
+
+// bestShortestPath returns the shortest path from src to dst with the highest total score of its pages if prefer
+// is set, or with the lowest one otherwise. Of equally scored paths, the first one found is returned.
+// Nil is returned if dst is not reachable.
+func bestShortestPath(links map[uint32][]uint32, src, dst uint32, score func(id uint32) float64, prefer bool) []uint32 {
+	distances := map[uint32]int{src: 0}
+	totals := map[uint32]float64{src: 0}
+	scores := make(map[uint32]float64)
+	parents := make(map[uint32]uint32)
+
+	// Pages are processed in the BFS order, so all predecessors of a page on the shortest paths
+	// are processed before it, and its total is final by then.
+	order := []uint32{src}
+	for head := 0; head < len(order) && order[head] != dst; head++ {
+		page := order[head]
+
+		for _, next := range links[page] {
+			distance, seen := distances[next]
+			if !seen {
+				distance = distances[page] + 1
+				distances[next] = distance
+				scores[next] = score(next)
+				order = append(order, next)
+			}
+
+			if distance != distances[page]+1 {
+				continue
+			}
+
+			total := totals[page] + scores[next]
+			_, hasParent := parents[next]
+			if !hasParent || (prefer && total > totals[next]) || (!prefer && total < totals[next]) {
+				totals[next] = total
+				parents[next] = page
+			}
+		}
+	}
+
+	if _, reached := parents[dst]; !reached {
+		return nil
+	}
+
+	path := []uint32{dst}
+	for page := dst; page != src; {
+		page = parents[page]
+		path = append(path, page)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
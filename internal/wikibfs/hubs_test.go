@@ -0,0 +1,34 @@
+package wikibfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBestShortestPath(t *testing.T) {
+	scores := map[uint32]float64{1: 5, 2: 1, 3: 2, 4: 3}
+	score := func(id uint32) float64 {
+		return scores[id]
+	}
+
+	tests := []struct {
+		name     string
+		src, dst uint32
+		prefer   bool
+		want     []uint32
+	}{
+		{name: "avoid high scores", src: 0, dst: 5, prefer: false, want: []uint32{0, 2, 3, 5}},
+		{name: "prefer high scores", src: 0, dst: 5, prefer: true, want: []uint32{0, 1, 3, 5}},
+		{name: "same page", src: 3, dst: 3, prefer: true, want: nil},
+		{name: "unreachable", src: 5, dst: 0, prefer: true, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bestShortestPath(testIDLinks, tt.src, tt.dst, score, tt.prefer)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bestShortestPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
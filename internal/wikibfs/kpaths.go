@@ -0,0 +1,140 @@
+package wikibfs
+
+import "sort"
+
+// kShortestPaths returns up to k loopless paths from src to dst ordered by length (Yen's algorithm).
+// Paths of the same length are ordered by discovery.
+func kShortestPaths(links map[uint32][]uint32, src, dst uint32, k int) [][]uint32 {
+	shortest := shortestPath(links, src, dst, nil, nil)
+	if shortest == nil {
+		return nil
+	}
+
+	found := [][]uint32{shortest}
+	var candidates [][]uint32
+	known := map[string]struct{}{pathKey(shortest): {}}
+
+	for len(found) < k {
+		last := found[len(found)-1]
+
+		for i := 0; i < len(last)-1; i++ {
+			spur := last[i]
+			root := last[:i+1]
+
+			// Links used by the found paths with the same root are removed, so the spur path deviates from them.
+			removedLinks := make(map[[2]uint32]struct{})
+			for _, path := range found {
+				if len(path) > i+1 && equalPaths(path[:i+1], root) {
+					removedLinks[[2]uint32{path[i], path[i+1]}] = struct{}{}
+				}
+			}
+
+			// Pages of the root except the spur one are removed, so paths stay loopless.
+			removedPages := make(map[uint32]struct{}, i)
+			for _, page := range root[:i] {
+				removedPages[page] = struct{}{}
+			}
+
+			spurPath := shortestPath(links, spur, dst, removedPages, removedLinks)
+			if spurPath == nil {
+				continue
+			}
+
+			candidate := make([]uint32, 0, i+len(spurPath))
+			candidate = append(candidate, root[:i]...)
+			candidate = append(candidate, spurPath...)
+
+			key := pathKey(candidate)
+			if _, ok := known[key]; ok {
+				continue
+			}
+
+			known[key] = struct{}{}
+			candidates = append(candidates, candidate)
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return len(candidates[i]) < len(candidates[j])
+		})
+
+		found = append(found, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	return found
+}
+
+// shortestPath runs BFS over the links skipping the removed pages and links. Nil is returned if dst is unreachable.
+func shortestPath(links map[uint32][]uint32, src, dst uint32, removedPages map[uint32]struct{}, removedLinks map[[2]uint32]struct{}) []uint32 {
+	prev := map[uint32]uint32{src: src}
+	queue := []uint32{src}
+
+	for len(queue) != 0 && !contains(prev, dst) {
+		page := queue[0]
+		queue = queue[1:]
+
+		for _, next := range links[page] {
+			if contains(prev, next) {
+				continue
+			}
+			if _, removed := removedPages[next]; removed {
+				continue
+			}
+			if _, removed := removedLinks[[2]uint32{page, next}]; removed {
+				continue
+			}
+
+			prev[next] = page
+			queue = append(queue, next)
+		}
+	}
+
+	if !contains(prev, dst) {
+		return nil
+	}
+
+	path := []uint32{dst}
+	for page := dst; page != src; {
+		page = prev[page]
+		path = append(path, page)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+func contains(set map[uint32]uint32, key uint32) bool {
+	_, ok := set[key]
+
+	return ok
+}
+
+func equalPaths(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func pathKey(path []uint32) string {
+	key := make([]byte, 0, len(path)*4)
+	for _, page := range path {
+		key = append(key, byte(page), byte(page>>8), byte(page>>16), byte(page>>24))
+	}
+
+	return string(key)
+}
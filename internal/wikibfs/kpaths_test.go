@@ -0,0 +1,62 @@
+package wikibfs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFindShortestPath_Alternatives(t *testing.T) {
+	algo := newTestAlgorithm()
+	algo.numPaths = 5
+
+	path, err := algo.findShortestPath(uuid.New(), "A", "F")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checkPath(t, path, "A", "F")
+
+	if len(algo.paths) != 3 {
+		t.Fatalf("got paths %v, want 3 of them", algo.paths)
+	}
+	for _, p := range algo.paths {
+		checkPath(t, p, "A", "F")
+		if len(p) != 4 {
+			t.Errorf("path %v is not the shortest", p)
+		}
+	}
+}
+
+// 0 -> {1, 2}, 1 -> 3, 2 -> {3, 4}, 3 -> 5, 4 -> 5.
+var testIDLinks = map[uint32][]uint32{
+	0: {1, 2},
+	1: {3},
+	2: {3, 4},
+	3: {5},
+	4: {5},
+}
+
+func TestKShortestPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		src, dst uint32
+		k        int
+		want     [][]uint32
+	}{
+		{name: "single path", src: 0, dst: 5, k: 1, want: [][]uint32{{0, 1, 3, 5}}},
+		{name: "all paths", src: 0, dst: 5, k: 10, want: [][]uint32{{0, 1, 3, 5}, {0, 2, 3, 5}, {0, 2, 4, 5}}},
+		{name: "direct link", src: 2, dst: 4, k: 3, want: [][]uint32{{2, 4}}},
+		{name: "unreachable", src: 5, dst: 0, k: 3, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := kShortestPaths(testIDLinks, tt.src, tt.dst, tt.k)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("kShortestPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package wikibfs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// findNeighborhood returns the pages within hops clicks from the root ordered by distance, the root included.
+// The expansion stops after the layer that brings the number of pages to NeighborhoodMaxPages,
+// and only the closest NeighborhoodMaxPages pages are returned in this case.
+// Nil is returned if the root doesn't exist.
+func (a *algorithm) findNeighborhood(taskID uuid.UUID, root string, hops uint) (neighbors []pathtask.Neighbor, truncated bool, err error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx = a.withFetchFlow(ctx, taskID)
+
+	startedAt := time.Now()
+	requests := new(wikiclient.RequestCounter)
+	ctx = wikiclient.WithRequestCounter(ctx, requests)
+
+	defer func() {
+		a.stats.ElapsedMs = time.Since(startedAt).Milliseconds()
+		a.stats.APIRequests = requests.Count()
+	}()
+
+	defer a.state.close()
+
+	canonical, err := a.canonicalize(ctx, root)
+	if errors.Is(err, ErrPageNotFound) {
+		zlog.Info().Str("root", root).Msg("root page does not exist")
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to canonicalize the root")
+	}
+	root = canonical
+
+	id, _, err := a.state.add(a.normalize(root), root, noParent)
+	if err != nil {
+		return nil, false, err
+	}
+
+	layers := [][]uint32{{id}}
+
+	var distance uint
+	for distance < hops {
+		if a.cfg.NeighborhoodMaxPages > 0 && a.state.len() >= a.cfg.NeighborhoodMaxPages {
+			truncated = true
+			break
+		}
+
+		queue := layers[len(layers)-1]
+		if len(queue) == 0 {
+			break
+		}
+
+		distance++
+
+		layerStartedAt := time.Now()
+		layer := pathtask.LayerStats{
+			Distance:     distance,
+			FrontierSize: len(queue),
+		}
+
+		next := make([]uint32, 0, len(queue))
+
+		// No title is empty, so the expansion never stops early.
+		_, err = a.expandLayer(ctx, taskID, queue, "", &next, &layer)
+
+		layer.Discovered = len(next)
+		layer.ElapsedMs = time.Since(layerStartedAt).Milliseconds()
+		a.addLayerStats(layer)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		layers = append(layers, next)
+	}
+
+	a.complete = a.isComplete(distance, false)
+
+	for d, layer := range layers {
+		if a.cfg.NeighborhoodMaxPages > 0 && len(neighbors)+len(layer) > a.cfg.NeighborhoodMaxPages {
+			layer = layer[:a.cfg.NeighborhoodMaxPages-len(neighbors)]
+			truncated = true
+		}
+
+		titles, err := a.state.titlesOf(layer)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to load the neighborhood")
+		}
+
+		for _, title := range titles {
+			neighbors = append(neighbors, pathtask.Neighbor{Title: title, Distance: uint(d)})
+		}
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"task_id":   taskID.String(),
+		"root":      root,
+		"hops":      distance,
+		"pages":     len(neighbors),
+		"truncated": truncated,
+	}).Msg("neighborhood explored")
+
+	return neighbors, truncated, nil
+}
@@ -0,0 +1,121 @@
+package wikibfs
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// Weights of the similarity components. Shared links and categories describe the expanded page,
+// so they are inherited by all pages it links to, while title tokens tell its links apart.
+const (
+	titleWeight      = 1.0
+	linksWeight      = 2.0
+	categoriesWeight = 1.0
+)
+
+// Words that don't make titles similar.
+var stopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "at": {}, "by": {}, "for": {}, "from": {},
+	"in": {}, "of": {}, "on": {}, "or": {}, "the": {}, "to": {}, "with": {},
+}
+
+// similarity scores pages by how close they look to the target:
+// title token overlap, shared outgoing links and shared categories.
+type similarity struct {
+	source GraphSource
+
+	targetTokens     map[string]struct{}
+	targetLinks      map[string]struct{}
+	targetCategories map[string]struct{}
+}
+
+// newSimilarity fetches links and, if useCategories is set, categories of the target.
+// Components that cannot be fetched are ignored.
+func newSimilarity(ctx context.Context, source GraphSource, target string, useCategories bool) *similarity {
+	s := &similarity{
+		source:       source,
+		targetTokens: titleTokens(target),
+	}
+
+	links, err := source.OutgoingLinks(ctx, target)
+	if err != nil && !errors.Is(err, ErrUnknownPage) {
+		zlog.Warn().Err(err).Str("target", target).Msg("failed to fetch links of the target, they are not used for scoring")
+	}
+	s.targetLinks = normalizedSet(links)
+
+	if useCategories {
+		categories, err := Categories(ctx, source, target)
+		if err != nil && !errors.Is(err, ErrNotSupported) {
+			zlog.Warn().Err(err).Str("target", target).Msg("failed to fetch categories of the target, they are not used for scoring")
+		}
+		s.targetCategories = normalizedSet(categories)
+	}
+
+	return s
+}
+
+// pageScore scores the expanded page by its links and categories.
+func (s *similarity) pageScore(ctx context.Context, title string, links []string) float64 {
+	score := linksWeight * jaccard(normalizedSet(links), s.targetLinks)
+
+	if len(s.targetCategories) != 0 {
+		categories, err := Categories(ctx, s.source, title)
+		if err == nil {
+			score += categoriesWeight * jaccard(normalizedSet(categories), s.targetCategories)
+		}
+	}
+
+	return score
+}
+
+// score scores a page discovered on the expanded page with the given score.
+func (s *similarity) score(title string, parentScore float64) float64 {
+	return parentScore + titleWeight*jaccard(titleTokens(title), s.targetTokens)
+}
+
+func titleTokens(title string) map[string]struct{} {
+	tokens := make(map[string]struct{})
+
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if _, stop := stopWords[word]; !stop {
+			tokens[word] = struct{}{}
+		}
+	}
+
+	return tokens
+}
+
+func normalizedSet(titles []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(titles))
+	for _, title := range titles {
+		set[strings.ToLower(title)] = struct{}{}
+	}
+
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	var shared int
+	for key := range a {
+		if _, ok := b[key]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,221 @@
+package wikibfs
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrPageNotFound is returned when the page definitely doesn't exist.
+	ErrPageNotFound = errors.New("page not found")
+
+	// ErrUnknownPage is returned when the source has no information about the page,
+	// e.g. the page is missing in a cache. LayeredSource asks the next layer in this case.
+	ErrUnknownPage = errors.New("unknown page")
+
+	// ErrNotSupported is returned when the source cannot provide the requested information.
+	ErrNotSupported = errors.New("not supported")
+)
+
+// GraphSource provides the link graph of Wikipedia pages.
+type GraphSource interface {
+	// OutgoingLinks returns titles of the pages the given page links to.
+	OutgoingLinks(ctx context.Context, title string) ([]string, error)
+
+	// Canonicalize returns the title of the page as it's known to the source,
+	// e.g. with fixed capitalization and followed redirects.
+	Canonicalize(ctx context.Context, title string) (string, error)
+}
+
+// IncomingLinksSource is implemented by sources that can also list pages linking to the given one.
+type IncomingLinksSource interface {
+	IncomingLinks(ctx context.Context, title string) ([]string, error)
+}
+
+// IncomingLinks returns titles of the pages that link to the given one,
+// or ErrNotSupported if the source cannot provide them.
+func IncomingLinks(ctx context.Context, source GraphSource, title string) ([]string, error) {
+	incoming, ok := source.(IncomingLinksSource)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return incoming.IncomingLinks(ctx, title)
+}
+
+// CategoriesSource is implemented by sources that know categories of the pages.
+type CategoriesSource interface {
+	Categories(ctx context.Context, title string) ([]string, error)
+}
+
+// Categories returns titles of the categories the page belongs to,
+// or ErrNotSupported if the source cannot provide them.
+func Categories(ctx context.Context, source GraphSource, title string) ([]string, error) {
+	categories, ok := source.(CategoriesSource)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return categories.Categories(ctx, title)
+}
+
+// ContentSource is implemented by sources that can provide the content of the pages.
+type ContentSource interface {
+	Wikitext(ctx context.Context, title string) (string, error)
+
+	// Redirects returns titles of the pages redirecting to the given one.
+	Redirects(ctx context.Context, title string) ([]string, error)
+}
+
+// Wikitext returns the wikitext of the page, or ErrNotSupported if the source cannot provide it.
+func Wikitext(ctx context.Context, source GraphSource, title string) (string, error) {
+	content, ok := source.(ContentSource)
+	if !ok {
+		return "", ErrNotSupported
+	}
+
+	return content.Wikitext(ctx, title)
+}
+
+// Redirects returns titles of the pages redirecting to the given one,
+// or ErrNotSupported if the source cannot provide them.
+func Redirects(ctx context.Context, source GraphSource, title string) ([]string, error) {
+	content, ok := source.(ContentSource)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return content.Redirects(ctx, title)
+}
+
+// PathFinder is implemented by sources that can run the whole search themselves,
+// e.g. in-memory indexes that don't need to fetch pages one by one.
+// ErrNotSupported means the page-by-page search should be used instead.
+type PathFinder interface {
+	FindShortestPath(ctx context.Context, from, to string) ([]string, error)
+}
+
+// writableSource is implemented by caching sources that accept links fetched by other layers.
+type writableSource interface {
+	SaveOutgoingLinks(ctx context.Context, title string, links []string) error
+}
+
+// LayeredSource asks its layers in order until one of them knows the page.
+// Links found in a deeper layer are saved to the writable layers above it.
+type LayeredSource struct {
+	layers []GraphSource
+}
+
+func NewLayeredSource(layers ...GraphSource) *LayeredSource {
+	return &LayeredSource{layers: layers}
+}
+
+func (s *LayeredSource) OutgoingLinks(ctx context.Context, title string) ([]string, error) {
+	for i, layer := range s.layers {
+		links, err := layer.OutgoingLinks(ctx, title)
+		if errors.Is(err, ErrUnknownPage) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, upper := range s.layers[:i] {
+			writable, ok := upper.(writableSource)
+			if !ok {
+				continue
+			}
+
+			err = writable.SaveOutgoingLinks(ctx, title, links)
+			if err != nil {
+				zlog.Error().Err(err).Str("title", title).Msg("failed to save links to the upper layer")
+			}
+		}
+
+		return links, nil
+	}
+
+	return nil, ErrUnknownPage
+}
+
+func (s *LayeredSource) Canonicalize(ctx context.Context, title string) (string, error) {
+	for _, layer := range s.layers {
+		canonical, err := layer.Canonicalize(ctx, title)
+		if errors.Is(err, ErrUnknownPage) {
+			continue
+		}
+
+		return canonical, err
+	}
+
+	return "", ErrUnknownPage
+}
+
+// FindShortestPath runs the search in the first layer if it's a PathFinder.
+// Otherwise, ErrNotSupported is returned.
+func (s *LayeredSource) FindShortestPath(ctx context.Context, from, to string) ([]string, error) {
+	if len(s.layers) == 0 {
+		return nil, ErrNotSupported
+	}
+
+	finder, ok := s.layers[0].(PathFinder)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return finder.FindShortestPath(ctx, from, to)
+}
+
+func (s *LayeredSource) IncomingLinks(ctx context.Context, title string) ([]string, error) {
+	for _, layer := range s.layers {
+		links, err := IncomingLinks(ctx, layer, title)
+		if errors.Is(err, ErrUnknownPage) || errors.Is(err, ErrNotSupported) {
+			continue
+		}
+
+		return links, err
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s *LayeredSource) Categories(ctx context.Context, title string) ([]string, error) {
+	for _, layer := range s.layers {
+		categories, err := Categories(ctx, layer, title)
+		if errors.Is(err, ErrUnknownPage) || errors.Is(err, ErrNotSupported) {
+			continue
+		}
+
+		return categories, err
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s *LayeredSource) Wikitext(ctx context.Context, title string) (string, error) {
+	for _, layer := range s.layers {
+		text, err := Wikitext(ctx, layer, title)
+		if errors.Is(err, ErrUnknownPage) || errors.Is(err, ErrNotSupported) {
+			continue
+		}
+
+		return text, err
+	}
+
+	return "", ErrNotSupported
+}
+
+func (s *LayeredSource) Redirects(ctx context.Context, title string) ([]string, error) {
+	for _, layer := range s.layers {
+		redirects, err := Redirects(ctx, layer, title)
+		if errors.Is(err, ErrUnknownPage) || errors.Is(err, ErrNotSupported) {
+			continue
+		}
+
+		return redirects, err
+	}
+
+	return nil, ErrNotSupported
+}
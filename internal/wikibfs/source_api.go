@@ -0,0 +1,87 @@
+package wikibfs
+
+import (
+	"context"
+
+	"github.com/lodthe/wiki-graph/internal/fairqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+)
+
+// APISource fetches the latest links from the Wikipedia API.
+// Page fetches of concurrent tasks are admitted by the scheduler, if any.
+type APISource struct {
+	wikiClient *wikiclient.Client
+	scheduler  *fairqueue.Scheduler
+}
+
+func NewAPISource(wikiClient *wikiclient.Client, scheduler *fairqueue.Scheduler) *APISource {
+	return &APISource{
+		wikiClient: wikiClient,
+		scheduler:  scheduler,
+	}
+}
+
+func (s *APISource) OutgoingLinks(ctx context.Context, title string) ([]string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return s.wikiClient.GetMentionedPages(ctx, title)
+}
+
+func (s *APISource) IncomingLinks(ctx context.Context, title string) ([]string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return s.wikiClient.GetLinkingPages(ctx, title)
+}
+
+func (s *APISource) Categories(ctx context.Context, title string) ([]string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return s.wikiClient.GetCategories(ctx, title)
+}
+
+func (s *APISource) Wikitext(ctx context.Context, title string) (string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	text, err := s.wikiClient.GetWikitext(ctx, title)
+	if errors.Is(err, wikiclient.ErrPageNotFound) {
+		return "", ErrPageNotFound
+	}
+
+	return text, err
+}
+
+func (s *APISource) Redirects(ctx context.Context, title string) ([]string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return s.wikiClient.GetRedirects(ctx, title)
+}
+
+func (s *APISource) Canonicalize(ctx context.Context, title string) (string, error) {
+	canonical, err := s.wikiClient.Canonicalize(ctx, title)
+	if errors.Is(err, wikiclient.ErrPageNotFound) {
+		return "", ErrPageNotFound
+	}
+
+	return canonical, err
+}
@@ -0,0 +1,57 @@
+package wikibfs
+
+import (
+	"context"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/linkcache"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// CacheSource returns links stored in the cache shared by all workers.
+// Pages that are missing or were fetched more than ttl ago are reported as unknown,
+// so it's supposed to be a layer above a source that actually fetches links.
+// Cache failures are logged and reported the same way.
+type CacheSource struct {
+	cache linkcache.Repository
+	ttl   time.Duration
+}
+
+func NewCacheSource(cache linkcache.Repository, ttl time.Duration) *CacheSource {
+	return &CacheSource{
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func (s *CacheSource) OutgoingLinks(_ context.Context, title string) ([]string, error) {
+	cached, err := s.cache.GetPageLinks(title)
+	if errors.Is(err, linkcache.ErrNotFound) {
+		return nil, ErrUnknownPage
+	}
+	if err != nil {
+		// The cache is only an optimization, the next layer can still fetch the links.
+		zlog.Error().Err(err).Str("title", title).Msg("cache lookup failed")
+		return nil, ErrUnknownPage
+	}
+
+	if time.Since(cached.FetchedAt) > s.ttl {
+		return nil, ErrUnknownPage
+	}
+
+	return cached.Links, nil
+}
+
+// Canonicalize always reports pages as unknown, because the cache doesn't know about redirects.
+func (s *CacheSource) Canonicalize(_ context.Context, _ string) (string, error) {
+	return "", ErrUnknownPage
+}
+
+func (s *CacheSource) SaveOutgoingLinks(_ context.Context, title string, links []string) error {
+	return s.cache.SavePageLinks(&linkcache.PageLinks{
+		PageTitle: title,
+		FetchedAt: time.Now(),
+		Links:     links,
+	})
+}
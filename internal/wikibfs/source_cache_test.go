@@ -0,0 +1,35 @@
+package wikibfs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/lodthe/wiki-graph/internal/linkcache"
+	"github.com/pkg/errors"
+)
+
+type failingCache struct {
+	linkcache.Repository
+}
+
+func (failingCache) GetPageLinks(string) (*linkcache.PageLinks, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingCache) SavePageLinks(*linkcache.PageLinks) error {
+	return errors.New("connection refused")
+}
+
+func TestCacheSource_FallsThroughOnErrors(t *testing.T) {
+	source := NewLayeredSource(NewCacheSource(failingCache{}, 0), NewStaticGraph(testLinks))
+
+	links, err := source.OutgoingLinks(context.Background(), "A")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(links, testLinks["A"]) {
+		t.Errorf("links = %v, want %v", links, testLinks["A"])
+	}
+}
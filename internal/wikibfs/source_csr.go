@@ -0,0 +1,84 @@
+package wikibfs
+
+import (
+	"context"
+
+	"github.com/lodthe/wiki-graph/internal/csrgraph"
+)
+
+// CSRSource serves links from the in-memory CSR index.
+// It can run the whole search itself if links of every page are known, so the page-by-page BFS
+// is not used with it. Otherwise, pages without known links are left to the next layers.
+type CSRSource struct {
+	graph *csrgraph.Graph
+
+	// Whether links of every page are known, so the graph can be searched on its own.
+	complete bool
+
+	// Maximum allowed distance for FindShortestPath, 0 means unlimited.
+	maxDistance uint
+}
+
+func NewCSRSource(graph *csrgraph.Graph, maxDistance uint) *CSRSource {
+	return &CSRSource{
+		graph:       graph,
+		complete:    graph.HasAllLinks(),
+		maxDistance: maxDistance,
+	}
+}
+
+func (s *CSRSource) OutgoingLinks(_ context.Context, title string) ([]string, error) {
+	id, ok := s.graph.Lookup(title)
+	if !ok || !s.graph.HasLinks(id) {
+		return nil, ErrUnknownPage
+	}
+
+	return s.titles(s.graph.Neighbors(id)), nil
+}
+
+func (s *CSRSource) Canonicalize(_ context.Context, title string) (string, error) {
+	id, ok := s.graph.Lookup(title)
+	if !ok {
+		return "", ErrUnknownPage
+	}
+
+	return s.graph.Title(id), nil
+}
+
+// FindShortestPath returns ErrNotSupported if links of some pages are unknown,
+// because the search in the graph would treat them as dead ends.
+func (s *CSRSource) FindShortestPath(ctx context.Context, from, to string) ([]string, error) {
+	if !s.complete {
+		return nil, ErrNotSupported
+	}
+
+	fromID, ok := s.graph.Lookup(from)
+	if !ok {
+		return nil, nil
+	}
+
+	toID, ok := s.graph.Lookup(to)
+	if !ok {
+		return nil, nil
+	}
+
+	path, err := s.graph.ShortestPath(ctx, fromID, toID, s.maxDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.titles(path), nil
+}
+
+func (s *CSRSource) titles(ids []uint32) []string {
+	if ids == nil {
+		return nil
+	}
+
+	titles := make([]string, 0, len(ids))
+	for _, id := range ids {
+		titles = append(titles, s.graph.Title(id))
+	}
+
+	return titles
+}
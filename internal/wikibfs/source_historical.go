@@ -0,0 +1,114 @@
+package wikibfs
+
+import (
+	"context"
+	"time"
+
+	"github.com/lodthe/wiki-graph/internal/fairqueue"
+	"github.com/lodthe/wiki-graph/internal/linkcache"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// HistoricalSource returns links of the page revisions that were current at the given moment.
+// Links are cached by revision ID, so repeated historical queries give the same results.
+type HistoricalSource struct {
+	wikiClient *wikiclient.Client
+	scheduler  *fairqueue.Scheduler
+	cache      linkcache.Repository
+	asOf       time.Time
+}
+
+func NewHistoricalSource(wikiClient *wikiclient.Client, scheduler *fairqueue.Scheduler, cache linkcache.Repository, asOf time.Time) *HistoricalSource {
+	return &HistoricalSource{
+		wikiClient: wikiClient,
+		scheduler:  scheduler,
+		cache:      cache,
+		asOf:       asOf,
+	}
+}
+
+func (s *HistoricalSource) OutgoingLinks(ctx context.Context, title string) ([]string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	revision, err := s.wikiClient.GetRevisionAt(ctx, title, s.asOf)
+	if errors.Is(err, wikiclient.ErrNoRevision) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get revision")
+	}
+
+	cached, err := s.cache.GetRevisionLinks(revision.ID)
+	if err == nil {
+		return cached.Links, nil
+	}
+	if !errors.Is(err, linkcache.ErrNotFound) {
+		zlog.Error().Err(err).Int64("revision_id", revision.ID).Msg("failed to get cached revision links")
+	}
+
+	links, err := s.wikiClient.GetRevisionLinks(ctx, revision.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get revision links")
+	}
+
+	err = s.cache.SaveRevisionLinks(&linkcache.RevisionLinks{
+		RevisionID: revision.ID,
+		PageTitle:  revision.PageTitle,
+		Links:      links,
+	})
+	if err != nil {
+		zlog.Error().Err(err).Int64("revision_id", revision.ID).Msg("failed to cache revision links")
+	}
+
+	return links, nil
+}
+
+// Wikitext returns the wikitext of the revision that was current at the moment.
+func (s *HistoricalSource) Wikitext(ctx context.Context, title string) (string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	revision, err := s.wikiClient.GetRevisionAt(ctx, title, s.asOf)
+	if errors.Is(err, wikiclient.ErrNoRevision) {
+		return "", ErrPageNotFound
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get revision")
+	}
+
+	return s.wikiClient.GetRevisionWikitext(ctx, revision.ID)
+}
+
+// Redirects returns the current redirects: the history of redirects is not tracked.
+func (s *HistoricalSource) Redirects(ctx context.Context, title string) ([]string, error) {
+	release, err := s.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return s.wikiClient.GetRedirects(ctx, title)
+}
+
+// Canonicalize normalizes the title, but doesn't follow redirects:
+// the page might have been an article at that moment.
+func (s *HistoricalSource) Canonicalize(ctx context.Context, title string) (string, error) {
+	revision, err := s.wikiClient.GetRevisionAt(ctx, title, s.asOf)
+	if errors.Is(err, wikiclient.ErrNoRevision) {
+		return "", ErrPageNotFound
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get revision")
+	}
+
+	return revision.PageTitle, nil
+}
@@ -0,0 +1,86 @@
+package wikibfs
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// StaticGraph is an immutable in-memory graph. Titles are matched case-insensitively.
+type StaticGraph struct {
+	titles   map[string]string
+	outgoing map[string][]string
+	incoming map[string][]string
+}
+
+// NewStaticGraph builds a graph from the adjacency lists: page title -> titles of the linked pages.
+func NewStaticGraph(links map[string][]string) *StaticGraph {
+	g := &StaticGraph{
+		titles:   make(map[string]string),
+		outgoing: make(map[string][]string),
+		incoming: make(map[string][]string),
+	}
+
+	for from, targets := range links {
+		g.titles[g.key(from)] = from
+		g.outgoing[g.key(from)] = targets
+
+		for _, to := range targets {
+			if _, exists := g.titles[g.key(to)]; !exists {
+				g.titles[g.key(to)] = to
+			}
+
+			g.incoming[g.key(to)] = append(g.incoming[g.key(to)], from)
+		}
+	}
+
+	return g
+}
+
+// LoadStaticGraph reads a JSON object with adjacency lists, e.g. {"Apple": ["Fruit", "Tree"]}.
+func LoadStaticGraph(path string) (*StaticGraph, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	var links map[string][]string
+	err = json.Unmarshal(data, &links)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode adjacency lists")
+	}
+
+	return NewStaticGraph(links), nil
+}
+
+func (g *StaticGraph) OutgoingLinks(_ context.Context, title string) ([]string, error) {
+	if _, exists := g.titles[g.key(title)]; !exists {
+		return nil, ErrUnknownPage
+	}
+
+	return g.outgoing[g.key(title)], nil
+}
+
+func (g *StaticGraph) IncomingLinks(_ context.Context, title string) ([]string, error) {
+	if _, exists := g.titles[g.key(title)]; !exists {
+		return nil, ErrUnknownPage
+	}
+
+	return g.incoming[g.key(title)], nil
+}
+
+func (g *StaticGraph) Canonicalize(_ context.Context, title string) (string, error) {
+	canonical, exists := g.titles[g.key(title)]
+	if !exists {
+		return "", ErrUnknownPage
+	}
+
+	return canonical, nil
+}
+
+func (g *StaticGraph) key(title string) string {
+	return strings.ToLower(title)
+}
@@ -0,0 +1,36 @@
+package wikibfs
+
+import (
+	"context"
+
+	"github.com/lodthe/wiki-graph/internal/graphstore"
+)
+
+// StoreSource returns links from the graph imported from Wikipedia dumps.
+type StoreSource struct {
+	store *graphstore.Store
+}
+
+func NewStoreSource(store *graphstore.Store) *StoreSource {
+	return &StoreSource{store: store}
+}
+
+func (s *StoreSource) OutgoingLinks(_ context.Context, title string) ([]string, error) {
+	titles, ok := s.store.OutgoingTitles(title)
+	if !ok {
+		return nil, ErrUnknownPage
+	}
+
+	return titles, nil
+}
+
+func (s *StoreSource) Canonicalize(_ context.Context, title string) (string, error) {
+	id, ok := s.store.Lookup(title)
+	if !ok {
+		return "", ErrUnknownPage
+	}
+
+	canonical, _ := s.store.Title(id)
+
+	return canonical, nil
+}
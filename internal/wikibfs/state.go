@@ -0,0 +1,441 @@
+package wikibfs
+
+import (
+	"bufio"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// ErrMemoryBudgetExceeded is returned when the search state grows over BFSConfig.MemoryBudget.
+var ErrMemoryBudgetExceeded = errors.New("memory budget exceeded")
+
+// noParent is the parent of the start page.
+const noParent = ^uint32(0)
+
+// Rough per-page overhead of the map entry, string headers and the parent ID.
+// Spilled pages cost a map entry keyed by a hash and the offsets of the key and the title instead
+// of the string headers, the key and the title themselves.
+// Recorded links cost a map entry with a slice header per expanded page and an ID per link.
+const (
+	inMemoryPageOverhead = 56
+	spilledPageOverhead  = 48
+	linkListOverhead     = 48
+	linkSize             = 4
+)
+
+// searchState keeps the pages discovered by the search. Titles are interned:
+// every page gets a sequential ID, and parents and frontiers are stored as IDs.
+// When the estimated size exceeds the spill threshold, titles and the visited set are moved
+// to temporary files: the visited set is keyed by hashes of normalized titles, and a hash match
+// is confirmed by reading the normalized title from disk. Parents, frontiers and recorded links
+// stay in memory, they take a few bytes per page.
+type searchState struct {
+	// Keyed by normalized titles, nil once they are spilled.
+	ids map[string]uint32
+
+	// Titles as they were returned by the source, nil once they are spilled.
+	titles  []string
+	parents []uint32
+
+	// Spilled titles and normalized titles. Pages are keyed by hashes of normalized titles,
+	// and the pages whose hashes are taken by other pages are keyed by normalized titles in collisions.
+	spilled     *titleFile
+	spilledKeys *titleFile
+	hashes      map[uint64]uint32
+	collisions  map[string]uint32
+
+	// Links of the expanded pages, nil unless they are recorded.
+	links     map[uint32][]uint32
+	linkCount int64
+
+	keyBytes   int64
+	titleBytes int64
+
+	spillThreshold int64
+	budget         int64
+	spillDir       string
+}
+
+func newSearchState(cfg BFSConfig) *searchState {
+	return &searchState{
+		ids:            make(map[string]uint32),
+		spillThreshold: cfg.SpillThreshold,
+		budget:         cfg.MemoryBudget,
+		spillDir:       cfg.SpillDir,
+	}
+}
+
+// add registers a new page. If the page is already known, its ID is returned and added is false.
+func (s *searchState) add(key, title string, parent uint32) (id uint32, added bool, err error) {
+	id, ok, err := s.lookup(key)
+	if err != nil || ok {
+		return id, false, err
+	}
+
+	id = uint32(len(s.parents))
+	s.parents = append(s.parents, parent)
+
+	if s.spilled != nil {
+		err = s.spilled.append(title)
+		if err == nil {
+			err = s.spilledKeys.append(key)
+		}
+		if err != nil {
+			return 0, false, errors.Wrap(err, "failed to spill the page")
+		}
+
+		s.addHashed(key, id)
+	} else {
+		s.ids[key] = id
+		s.keyBytes += int64(len(key))
+		s.titles = append(s.titles, title)
+		s.titleBytes += int64(len(title))
+	}
+
+	if s.spilled == nil && s.spillThreshold > 0 && s.size() > s.spillThreshold {
+		err = s.spill()
+		if err != nil {
+			return 0, false, errors.Wrap(err, "failed to spill titles")
+		}
+	}
+
+	err = s.checkBudget()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+// addLink records a link between the pages.
+func (s *searchState) addLink(from, to uint32) error {
+	if s.links == nil {
+		s.links = make(map[uint32][]uint32)
+	}
+
+	s.links[from] = append(s.links[from], to)
+	s.linkCount++
+
+	return s.checkBudget()
+}
+
+func (s *searchState) checkBudget() error {
+	if s.budget > 0 && s.size() > s.budget {
+		return errors.Wrapf(ErrMemoryBudgetExceeded, "%d pages discovered, the search state takes about %d MB",
+			s.len(), s.size()>>20)
+	}
+
+	return nil
+}
+
+// lookup returns the ID of the page with the given normalized title.
+func (s *searchState) lookup(key string) (uint32, bool, error) {
+	if s.spilled == nil {
+		id, ok := s.ids[key]
+		return id, ok, nil
+	}
+
+	id, ok := s.hashes[hashKey(key)]
+	if !ok {
+		return 0, false, nil
+	}
+
+	spilledKey, err := s.spilledKeys.read(id)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to read the spilled page")
+	}
+	if spilledKey == key {
+		return id, true, nil
+	}
+
+	id, ok = s.collisions[key]
+
+	return id, ok, nil
+}
+
+// addHashed adds the spilled page to the visited set.
+func (s *searchState) addHashed(key string, id uint32) {
+	h := hashKey(key)
+	if _, taken := s.hashes[h]; taken {
+		s.collisions[key] = id
+		s.keyBytes += int64(len(key))
+		return
+	}
+
+	s.hashes[h] = id
+}
+
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	return h.Sum64()
+}
+
+func (s *searchState) parent(id uint32) uint32 {
+	return s.parents[id]
+}
+
+func (s *searchState) title(id uint32) (string, error) {
+	if s.spilled != nil {
+		return s.spilled.read(id)
+	}
+
+	return s.titles[id], nil
+}
+
+// path returns titles of the pages from the start page to the page with the given ID.
+// The last title is replaced with last, e.g. the canonical title of the target.
+func (s *searchState) path(id uint32, last string) ([]string, error) {
+	path := []string{last}
+	for id = s.parent(id); id != noParent; id = s.parent(id) {
+		title, err := s.title(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to restore the path")
+		}
+
+		path = append(path, title)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, nil
+}
+
+// titlesOf resolves the IDs to titles.
+func (s *searchState) titlesOf(ids []uint32) ([]string, error) {
+	titles := make([]string, 0, len(ids))
+	for _, id := range ids {
+		title, err := s.title(id)
+		if err != nil {
+			return nil, err
+		}
+
+		titles = append(titles, title)
+	}
+
+	return titles, nil
+}
+
+// forEachTitle calls fn with titles of all pages in the order of their IDs.
+// Spilled titles are streamed from the file.
+func (s *searchState) forEachTitle(fn func(title string) error) error {
+	if s.spilled != nil {
+		return s.spilled.forEach(fn)
+	}
+
+	for _, title := range s.titles {
+		err := fn(title)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *searchState) len() int {
+	return len(s.parents)
+}
+
+// size estimates the memory taken by the state.
+func (s *searchState) size() int64 {
+	pages := int64(s.len())
+	links := int64(len(s.links))*linkListOverhead + s.linkCount*linkSize
+	if s.spilled != nil {
+		return s.keyBytes + pages*spilledPageOverhead + links
+	}
+
+	return s.keyBytes + s.titleBytes + pages*inMemoryPageOverhead + links
+}
+
+// spill moves the titles and the visited set to temporary files.
+func (s *searchState) spill() error {
+	keys := make([]string, len(s.ids))
+	for key, id := range s.ids {
+		keys[id] = key
+	}
+
+	titles, err := writeTitleFile(s.spillDir, s.titles)
+	if err != nil {
+		return err
+	}
+
+	spilledKeys, err := writeTitleFile(s.spillDir, keys)
+	if err != nil {
+		titles.close()
+		return err
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"pages": s.len(),
+		"file":  titles.file.Name(),
+	}).Msg("search state exceeded the spill threshold, titles and visited pages are moved to disk")
+
+	s.spilled = titles
+	s.spilledKeys = spilledKeys
+	s.hashes = make(map[uint64]uint32, len(keys))
+	s.collisions = make(map[string]uint32)
+	s.ids = nil
+	s.titles = nil
+	s.keyBytes = 0
+	s.titleBytes = 0
+
+	for id, key := range keys {
+		s.addHashed(key, uint32(id))
+	}
+
+	return nil
+}
+
+// writeTitleFile creates a temporary file with the titles.
+func writeTitleFile(dir string, titles []string) (*titleFile, error) {
+	f, err := newTitleFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, title := range titles {
+		err = f.append(title)
+		if err != nil {
+			f.close()
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// close removes the spilled titles, if any.
+func (s *searchState) close() {
+	if s.spilled != nil {
+		s.spilled.close()
+		s.spilledKeys.close()
+	}
+}
+
+// titleFile is an append-only file of titles indexed by page IDs.
+type titleFile struct {
+	file *os.File
+	w    *bufio.Writer
+
+	// offsets[id] is the end of the title with the given ID.
+	offsets []int64
+	size    int64
+
+	// Appended titles are buffered until the next read.
+	dirty bool
+}
+
+func newTitleFile(dir string) (*titleFile, error) {
+	file, err := ioutil.TempFile(dir, "wikibfs-titles-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a file")
+	}
+
+	return &titleFile{
+		file: file,
+		w:    bufio.NewWriter(file),
+	}, nil
+}
+
+func (f *titleFile) append(title string) error {
+	_, err := f.w.WriteString(title)
+	if err != nil {
+		return err
+	}
+
+	f.size += int64(len(title))
+	f.offsets = append(f.offsets, f.size)
+	f.dirty = true
+
+	return nil
+}
+
+func (f *titleFile) read(id uint32) (string, error) {
+	err := f.flush()
+	if err != nil {
+		return "", err
+	}
+
+	var start int64
+	if id > 0 {
+		start = f.offsets[id-1]
+	}
+
+	buf := make([]byte, f.offsets[id]-start)
+	_, err = f.file.ReadAt(buf, start)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the title")
+	}
+
+	return string(buf), nil
+}
+
+func (f *titleFile) forEach(fn func(title string) error) error {
+	err := f.flush()
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(f.file, 0, f.size))
+
+	var buf []byte
+	var start int64
+	for _, end := range f.offsets {
+		if int64(cap(buf)) < end-start {
+			buf = make([]byte, end-start)
+		}
+		buf = buf[:end-start]
+
+		_, err = io.ReadFull(r, buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to read titles")
+		}
+
+		err = fn(string(buf))
+		if err != nil {
+			return err
+		}
+
+		start = end
+	}
+
+	return nil
+}
+
+func (f *titleFile) flush() error {
+	if !f.dirty {
+		return nil
+	}
+
+	err := f.w.Flush()
+	if err != nil {
+		return errors.Wrap(err, "failed to flush titles")
+	}
+
+	f.dirty = false
+
+	return nil
+}
+
+func (f *titleFile) close() {
+	name := f.file.Name()
+
+	err := f.file.Close()
+	if err != nil {
+		zlog.Error().Err(err).Str("file", name).Msg("failed to close the titles file")
+	}
+
+	err = os.Remove(name)
+	if err != nil {
+		zlog.Error().Err(err).Str("file", name).Msg("failed to remove the titles file")
+	}
+}
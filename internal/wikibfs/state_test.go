@@ -0,0 +1,79 @@
+package wikibfs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchState_SpillsVisitedPages(t *testing.T) {
+	state := newSearchState(BFSConfig{SpillDir: t.TempDir()})
+	defer state.close()
+
+	mustAdd := func(key, title string, parent uint32) uint32 {
+		t.Helper()
+
+		id, added, err := state.add(key, title, parent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !added {
+			t.Fatalf("%s was not added", key)
+		}
+
+		return id
+	}
+
+	apple := mustAdd("apple", "Apple", noParent)
+	fruit := mustAdd("fruit", "Fruit", apple)
+
+	err := state.spill()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ids != nil || state.titles != nil {
+		t.Fatal("normalized titles and titles are kept in memory after spilling")
+	}
+
+	tree := mustAdd("tree", "Tree", fruit)
+
+	// Pretend that the hash of "plant" is taken by "tree".
+	state.hashes[hashKey("plant")] = tree
+	plant := mustAdd("plant", "Plant", tree)
+	if _, ok := state.collisions["plant"]; !ok {
+		t.Fatal("the colliding page is not keyed by its normalized title")
+	}
+
+	for key, want := range map[string]uint32{"apple": apple, "fruit": fruit, "tree": tree, "plant": plant} {
+		id, ok, err := state.lookup(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || id != want {
+			t.Errorf("lookup(%q) = %d, %v, want %d", key, id, ok, want)
+		}
+
+		_, added, err := state.add(key, key, noParent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if added {
+			t.Errorf("%s was added twice", key)
+		}
+	}
+
+	_, ok, err := state.lookup("stone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("an unknown page was found")
+	}
+
+	path, err := state.path(plant, "Plant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Apple", "Fruit", "Tree", "Plant"}; !reflect.DeepEqual(path, want) {
+		t.Errorf("path = %v, want %v", path, want)
+	}
+}
@@ -0,0 +1,247 @@
+// Package wikidump reads MediaWiki SQL dumps (e.g. enwiki-latest-page.sql.gz)
+// without loading them into a database.
+package wikidump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Row is a single tuple of an INSERT statement.
+// Values are unescaped, NULL is represented by an empty string.
+type Row []string
+
+func (r Row) Int(i int) (int64, error) {
+	if i >= len(r) {
+		return 0, fmt.Errorf("column %d is out of range", i)
+	}
+
+	return strconv.ParseInt(r[i], 10, 64)
+}
+
+// ReadFile streams rows of the given table from the dump file.
+// The row passed to handle is reused between calls and must not be retained.
+// Files with the .gz extension are decompressed on the fly.
+func ReadFile(path, table string, handle func(row Row) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open dump")
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.Wrap(err, "failed to open gzip stream")
+		}
+		defer gz.Close()
+
+		r = gz
+	}
+
+	return Read(r, table, handle)
+}
+
+// Read streams rows of the given table from the dump.
+// Statements other than INSERT INTO `table` are skipped.
+func Read(r io.Reader, table string, handle func(row Row) error) error {
+	reader := bufio.NewReaderSize(r, 1<<20)
+	prefix := []byte(fmt.Sprintf("INSERT INTO `%s` VALUES ", table))
+
+	for {
+		line, lineEnded, err := readLinePrefix(reader, len(prefix))
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "read failed")
+		}
+
+		if lineEnded {
+			continue
+		}
+
+		if !bytes.Equal(line, prefix) {
+			err = skipLine(reader)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.Wrap(err, "read failed")
+			}
+
+			continue
+		}
+
+		err = readTuples(reader, handle)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readLinePrefix reads at most n bytes of the current line.
+// If the line is shorter, the newline character is consumed and lineEnded is true.
+func readLinePrefix(r *bufio.Reader, n int) (buf []byte, lineEnded bool, err error) {
+	buf = make([]byte, 0, n)
+	for len(buf) < n {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if b == '\n' {
+			return buf, true, nil
+		}
+
+		buf = append(buf, b)
+	}
+
+	return buf, false, nil
+}
+
+func skipLine(r *bufio.Reader) error {
+	for {
+		_, err := r.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+
+		return err
+	}
+}
+
+// readTuples parses `(v1,v2,...),(...);` until the terminating semicolon.
+func readTuples(r *bufio.Reader, handle func(row Row) error) error {
+	var row Row
+	var value []byte
+
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return errors.Wrap(err, "unexpected end of INSERT statement")
+		}
+
+		switch b {
+		case '(':
+			row = row[:0]
+
+		case ',':
+			// Separator between tuples.
+
+		case ';':
+			err = skipLine(r)
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+
+		default:
+			return fmt.Errorf("unexpected character %q between tuples", b)
+		}
+
+		if b != '(' {
+			continue
+		}
+
+		for {
+			value, b, err = readValue(r, value[:0])
+			if err != nil {
+				return err
+			}
+
+			row = append(row, string(value))
+			if b == ')' {
+				break
+			}
+		}
+
+		err = handle(row)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readValue reads a single value and returns it together with the following delimiter (',' or ')').
+func readValue(r *bufio.Reader, buf []byte) ([]byte, byte, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unexpected end of tuple")
+	}
+
+	if b == '\'' {
+		for {
+			b, err = r.ReadByte()
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "unexpected end of string")
+			}
+
+			if b == '\'' {
+				break
+			}
+
+			if b == '\\' {
+				b, err = r.ReadByte()
+				if err != nil {
+					return nil, 0, errors.Wrap(err, "unexpected end of string")
+				}
+
+				b = unescape(b)
+			}
+
+			buf = append(buf, b)
+		}
+
+		b, err = r.ReadByte()
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "unexpected end of tuple")
+		}
+	} else {
+		for b != ',' && b != ')' {
+			buf = append(buf, b)
+
+			b, err = r.ReadByte()
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "unexpected end of tuple")
+			}
+		}
+
+		if string(buf) == "NULL" {
+			buf = buf[:0]
+		}
+	}
+
+	if b != ',' && b != ')' {
+		return nil, 0, fmt.Errorf("unexpected character %q after value", b)
+	}
+
+	return buf, b, nil
+}
+
+func unescape(b byte) byte {
+	switch b {
+	case '0':
+		return 0
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	default:
+		return b
+	}
+}
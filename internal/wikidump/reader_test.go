@@ -0,0 +1,81 @@
+package wikidump
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDump = "-- MySQL dump 10.19\n" +
+	"DROP TABLE IF EXISTS `page`;\n" +
+	"CREATE TABLE `page` (\n  `page_id` int NOT NULL\n);\n" +
+	"INSERT INTO `redirect` VALUES (9,0,'Skipped','','');\n" +
+	"INSERT INTO `page` VALUES (1,0,'It\\'s_a_\\\"test\\\"',0,NULL),(2,0,'Back\\\\slash,\\n(line)',1,-1.5);\n" +
+	"INSERT INTO `page` VALUES (3,0,'',0,'');\n" +
+	"/*!40000 ALTER TABLE `page` ENABLE KEYS */;\n"
+
+func TestRead(t *testing.T) {
+	var rows []Row
+	err := Read(strings.NewReader(testDump), "page", func(row Row) error {
+		rows = append(rows, append(Row{}, row...))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	want := []Row{
+		{"1", "0", `It's_a_"test"`, "0", ""},
+		{"2", "0", "Back\\slash,\n(line)", "1", "-1.5"},
+		{"3", "0", "", "0", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestRead_Malformed(t *testing.T) {
+	dumps := []string{
+		"INSERT INTO `page` VALUES (1,'unterminated",
+		"INSERT INTO `page` VALUES (1,2)x(3,4);\n",
+		"INSERT INTO `page` VALUES (1,'a'b);\n",
+	}
+
+	for _, dump := range dumps {
+		err := Read(strings.NewReader(dump), "page", func(Row) error { return nil })
+		if err == nil {
+			t.Errorf("no error for %q", dump)
+		}
+	}
+}
+
+func TestReadFile_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(testDump))
+	_ = gz.Close()
+
+	path := filepath.Join(t.TempDir(), "page.sql.gz")
+	err := os.WriteFile(path, buf.Bytes(), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int64
+	err = ReadFile(path, "page", func(row Row) error {
+		id, err := row.Int(0)
+		ids = append(ids, id)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []int64{1, 2, 3}) {
+		t.Errorf("ids = %v, want [1 2 3]", ids)
+	}
+}
@@ -0,0 +1,47 @@
+package wikigraphserver
+
+import (
+	"context"
+
+	"github.com/lodthe/wiki-graph/pkg/wikigraphpb"
+	zlog "github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultHubsLimit = 100
+	maxHubsLimit     = 1000
+)
+
+func (s *Server) ListHubs(_ context.Context, in *wikigraphpb.ListHubsRequest) (*wikigraphpb.ListHubsResponse, error) {
+	limit := int(in.GetLimit())
+	if limit == 0 {
+		limit = defaultHubsLimit
+	}
+	if limit > maxHubsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit cannot be more than %d", maxHubsLimit)
+	}
+
+	rankings, err := s.hubs.Top(limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("failed to list hubs")
+		return nil, status.Error(codes.Internal, "failed to list the hubs")
+	}
+
+	response := new(wikigraphpb.ListHubsResponse)
+	for _, ranking := range rankings {
+		response.Hubs = append(response.Hubs, &wikigraphpb.Hub{
+			Page:        ranking.PageTitle,
+			Rank:        uint32(ranking.Rank),
+			Score:       ranking.Score,
+			Pagerank:    ranking.PageRank,
+			InDegree:    uint32(ranking.InDegree),
+			Betweenness: ranking.Betweenness,
+			ComputedAt:  timestamppb.New(ranking.ComputedAt),
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,95 @@
+package wikigraphserver
+
+import (
+	"context"
+
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/lodthe/wiki-graph/pkg/wikigraphpb"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultNeighborsPageSize = 100
+
+func (s *Server) GetNeighbors(ctx context.Context, in *wikigraphpb.GetNeighborsRequest) (*wikigraphpb.GetNeighborsResponse, error) {
+	if in.GetPage() == "" {
+		return nil, status.Error(codes.InvalidArgument, "page is empty")
+	}
+
+	pageSize := int(in.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultNeighborsPageSize
+	}
+	if pageSize > wikiclient.MaxLinksPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size cannot be more than %d", wikiclient.MaxLinksPageSize)
+	}
+
+	namespaces := make([]int, 0, len(in.GetNamespaces()))
+	for _, ns := range in.GetNamespaces() {
+		namespaces = append(namespaces, int(ns))
+	}
+
+	var links *wikiclient.LinksPage
+	var err error
+	switch in.GetDirection() {
+	case wikigraphpb.LinkDirection_LINK_DIRECTION_UNSPECIFIED, wikigraphpb.LinkDirection_OUTGOING:
+		links, err = s.wikiClient.ListLinks(ctx, in.GetPage(), namespaces, pageSize, in.GetPageToken())
+
+	case wikigraphpb.LinkDirection_INCOMING:
+		links, err = s.wikiClient.ListLinkingPages(ctx, in.GetPage(), namespaces, pageSize, in.GetPageToken())
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown direction %d", in.GetDirection())
+	}
+	if errors.Is(err, wikiclient.ErrPageNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("page", in.GetPage()).Msg("failed to list links")
+		return nil, status.Error(codes.Unavailable, errors.Wrap(err, "failed to list links").Error())
+	}
+
+	response := &wikigraphpb.GetNeighborsResponse{
+		NextPageToken: links.NextCursor,
+	}
+	for _, link := range links.Links {
+		response.Links = append(response.Links, &wikigraphpb.Link{
+			Title:     link.Title,
+			Namespace: int32(link.Namespace),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *Server) GetNeighborhood(ctx context.Context, in *wikigraphpb.GetNeighborhoodRequest) (*wikigraphpb.GetNeighborhoodResponse, error) {
+	if in.GetPage() == "" {
+		return nil, status.Error(codes.InvalidArgument, "page is empty")
+	}
+
+	if in.GetHops() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "hops is zero")
+	}
+
+	priority, err := priorityFromProto(in.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	task, err := s.createTask(ctx, in.GetPage(), "", priority, pathtask.Options{
+		Kind:        pathtask.KindNeighborhood,
+		MaxDistance: uint(in.GetHops()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &wikigraphpb.GetNeighborhoodResponse{
+		TaskId: &wikigraphpb.TaskId{
+			Id: task.ID.String(),
+		},
+	}, nil
+}
@@ -0,0 +1,379 @@
+package wikigraphserver
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/wikirace"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/lodthe/wiki-graph/pkg/wikigraphpb"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) SubmitPath(ctx context.Context, in *wikigraphpb.SubmitPathRequest) (*wikigraphpb.SubmitPathResponse, error) {
+	if len(in.GetPages()) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "at least two pages are required")
+	}
+
+	if len(in.GetPages()) > wikirace.MaxPages {
+		return nil, status.Errorf(codes.InvalidArgument, "a path cannot have more than %d pages", wikirace.MaxPages)
+	}
+
+	for _, page := range in.GetPages() {
+		if page == "" {
+			return nil, status.Error(codes.InvalidArgument, "a page title is empty")
+		}
+	}
+
+	priority, err := priorityFromProto(in.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	caller, err := s.callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := s.referee.Submit(ctx, caller, in.GetPages(), priority)
+	if errors.Is(err, pathtask.ErrTooManyUnfinished) {
+		return nil, errTooManyUnfinished
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("caller", caller).Msg("failed to referee the path")
+		return nil, status.Error(codes.Unavailable, errors.Wrap(err, "failed to check the path").Error())
+	}
+
+	return &wikigraphpb.SubmitPathResponse{
+		Submission: submissionToProto(submission),
+	}, nil
+}
+
+func (s *Server) GetSubmission(_ context.Context, in *wikigraphpb.GetSubmissionRequest) (*wikigraphpb.GetSubmissionResponse, error) {
+	if in.GetSubmissionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "submission_id is empty")
+	}
+
+	id, err := uuid.Parse(in.GetSubmissionId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "submission_id is invalid").Error())
+	}
+
+	submission, err := s.referee.Get(id)
+	if errors.Is(err, wikirace.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("id", id.String()).Msg("failed to get submission")
+		return nil, status.Error(codes.Internal, "failed to get the submission")
+	}
+
+	return &wikigraphpb.GetSubmissionResponse{
+		Submission: submissionToProto(submission),
+	}, nil
+}
+
+func submissionToProto(submission *wikirace.Submission) *wikigraphpb.Submission {
+	converted := &wikigraphpb.Submission{
+		Id:          submission.ID.String(),
+		CreatedAt:   timestamppb.New(submission.CreatedAt),
+		Pages:       submission.Pages,
+		Valid:       submission.Valid(),
+		Scored:      submission.Score != nil,
+		Approximate: submission.Approximate,
+	}
+
+	if hop := submission.InvalidHop; hop != nil {
+		converted.InvalidHopIndex = uint32(*hop)
+		converted.InvalidHop = &wikigraphpb.Hop{From: submission.Pages[*hop]}
+		if *hop+1 < len(submission.Pages) {
+			converted.InvalidHop.To = submission.Pages[*hop+1]
+		}
+	}
+
+	if submission.Score != nil {
+		converted.Score = uint32(*submission.Score)
+	}
+	if submission.OptimalDistance != nil {
+		converted.OptimalDistance = uint32(*submission.OptimalDistance)
+	}
+	if submission.OptimalTaskID != nil {
+		converted.OptimalTaskId = &wikigraphpb.TaskId{Id: submission.OptimalTaskID.String()}
+	}
+
+	return converted
+}
+
+func (s *Server) CreateRace(ctx context.Context, in *wikigraphpb.CreateRaceRequest) (*wikigraphpb.CreateRaceResponse, error) {
+	var duration time.Duration
+	if in.GetDuration() != nil {
+		err := in.GetDuration().CheckValid()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "duration is invalid").Error())
+		}
+
+		duration = in.GetDuration().AsDuration()
+		if duration <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "duration must be positive")
+		}
+		if duration > wikirace.MaxRaceDuration {
+			return nil, status.Errorf(codes.InvalidArgument, "duration cannot be more than %s", wikirace.MaxRaceDuration)
+		}
+	}
+
+	caller, err := s.callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	race, err := s.referee.CreateRace(ctx, caller, wikirace.RaceOptions{
+		StartPage:  in.GetStartPage(),
+		TargetPage: in.GetTargetPage(),
+		Duration:   duration,
+	})
+	if errors.Is(err, wikiclient.ErrPageNotFound) || errors.Is(err, wikirace.ErrSamePages) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, pathtask.ErrTooManyUnfinished) {
+		return nil, errTooManyUnfinished
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("caller", caller).Msg("failed to create a race")
+		return nil, status.Error(codes.Unavailable, errors.Wrap(err, "failed to create the race").Error())
+	}
+
+	return &wikigraphpb.CreateRaceResponse{
+		Race: raceToProto(race),
+	}, nil
+}
+
+func (s *Server) GetRace(_ context.Context, in *wikigraphpb.GetRaceRequest) (*wikigraphpb.GetRaceResponse, error) {
+	id, err := parseRaceID(in.GetRaceId())
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard, err := s.referee.GetRace(id)
+	if errors.Is(err, wikirace.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("id", id.String()).Msg("failed to get race")
+		return nil, status.Error(codes.Internal, "failed to get the race")
+	}
+
+	return &wikigraphpb.GetRaceResponse{
+		Leaderboard: leaderboardToProto(leaderboard),
+	}, nil
+}
+
+// Race joins the player to the race and accepts their hops until the client closes the stream.
+// Leaderboards are sent between the replies as the race goes on.
+func (s *Server) Race(stream wikigraphpb.WikiGraph_RaceServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	join := first.GetJoin()
+	if join == nil {
+		return status.Error(codes.InvalidArgument, "the first message must join a race")
+	}
+
+	raceID, err := parseRaceID(join.GetRaceId())
+	if err != nil {
+		return err
+	}
+
+	caller, err := s.callerFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := join.GetPlayer()
+	if name == "" {
+		name = caller
+	}
+	if len(name) > wikirace.MaxPlayerNameLength {
+		return status.Errorf(codes.InvalidArgument, "player cannot be longer than %d bytes", wikirace.MaxPlayerNameLength)
+	}
+
+	// Subscribe before joining to receive the leaderboard with the player.
+	updates, unsubscribe := s.referee.Subscribe(raceID)
+	defer unsubscribe()
+
+	_, player, err := s.referee.Join(raceID, caller, name)
+	if errors.Is(err, wikirace.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, wikirace.ErrPlayerTaken) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, wikirace.ErrRaceOver) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("race_id", raceID.String()).Str("player", name).Msg("failed to join the race")
+		return status.Error(codes.Internal, "failed to join the race")
+	}
+
+	err = stream.Send(&wikigraphpb.RaceEvent{
+		Event: &wikigraphpb.RaceEvent_Joined{Joined: racePlayerToProto(player)},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Messages are received in the background, so that leaderboards are sent while the player thinks.
+	requests := make(chan *wikigraphpb.RaceRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			request, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case requests <- request:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var event *wikigraphpb.RaceEvent
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err := <-recvErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+
+		case leaderboard := <-updates:
+			event = &wikigraphpb.RaceEvent{
+				Event: &wikigraphpb.RaceEvent_Leaderboard{Leaderboard: leaderboardToProto(leaderboard)},
+			}
+
+		case request := <-requests:
+			hop, ok := request.GetAction().(*wikigraphpb.RaceRequest_Hop)
+			if !ok || hop.Hop == "" {
+				return status.Error(codes.InvalidArgument, "only hops are accepted after joining")
+			}
+
+			result, err := s.referee.Hop(ctx, raceID, caller, name, hop.Hop)
+			if errors.Is(err, wikirace.ErrPlayerTaken) {
+				return status.Error(codes.PermissionDenied, err.Error())
+			}
+			if err != nil {
+				zlog.Error().Err(err).Str("race_id", raceID.String()).Str("player", name).Msg("failed to check the hop")
+				return status.Error(codes.Unavailable, errors.Wrap(err, "failed to check the hop").Error())
+			}
+
+			event = &wikigraphpb.RaceEvent{
+				Event: &wikigraphpb.RaceEvent_Hop{Hop: &wikigraphpb.HopResult{
+					Accepted: result.Accepted,
+					Reason:   result.Reason,
+					Player:   racePlayerToProto(result.Player),
+				}},
+			}
+		}
+
+		err = stream.Send(event)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func parseRaceID(raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "race_id is empty")
+	}
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "race_id is invalid").Error())
+	}
+
+	return id, nil
+}
+
+func raceToProto(race *wikirace.Race) *wikigraphpb.Race {
+	converted := &wikigraphpb.Race{
+		Id:           race.ID.String(),
+		CreatedAt:    timestamppb.New(race.CreatedAt),
+		EndsAt:       timestamppb.New(race.EndsAt),
+		Over:         race.Over(time.Now()),
+		StartPage:    race.StartPage,
+		TargetPage:   race.TargetPage,
+		OptimalKnown: race.OptimalDistance != nil,
+		Approximate:  race.Approximate,
+	}
+
+	if race.OptimalDistance != nil {
+		converted.OptimalDistance = uint32(*race.OptimalDistance)
+	}
+	if race.OptimalTaskID != nil {
+		converted.OptimalTaskId = &wikigraphpb.TaskId{Id: race.OptimalTaskID.String()}
+	}
+
+	return converted
+}
+
+func racePlayerToProto(player *wikirace.Player) *wikigraphpb.RacePlayer {
+	converted := &wikigraphpb.RacePlayer{
+		Name:     player.Name,
+		JoinedAt: timestamppb.New(player.JoinedAt),
+		Path:     player.Path,
+		Hops:     uint32(player.Hops()),
+		Finished: player.FinishedAt != nil,
+	}
+
+	if player.FinishedAt != nil {
+		converted.FinishedAt = timestamppb.New(*player.FinishedAt)
+	}
+
+	return converted
+}
+
+func leaderboardToProto(leaderboard *wikirace.Leaderboard) *wikigraphpb.Leaderboard {
+	converted := &wikigraphpb.Leaderboard{
+		Race: raceToProto(leaderboard.Race),
+	}
+
+	for _, entry := range leaderboard.Entries {
+		converted.Entries = append(converted.Entries, &wikigraphpb.LeaderboardEntry{
+			Rank:   uint32(entry.Rank),
+			Player: racePlayerToProto(entry.Player),
+			Scored: entry.Score != nil,
+			Score:  scoreToProto(entry.Score),
+		})
+	}
+
+	return converted
+}
+
+func scoreToProto(score *int) uint32 {
+	if score == nil {
+		return 0
+	}
+
+	return uint32(*score)
+}
@@ -0,0 +1,135 @@
+package wikigraphserver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/separation"
+	"github.com/lodthe/wiki-graph/pkg/wikigraphpb"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultSamplingRunsLimit = 20
+	maxSamplingRunsLimit     = 100
+)
+
+func (s *Server) StartSamplingRun(ctx context.Context, in *wikigraphpb.StartSamplingRunRequest) (*wikigraphpb.StartSamplingRunResponse, error) {
+	if in.GetPairs() == 0 || in.GetPairs() > separation.MaxPairs {
+		return nil, status.Errorf(codes.InvalidArgument, "pairs must be from 1 to %d", separation.MaxPairs)
+	}
+
+	priority := pathtask.PriorityBatch
+	if in.GetPriority() != wikigraphpb.Priority_PRIORITY_UNSPECIFIED {
+		var err error
+		priority, err = priorityFromProto(in.GetPriority())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	caller, err := s.callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.sampler.Start(ctx, caller, int(in.GetPairs()), uint(in.GetMaxDistance()), priority)
+	if errors.Is(err, pathtask.ErrTooManyUnfinished) {
+		return nil, errTooManyUnfinished
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("caller", caller).Msg("failed to start a sampling run")
+		return nil, status.Error(codes.Unavailable, errors.Wrap(err, "failed to start the run").Error())
+	}
+
+	return &wikigraphpb.StartSamplingRunResponse{
+		Run: samplingRunToProto(run),
+	}, nil
+}
+
+func (s *Server) GetSamplingRun(_ context.Context, in *wikigraphpb.GetSamplingRunRequest) (*wikigraphpb.GetSamplingRunResponse, error) {
+	if in.GetRunId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "run_id is empty")
+	}
+
+	id, err := uuid.Parse(in.GetRunId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "run_id is invalid").Error())
+	}
+
+	run, err := s.sampler.Get(id)
+	if errors.Is(err, separation.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("id", id.String()).Msg("failed to get sampling run")
+		return nil, status.Error(codes.Internal, "failed to get the run")
+	}
+
+	return &wikigraphpb.GetSamplingRunResponse{
+		Run: samplingRunToProto(run),
+	}, nil
+}
+
+func (s *Server) ListSamplingRuns(_ context.Context, in *wikigraphpb.ListSamplingRunsRequest) (*wikigraphpb.ListSamplingRunsResponse, error) {
+	limit := int(in.GetLimit())
+	if limit == 0 {
+		limit = defaultSamplingRunsLimit
+	}
+	if limit > maxSamplingRunsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit cannot be more than %d", maxSamplingRunsLimit)
+	}
+
+	runs, err := s.sampler.List(limit)
+	if err != nil {
+		zlog.Error().Err(err).Msg("failed to list sampling runs")
+		return nil, status.Error(codes.Internal, "failed to list the runs")
+	}
+
+	response := new(wikigraphpb.ListSamplingRunsResponse)
+	for _, run := range runs {
+		response.Runs = append(response.Runs, samplingRunToProto(run))
+	}
+
+	return response, nil
+}
+
+func samplingRunToProto(run *separation.Run) *wikigraphpb.SamplingRun {
+	converted := &wikigraphpb.SamplingRun{
+		Id:          run.ID.String(),
+		CreatedAt:   timestamppb.New(run.CreatedAt),
+		Finished:    run.FinishedAt != nil,
+		Pairs:       uint32(run.Pairs),
+		MaxDistance: uint32(run.MaxDistance),
+	}
+
+	if run.FinishedAt != nil {
+		converted.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+
+	stats := run.Stats
+	if stats == nil {
+		return converted
+	}
+
+	converted.Done = uint32(stats.Done)
+	converted.Failed = uint32(stats.Failed)
+	converted.Reachable = uint32(stats.Reachable())
+	converted.Unreachable = uint32(stats.Unreachable)
+	converted.UnreachableRatio = stats.UnreachableRatio()
+	converted.SearchedDistance = uint32(stats.SearchedDistance)
+	converted.Incomplete = uint32(stats.Incomplete)
+	converted.MeanDistance = stats.MeanDistance
+	converted.MedianDistance = uint32(stats.MedianDistance)
+
+	for _, count := range stats.Histogram {
+		converted.Histogram = append(converted.Histogram, uint32(count))
+	}
+
+	return converted
+}
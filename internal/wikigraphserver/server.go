@@ -2,32 +2,91 @@ package wikigraphserver
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/distcache"
+	"github.com/lodthe/wiki-graph/internal/hubrank"
 	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/pathverify"
+	"github.com/lodthe/wiki-graph/internal/separation"
 	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/internal/wikirace"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
 	"github.com/lodthe/wiki-graph/pkg/wikigraphpb"
 	"github.com/pkg/errors"
 	zlog "github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// CallerTokenMetadataKey is the gRPC metadata key clients authenticate with.
+// Clients without a token are identified by their network address.
+const CallerTokenMetadataKey = "x-caller-token"
+
+// errTooManyUnfinished is returned when a request would exceed the limit of unfinished tasks of the caller.
+var errTooManyUnfinished = status.Error(codes.ResourceExhausted, "too many unfinished tasks, wait for them to finish")
+
+type Config struct {
+	// Maximum number of unfinished tasks of a single caller, 0 means unlimited.
+	MaxUnfinishedTasks int
+
+	// Caller tokens -> caller names. Callers are never trusted to name themselves,
+	// so clients sharing a host have to be told apart by their tokens.
+	CallerTokens map[string]string
+
+	// Cached distances older than this are computed again.
+	DistanceCacheTTL time.Duration
+
+	// Distance limit of the workers, cached results must cover it to answer queries without a limit.
+	DistanceQueryThreshold uint
+}
+
 type Server struct {
 	wikigraphpb.UnimplementedWikiGraphServer
 
-	repo     pathtask.Repository
-	producer *taskqueue.Producer
+	repo       pathtask.Repository
+	distances  distcache.Repository
+	hubs       hubrank.Repository
+	producer   *taskqueue.Producer
+	wikiClient *wikiclient.Client
+	verifier   *pathverify.Verifier
+	referee    *wikirace.Referee
+	sampler    *separation.Sampler
+
+	config Config
 }
 
-func New(repo pathtask.Repository, producer *taskqueue.Producer) *Server {
+func New(
+	repo pathtask.Repository,
+	distances distcache.Repository,
+	hubs hubrank.Repository,
+	producer *taskqueue.Producer,
+	wikiClient *wikiclient.Client,
+	verifier *pathverify.Verifier,
+	referee *wikirace.Referee,
+	sampler *separation.Sampler,
+	config Config,
+) *Server {
 	return &Server{
-		repo:     repo,
-		producer: producer,
+		repo:       repo,
+		distances:  distances,
+		hubs:       hubs,
+		producer:   producer,
+		wikiClient: wikiClient,
+		verifier:   verifier,
+		referee:    referee,
+		sampler:    sampler,
+		config:     config,
 	}
 }
 
-func (s *Server) FindShortestPath(_ context.Context, in *wikigraphpb.FindShortestPathRequest) (*wikigraphpb.FindShortestPathResponse, error) {
+func (s *Server) FindShortestPath(ctx context.Context, in *wikigraphpb.FindShortestPathRequest) (*wikigraphpb.FindShortestPathResponse, error) {
 	if in.GetFrom() == "" {
 		return nil, status.Error(codes.InvalidArgument, "from is empty")
 	}
@@ -36,41 +95,201 @@ func (s *Server) FindShortestPath(_ context.Context, in *wikigraphpb.FindShortes
 		return nil, status.Error(codes.InvalidArgument, "to is empty")
 	}
 
-	task, err := s.repo.Create(in.GetFrom(), in.GetTo())
+	var options pathtask.Options
+	if in.GetAsOf() != nil {
+		err := in.GetAsOf().CheckValid()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "as_of is invalid").Error())
+		}
+
+		asOf := in.GetAsOf().AsTime()
+		if asOf.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "as_of is in the future")
+		}
+
+		options.AsOf = &asOf
+	}
+
+	priority, err := priorityFromProto(in.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	options.Mode, err = modeFromProto(in.GetMode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if in.GetProveOptimal() && options.Mode != pathtask.ModeBestFirst {
+		return nil, status.Error(codes.InvalidArgument, "prove_optimal requires the BEST_FIRST mode")
+	}
+	options.ProveOptimal = in.GetProveOptimal()
+
+	if in.GetNumPaths() > pathtask.MaxNumPaths {
+		return nil, status.Errorf(codes.InvalidArgument, "num_paths cannot be more than %d", pathtask.MaxNumPaths)
+	}
+	options.NumPaths = int(in.GetNumPaths())
+	options.Explain = in.GetExplain()
+
+	if in.GetAvoidHubs() > pathtask.MaxAvoidHubs {
+		return nil, status.Errorf(codes.InvalidArgument, "avoid_hubs cannot be more than %d", pathtask.MaxAvoidHubs)
+	}
+	options.AvoidHubs = uint(in.GetAvoidHubs())
+
+	options.TieBreak, err = tieBreakFromProto(in.GetTieBreak())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if options.UsesHubs() && options.Mode != pathtask.ModeBFS {
+		return nil, status.Error(codes.InvalidArgument, "avoid_hubs and tie_break require the BFS mode")
+	}
+	if options.TieBreak != pathtask.TieBreakNone && options.NumPaths > 1 {
+		return nil, status.Error(codes.InvalidArgument, "tie_break cannot be combined with num_paths")
+	}
+
+	task, err := s.createTask(ctx, in.GetFrom(), in.GetTo(), priority, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wikigraphpb.FindShortestPathResponse{
+		TaskId: &wikigraphpb.TaskId{
+			Id: task.ID.String(),
+		},
+	}, nil
+}
+
+func (s *Server) GetDistance(ctx context.Context, in *wikigraphpb.GetDistanceRequest) (*wikigraphpb.GetDistanceResponse, error) {
+	if in.GetFrom() == "" {
+		return nil, status.Error(codes.InvalidArgument, "from is empty")
+	}
+
+	if in.GetTo() == "" {
+		return nil, status.Error(codes.InvalidArgument, "to is empty")
+	}
+
+	priority, err := priorityFromProto(in.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// The cache is an optimization, so the distance is computed again if it cannot be read.
+	cached, err := s.distances.Get(in.GetFrom(), in.GetTo())
+	if err != nil && !errors.Is(err, distcache.ErrNotFound) {
+		zlog.Error().Err(err).Str("from", in.GetFrom()).Str("to", in.GetTo()).Msg("failed to get cached distance")
+	}
+	if err == nil && time.Since(cached.ComputedAt) < s.config.DistanceCacheTTL && cached.Answers(int(in.GetMaxDistance()), int(s.config.DistanceQueryThreshold)) {
+		return &wikigraphpb.GetDistanceResponse{
+			Result: cachedDistanceToProto(cached, in.GetMaxDistance()),
+		}, nil
+	}
+
+	task, err := s.createTask(ctx, in.GetFrom(), in.GetTo(), priority, pathtask.Options{
+		Kind:        pathtask.KindDistance,
+		MaxDistance: uint(in.GetMaxDistance()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &wikigraphpb.GetDistanceResponse{
+		TaskId: &wikigraphpb.TaskId{
+			Id: task.ID.String(),
+		},
+	}, nil
+}
+
+// createTask saves and enqueues a new task. The returned error is a gRPC status.
+func (s *Server) createTask(
+	ctx context.Context,
+	from, to string,
+	priority pathtask.Priority,
+	options pathtask.Options,
+) (*pathtask.Task, error) {
+	caller, err := s.callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.CreateWithinLimit(from, to, caller, priority, options, s.config.MaxUnfinishedTasks)
+	if errors.Is(err, pathtask.ErrTooManyUnfinished) {
+		return nil, errTooManyUnfinished
+	}
 	if err != nil {
 		zlog.Error().Err(err).Fields(map[string]interface{}{
-			"from": in.GetFrom(),
-			"to":   in.GetTo(),
+			"from":   from,
+			"to":     to,
+			"caller": caller,
 		}).Msg("failed to create task")
 
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	zlog.Info().Fields(map[string]interface{}{
-		"id":   task.ID.String(),
-		"from": task.From,
-		"to":   task.To,
+		"id":       task.ID.String(),
+		"from":     task.From,
+		"to":       task.To,
+		"caller":   task.Caller,
+		"priority": task.Priority,
+		"kind":     task.Options.Kind,
 	}).Msg("created a new task")
 
-	err = s.producer.Produce(taskqueue.Task{ID: task.ID})
+	err = s.producer.Produce(taskqueue.Task{ID: task.ID, Priority: uint8(task.Priority)})
 	if err != nil {
 		zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to publish")
 		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to enqueue the task").Error())
 	}
 
-	return &wikigraphpb.FindShortestPathResponse{
-		TaskId: &wikigraphpb.TaskId{
-			Id: task.ID.String(),
-		},
-	}, nil
+	return task, nil
 }
 
 func (s *Server) GetTask(_ context.Context, in *wikigraphpb.GetTaskRequest) (*wikigraphpb.GetTaskResponse, error) {
-	if in.GetTaskId().GetId() == "" {
+	task, err := s.getTask(in.GetTaskId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &wikigraphpb.GetTaskResponse{
+		Task: s.taskToProto(task),
+	}, nil
+}
+
+func (s *Server) VerifyTask(ctx context.Context, in *wikigraphpb.VerifyTaskRequest) (*wikigraphpb.VerifyTaskResponse, error) {
+	task, err := s.getTask(in.GetTaskId())
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := s.callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.verifier.Verify(ctx, task, in.GetRerunIfInvalid(), caller, task.Priority)
+	if errors.Is(err, pathverify.ErrNotVerifiable) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, pathtask.ErrTooManyUnfinished) {
+		return nil, errTooManyUnfinished
+	}
+	if err != nil {
+		zlog.Error().Err(err).Str("id", task.ID.String()).Msg("failed to verify the path")
+		return nil, status.Error(codes.Unavailable, errors.Wrap(err, "failed to verify the path").Error())
+	}
+
+	return &wikigraphpb.VerifyTaskResponse{
+		Verification: verificationToProto(time.Now(), verification),
+	}, nil
+}
+
+// getTask finds the task by its ID. The returned error is a gRPC status.
+func (s *Server) getTask(taskID *wikigraphpb.TaskId) (*pathtask.Task, error) {
+	if taskID.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "task_id is empty")
 	}
 
-	id, err := uuid.Parse(in.GetTaskId().GetId())
+	id, err := uuid.Parse(taskID.GetId())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "task_id is invalid").Error())
 	}
@@ -79,14 +298,12 @@ func (s *Server) GetTask(_ context.Context, in *wikigraphpb.GetTaskRequest) (*wi
 	if errors.Is(err, pathtask.ErrNotFound) {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	if errors.Is(err, pathtask.ErrNotFound) {
+	if err != nil {
 		zlog.Error().Err(err).Str("id", id.String()).Msg("failed to get task")
 		return nil, status.Error(codes.Internal, "failed to find a task")
 	}
 
-	return &wikigraphpb.GetTaskResponse{
-		Task: s.taskToProto(task),
-	}, nil
+	return task, nil
 }
 
 func (s *Server) taskToProto(task *pathtask.Task) *wikigraphpb.Task {
@@ -99,6 +316,77 @@ func (s *Server) taskToProto(task *pathtask.Task) *wikigraphpb.Task {
 	}
 	if task.Result != nil {
 		converted.Path = task.Result.ShortestPath
+		converted.Complete = task.Result.Complete
+		converted.FailedPages = task.Result.FailedPages
+		converted.Heuristic = task.Result.Heuristic
+
+		for _, path := range task.Result.Paths {
+			converted.Paths = append(converted.Paths, &wikigraphpb.Path{Pages: path})
+		}
+		converted.Stats = statsToProto(task.Result.Stats)
+
+		if task.Options.Kind == pathtask.KindDistance && task.Status == pathtask.StatusDone {
+			converted.DistanceResult = &wikigraphpb.DistanceResult{
+				Reachable:        task.Result.Distance != nil,
+				SearchedDistance: uint32(task.Result.SearchedDistance),
+			}
+			if task.Result.Distance != nil {
+				converted.DistanceResult.Distance = uint32(*task.Result.Distance)
+			}
+		}
+
+		for _, neighbor := range task.Result.Neighborhood {
+			converted.Neighborhood = append(converted.Neighborhood, &wikigraphpb.Neighbor{
+				Title:    neighbor.Title,
+				Distance: uint32(neighbor.Distance),
+			})
+		}
+		converted.NeighborhoodTruncated = task.Result.Truncated
+
+		for _, explanation := range task.Result.Explanations {
+			converted.Explanations = append(converted.Explanations, &wikigraphpb.HopExplanation{
+				From:     explanation.From,
+				To:       explanation.To,
+				Section:  explanation.Section,
+				Sentence: explanation.Sentence,
+			})
+		}
+	}
+	if task.Options.AsOf != nil {
+		converted.AsOf = timestamppb.New(*task.Options.AsOf)
+	}
+	if task.VerifiedAt != nil && task.Verification != nil {
+		converted.Verification = verificationToProto(*task.VerifiedAt, task.Verification)
+	}
+
+	switch task.Options.Kind {
+	case pathtask.KindDistance:
+		converted.Kind = wikigraphpb.TaskKind_DISTANCE
+
+	case pathtask.KindNeighborhood:
+		converted.Kind = wikigraphpb.TaskKind_NEIGHBORHOOD
+
+	default:
+		converted.Kind = wikigraphpb.TaskKind_PATH
+	}
+
+	switch task.Options.Mode {
+	case pathtask.ModeBestFirst:
+		converted.Mode = wikigraphpb.SearchMode_BEST_FIRST
+
+	default:
+		converted.Mode = wikigraphpb.SearchMode_BFS
+	}
+
+	switch task.Priority {
+	case pathtask.PriorityBatch:
+		converted.Priority = wikigraphpb.Priority_BATCH
+
+	case pathtask.PriorityInteractive:
+		converted.Priority = wikigraphpb.Priority_INTERACTIVE
+
+	default:
+		converted.Priority = wikigraphpb.Priority_NORMAL
 	}
 
 	switch task.Status {
@@ -107,10 +395,17 @@ func (s *Server) taskToProto(task *pathtask.Task) *wikigraphpb.Task {
 
 	case pathtask.StatusProcessing:
 		converted.Status = wikigraphpb.Task_PROCESSING
+		converted.Progress = progressToProto(task.Progress)
 
 	case pathtask.StatusDone:
 		converted.Status = wikigraphpb.Task_DONE
 
+	case pathtask.StatusFailed:
+		converted.Status = wikigraphpb.Task_FAILED
+		if task.Result != nil {
+			converted.Error = task.Result.Error
+		}
+
 	default:
 		zlog.Error().Fields(map[string]interface{}{
 			"id":     task.ID.String(),
@@ -120,3 +415,160 @@ func (s *Server) taskToProto(task *pathtask.Task) *wikigraphpb.Task {
 
 	return converted
 }
+
+func progressToProto(progress *pathtask.Progress) *wikigraphpb.TaskProgress {
+	if progress == nil {
+		return nil
+	}
+
+	return &wikigraphpb.TaskProgress{
+		UpdatedAt:                 timestamppb.New(progress.UpdatedAt),
+		Distance:                  uint32(progress.Distance),
+		MaxDistance:               uint32(progress.MaxDistance),
+		FrontierSize:              int64(progress.FrontierSize),
+		LayerPagesFetched:         int64(progress.LayerPagesFetched),
+		PagesFetched:              int64(progress.PagesFetched),
+		EstimatedRemainingFetches: int64(progress.EstimatedRemainingFetches),
+	}
+}
+
+func statsToProto(stats *pathtask.Stats) *wikigraphpb.TaskStats {
+	if stats == nil {
+		return nil
+	}
+
+	converted := &wikigraphpb.TaskStats{
+		PagesFetched:   int64(stats.PagesFetched),
+		FetchErrors:    int64(stats.FetchErrors),
+		ApiRequests:    stats.APIRequests,
+		SkippedFetches: int64(stats.SkippedFetches),
+		Elapsed:        durationpb.New(time.Duration(stats.ElapsedMs) * time.Millisecond),
+	}
+
+	for _, layer := range stats.Layers {
+		converted.Layers = append(converted.Layers, &wikigraphpb.TaskStats_Layer{
+			Distance:     uint32(layer.Distance),
+			FrontierSize: int64(layer.FrontierSize),
+			PagesFetched: int64(layer.PagesFetched),
+			FetchErrors:  int64(layer.FetchErrors),
+			Discovered:   int64(layer.Discovered),
+			Elapsed:      durationpb.New(time.Duration(layer.ElapsedMs) * time.Millisecond),
+			FailedPages:  int64(layer.FailedPages),
+			Direction:    layer.Direction,
+		})
+	}
+
+	return converted
+}
+
+func verificationToProto(verifiedAt time.Time, verification *pathtask.Verification) *wikigraphpb.PathVerification {
+	converted := &wikigraphpb.PathVerification{
+		VerifiedAt: timestamppb.New(verifiedAt),
+		Valid:      verification.Valid,
+	}
+
+	for _, hop := range verification.BrokenHops {
+		converted.BrokenHops = append(converted.BrokenHops, &wikigraphpb.Hop{From: hop.From, To: hop.To})
+	}
+
+	if verification.RerunTaskID != nil {
+		converted.RerunTaskId = &wikigraphpb.TaskId{Id: verification.RerunTaskID.String()}
+	}
+
+	return converted
+}
+
+// cachedDistanceToProto converts the cached distance to the result of a query limited by maxDistance, 0 means no limit.
+func cachedDistanceToProto(cached *distcache.Distance, maxDistance uint32) *wikigraphpb.DistanceResult {
+	result := &wikigraphpb.DistanceResult{
+		SearchedDistance: uint32(cached.SearchedDistance),
+		Cached:           true,
+	}
+	if maxDistance != 0 && maxDistance < result.SearchedDistance {
+		result.SearchedDistance = maxDistance
+	}
+
+	if cached.Distance != nil && uint32(*cached.Distance) <= result.SearchedDistance {
+		result.Reachable = true
+		result.Distance = uint32(*cached.Distance)
+	}
+
+	return result
+}
+
+func priorityFromProto(priority wikigraphpb.Priority) (pathtask.Priority, error) {
+	switch priority {
+	case wikigraphpb.Priority_PRIORITY_UNSPECIFIED, wikigraphpb.Priority_NORMAL:
+		return pathtask.PriorityNormal, nil
+
+	case wikigraphpb.Priority_BATCH:
+		return pathtask.PriorityBatch, nil
+
+	case wikigraphpb.Priority_INTERACTIVE:
+		return pathtask.PriorityInteractive, nil
+
+	default:
+		return 0, errors.Errorf("unknown priority %d", priority)
+	}
+}
+
+func tieBreakFromProto(tieBreak wikigraphpb.TieBreak) (pathtask.TieBreak, error) {
+	switch tieBreak {
+	case wikigraphpb.TieBreak_TIE_BREAK_UNSPECIFIED:
+		return pathtask.TieBreakNone, nil
+
+	case wikigraphpb.TieBreak_PREFER_HUBS:
+		return pathtask.TieBreakPreferHubs, nil
+
+	case wikigraphpb.TieBreak_AVOID_HUBS:
+		return pathtask.TieBreakAvoidHubs, nil
+
+	default:
+		return "", errors.Errorf("unknown tie break %d", tieBreak)
+	}
+}
+
+func modeFromProto(mode wikigraphpb.SearchMode) (pathtask.SearchMode, error) {
+	switch mode {
+	case wikigraphpb.SearchMode_SEARCH_MODE_UNSPECIFIED, wikigraphpb.SearchMode_BFS:
+		return pathtask.ModeBFS, nil
+
+	case wikigraphpb.SearchMode_BEST_FIRST:
+		return pathtask.ModeBestFirst, nil
+
+	default:
+		return "", errors.Errorf("unknown mode %d", mode)
+	}
+}
+
+// callerFromContext identifies the client by its token or by its host. The returned error is a gRPC status.
+func (s *Server) callerFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		values := md.Get(CallerTokenMetadataKey)
+		if len(values) > 0 && values[0] != "" {
+			caller, known := s.config.CallerTokens[values[0]]
+			if !known {
+				return "", status.Error(codes.Unauthenticated, "unknown caller token")
+			}
+
+			return caller, nil
+		}
+	}
+
+	return hostFromContext(ctx), nil
+}
+
+func hostFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,142 @@
+package wikirace
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// hub delivers leaderboards to the subscribers of the races within this process.
+// Only the latest leaderboard is kept for a slow subscriber.
+//
+// Hops made through other server instances and the end of the distance task of the race are not seen
+// by this process, so while a race has subscribers, its leaderboard is rebuilt every refreshInterval
+// and published if the standing has changed.
+type hub struct {
+	refresh         func(raceID uuid.UUID) (*Leaderboard, error)
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan *Leaderboard]struct{}
+
+	// The last published leaderboards and the channels stopping the refreshes of the watched races.
+	latest map[uuid.UUID]*Leaderboard
+	stops  map[uuid.UUID]chan struct{}
+}
+
+func newHub(refresh func(raceID uuid.UUID) (*Leaderboard, error), refreshInterval time.Duration) *hub {
+	return &hub{
+		refresh:         refresh,
+		refreshInterval: refreshInterval,
+		subscribers:     make(map[uuid.UUID]map[chan *Leaderboard]struct{}),
+		latest:          make(map[uuid.UUID]*Leaderboard),
+		stops:           make(map[uuid.UUID]chan struct{}),
+	}
+}
+
+func (h *hub) subscribe(raceID uuid.UUID) (updates <-chan *Leaderboard, unsubscribe func()) {
+	ch := make(chan *Leaderboard, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[raceID] == nil {
+		h.subscribers[raceID] = make(map[chan *Leaderboard]struct{})
+
+		stop := make(chan struct{})
+		h.stops[raceID] = stop
+		go h.watch(raceID, stop)
+	}
+	h.subscribers[raceID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subscribers[raceID], ch)
+		if len(h.subscribers[raceID]) == 0 {
+			delete(h.subscribers, raceID)
+			delete(h.latest, raceID)
+
+			close(h.stops[raceID])
+			delete(h.stops, raceID)
+		}
+	}
+}
+
+func (h *hub) publish(raceID uuid.UUID, leaderboard *Leaderboard) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.send(raceID, leaderboard)
+}
+
+// watch rebuilds the leaderboard of the race until stop is closed.
+func (h *hub) watch(raceID uuid.UUID, stop <-chan struct{}) {
+	if h.refresh == nil || h.refreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(h.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		leaderboard, err := h.refresh(raceID)
+		if err != nil {
+			zlog.Error().Err(err).Str("race_id", raceID.String()).Msg("failed to refresh the leaderboard")
+			continue
+		}
+
+		h.mu.Lock()
+		if latest := h.latest[raceID]; latest == nil || standingChanged(latest, leaderboard) {
+			h.send(raceID, leaderboard)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// send delivers the leaderboard to the subscribers of the race. h.mu must be held.
+func (h *hub) send(raceID uuid.UUID, leaderboard *Leaderboard) {
+	if len(h.subscribers[raceID]) == 0 {
+		return
+	}
+
+	h.latest[raceID] = leaderboard
+
+	for ch := range h.subscribers[raceID] {
+		// Drop the stale leaderboard the subscriber hasn't received yet.
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- leaderboard
+	}
+}
+
+// standingChanged reports whether the leaderboards differ in anything but the time they were built.
+func standingChanged(a, b *Leaderboard) bool {
+	if (a.Race.OptimalDistance == nil) != (b.Race.OptimalDistance == nil) || a.Race.Approximate != b.Race.Approximate {
+		return true
+	}
+	if len(a.Entries) != len(b.Entries) {
+		return true
+	}
+
+	for i := range a.Entries {
+		x, y := a.Entries[i].Player, b.Entries[i].Player
+		if x.Name != y.Name || x.Hops() != y.Hops() || (x.FinishedAt == nil) != (y.FinishedAt == nil) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package wikirace
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestHub_PublishesRefreshedStanding(t *testing.T) {
+	raceID := uuid.New()
+	race := &Race{ID: raceID}
+
+	var mu sync.Mutex
+	players := []*Player{{Name: "alice", Path: Pages{"A"}}}
+
+	h := newHub(func(uuid.UUID) (*Leaderboard, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		copied := make([]*Player, len(players))
+		for i, p := range players {
+			player := *p
+			copied[i] = &player
+		}
+
+		return newLeaderboard(race, copied), nil
+	}, time.Millisecond)
+
+	updates, unsubscribe := h.subscribe(raceID)
+	defer unsubscribe()
+
+	first := receive(t, updates)
+	if len(first.Entries) != 1 {
+		t.Fatalf("first leaderboard has %d entries, want 1", len(first.Entries))
+	}
+
+	// The standing hasn't changed, so nothing is published.
+	select {
+	case leaderboard := <-updates:
+		t.Fatalf("unchanged leaderboard was published: %+v", leaderboard)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Another instance accepts a hop.
+	mu.Lock()
+	players = []*Player{{Name: "alice", Path: Pages{"A", "B"}}}
+	mu.Unlock()
+
+	second := receive(t, updates)
+	if hops := second.Entries[0].Player.Hops(); hops != 1 {
+		t.Errorf("refreshed leaderboard has %d hops, want 1", hops)
+	}
+}
+
+func receive(t *testing.T, updates <-chan *Leaderboard) *Leaderboard {
+	t.Helper()
+
+	select {
+	case leaderboard := <-updates:
+		return leaderboard
+	case <-time.After(time.Second):
+		t.Fatal("no leaderboard was published")
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+package wikirace
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Race is a session in which players race from the start page to the target one until EndsAt.
+type Race struct {
+	ID        uuid.UUID `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	EndsAt    time.Time `db:"ends_at"`
+
+	// Caller identifies the client that created the race.
+	Caller string `db:"caller"`
+
+	// Canonical titles of the pages.
+	StartPage  string `db:"start_page"`
+	TargetPage string `db:"target_page"`
+
+	// The task computing the shortest distance between the pages.
+	OptimalTaskID *uuid.UUID `db:"optimal_task_id"`
+
+	// Set when the shortest distance is known. If Approximate, the shortest path is longer
+	// than the search limit, and OptimalDistance is the lower bound of its length.
+	OptimalDistance *int `db:"optimal_distance"`
+	Approximate     bool `db:"approximate"`
+}
+
+// Over reports whether hops are not accepted anymore.
+func (r *Race) Over(now time.Time) bool {
+	return !now.Before(r.EndsAt)
+}
+
+// Player is a participant of a race identified by the name within the race.
+type Player struct {
+	RaceID   uuid.UUID `db:"race_id"`
+	Name     string    `db:"name"`
+	JoinedAt time.Time `db:"joined_at"`
+
+	// Caller identifies the client that joined as the player, only it can reconnect and make hops.
+	Caller string `db:"caller"`
+
+	// Pages the player has clicked through, starting with the start page of the race.
+	Path Pages `db:"path"`
+
+	// Set when the player reaches the target page.
+	FinishedAt *time.Time `db:"finished_at"`
+}
+
+// CurrentPage returns the page the player is on.
+func (p *Player) CurrentPage() string {
+	return p.Path[len(p.Path)-1]
+}
+
+// Hops returns the number of clicks made by the player.
+func (p *Player) Hops() int {
+	return len(p.Path) - 1
+}
+
+// LeaderboardEntry is the standing of a player. Score is set for finished players once the shortest distance is known.
+type LeaderboardEntry struct {
+	Rank   int
+	Player *Player
+	Score  *int
+}
+
+// Leaderboard is the standing of the players of a race.
+type Leaderboard struct {
+	Race    *Race
+	Entries []LeaderboardEntry
+}
+
+// newLeaderboard ranks finished players by the number of clicks and then by the finish time.
+// Players still racing follow them in the order of joining.
+func newLeaderboard(race *Race, players []*Player) *Leaderboard {
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		if (a.FinishedAt != nil) != (b.FinishedAt != nil) {
+			return a.FinishedAt != nil
+		}
+
+		if a.FinishedAt == nil {
+			return a.JoinedAt.Before(b.JoinedAt)
+		}
+
+		if a.Hops() != b.Hops() {
+			return a.Hops() < b.Hops()
+		}
+
+		return a.FinishedAt.Before(*b.FinishedAt)
+	})
+
+	leaderboard := &Leaderboard{Race: race}
+	for i, player := range players {
+		entry := LeaderboardEntry{
+			Rank:   i + 1,
+			Player: player,
+		}
+
+		if player.FinishedAt != nil && race.OptimalDistance != nil {
+			points := score(*race.OptimalDistance, player.Hops())
+			entry.Score = &points
+		}
+
+		leaderboard.Entries = append(leaderboard.Entries, entry)
+	}
+
+	return leaderboard
+}
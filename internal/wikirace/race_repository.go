@@ -0,0 +1,114 @@
+package wikirace
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type RaceRepository interface {
+	CreateRace(race *Race) error
+	GetRace(id uuid.UUID) (*Race, error)
+	SetRaceOptimal(id uuid.UUID, optimalDistance int, approximate bool) error
+
+	// AddPlayer adds the player to the race. If the name is taken, the existing player is returned,
+	// so a player can reconnect to the race. The caller decides whether the existing player may be taken over.
+	AddPlayer(player *Player) (*Player, error)
+	GetPlayer(raceID uuid.UUID, name string) (*Player, error)
+	ListPlayers(raceID uuid.UUID) ([]*Player, error)
+
+	// AppendHop adds the page to the path of the player if the player is still racing and has made
+	// exactly hops clicks. False is returned otherwise. If finishedAt is not nil, the player is finished.
+	AppendHop(raceID uuid.UUID, name string, hops int, page string, finishedAt *time.Time) (bool, error)
+}
+
+type RaceRepo struct {
+	db *sqlx.DB
+}
+
+func NewRaceRepository(db *sqlx.DB) *RaceRepo {
+	return &RaceRepo{db: db}
+}
+
+func (r *RaceRepo) CreateRace(race *Race) error {
+	query := `INSERT INTO "races" (id, created_at, ends_at, caller, start_page, target_page, optimal_task_id, optimal_distance, approximate)
+							VALUES (:id, :created_at, :ends_at, :caller, :start_page, :target_page, :optimal_task_id, :optimal_distance, :approximate)`
+	_, err := r.db.NamedExec(query, race)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func (r *RaceRepo) GetRace(id uuid.UUID) (*Race, error) {
+	race := new(Race)
+	err := r.db.Get(race, `SELECT * FROM "races" WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return race, nil
+}
+
+func (r *RaceRepo) SetRaceOptimal(id uuid.UUID, optimalDistance int, approximate bool) error {
+	_, err := r.db.Exec(`UPDATE "races" SET optimal_distance = $1, approximate = $2 WHERE id = $3`, optimalDistance, approximate, id)
+
+	return err
+}
+
+func (r *RaceRepo) AddPlayer(player *Player) (*Player, error) {
+	query := `INSERT INTO "race_players" (race_id, name, joined_at, caller, path) VALUES (:race_id, :name, :joined_at, :caller, :path)
+							ON CONFLICT (race_id, name) DO NOTHING`
+	_, err := r.db.NamedExec(query, player)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return r.GetPlayer(player.RaceID, player.Name)
+}
+
+func (r *RaceRepo) GetPlayer(raceID uuid.UUID, name string) (*Player, error) {
+	player := new(Player)
+	err := r.db.Get(player, `SELECT * FROM "race_players" WHERE race_id = $1 AND name = $2`, raceID, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return player, nil
+}
+
+func (r *RaceRepo) ListPlayers(raceID uuid.UUID) ([]*Player, error) {
+	var players []*Player
+	err := r.db.Select(&players, `SELECT * FROM "race_players" WHERE race_id = $1`, raceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return players, nil
+}
+
+func (r *RaceRepo) AppendHop(raceID uuid.UUID, name string, hops int, page string, finishedAt *time.Time) (bool, error) {
+	query := `UPDATE "race_players" SET path = path || to_jsonb($1::text), finished_at = $2
+				WHERE race_id = $3 AND name = $4 AND finished_at IS NULL AND jsonb_array_length(path) = $5`
+	result, err := r.db.Exec(query, page, finishedAt, raceID, name, hops+1)
+	if err != nil {
+		return false, errors.Wrap(err, "database error")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "database error")
+	}
+
+	return affected != 0, nil
+}
@@ -0,0 +1,257 @@
+package wikirace
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/distcache"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// MaxPages is the maximum length of a submitted path.
+const MaxPages = 50
+
+// Leaderboards of the races with subscribers are rebuilt this often to catch up with other server instances
+// and with the distance tasks of the races.
+const leaderboardRefreshInterval = 5 * time.Second
+
+// Referee checks submitted paths against the current links and scores them.
+// It also hosts race sessions and broadcasts their leaderboards.
+type Referee struct {
+	submissions Repository
+	races       RaceRepository
+	tasks       pathtask.Repository
+	distances   distcache.Repository
+	producer    *taskqueue.Producer
+	wikiClient  *wikiclient.Client
+
+	// Cached distances older than this are computed again.
+	distanceCacheTTL time.Duration
+
+	// Distance limit of the workers, race distances are taken from the cache only if they were searched this far.
+	distanceThreshold uint
+
+	// Maximum number of unfinished tasks of a caller, distance tasks are not created beyond it. 0 means unlimited.
+	maxUnfinished int
+
+	hub *hub
+}
+
+func NewReferee(
+	submissions Repository,
+	races RaceRepository,
+	tasks pathtask.Repository,
+	distances distcache.Repository,
+	producer *taskqueue.Producer,
+	wikiClient *wikiclient.Client,
+	distanceCacheTTL time.Duration,
+	distanceThreshold uint,
+	maxUnfinished int,
+) *Referee {
+	r := &Referee{
+		submissions:       submissions,
+		races:             races,
+		tasks:             tasks,
+		distances:         distances,
+		producer:          producer,
+		wikiClient:        wikiClient,
+		distanceCacheTTL:  distanceCacheTTL,
+		distanceThreshold: distanceThreshold,
+		maxUnfinished:     maxUnfinished,
+	}
+	r.hub = newHub(r.GetRace, leaderboardRefreshInterval)
+
+	return r
+}
+
+// Submit checks every hop of the path and saves the submission. Valid paths are scored right away
+// if the shortest distance is cached. Otherwise, a distance task with the given priority is enqueued,
+// and the submission is scored by Get once the task is done. pathtask.ErrTooManyUnfinished is returned
+// if the caller already has too many unfinished tasks.
+func (r *Referee) Submit(ctx context.Context, caller string, pages []string, priority pathtask.Priority) (*Submission, error) {
+	submission := &Submission{
+		ID:        uuid.New(),
+		CreatedAt: time.Now(),
+		Caller:    caller,
+	}
+
+	var err error
+	submission.Pages, submission.InvalidHop, err = r.checkPath(ctx, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.Valid() {
+		err = r.findOptimal(submission, priority)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		invalid := 0
+		submission.Score = &invalid
+	}
+
+	err = r.submissions.Create(submission)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save the submission")
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"id":     submission.ID.String(),
+		"caller": caller,
+		"hops":   submission.Hops(),
+		"valid":  submission.Valid(),
+	}).Msg("path submitted")
+
+	return submission, nil
+}
+
+// Get returns the submission and scores it if its distance task is done.
+func (r *Referee) Get(id uuid.UUID) (*Submission, error) {
+	submission, err := r.submissions.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.Score != nil || submission.OptimalTaskID == nil {
+		return submission, nil
+	}
+
+	task, err := r.tasks.Get(*submission.OptimalTaskID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the distance task")
+	}
+
+	if task.Status != pathtask.StatusDone || task.Result == nil {
+		return submission, nil
+	}
+
+	r.setOptimal(submission, task.Result.Distance, int(task.Result.SearchedDistance))
+
+	err = r.submissions.SetScore(submission.ID, *submission.OptimalDistance, submission.Approximate, *submission.Score)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save the score")
+	}
+
+	return submission, nil
+}
+
+// checkPath canonicalizes the pages and returns the index of the first page that doesn't link to the next one.
+// Pages after the invalid hop are returned as is.
+func (r *Referee) checkPath(ctx context.Context, pages []string) (canonical []string, invalidHop *int, err error) {
+	canonical = append([]string(nil), pages...)
+
+	for i := range canonical {
+		title, err := r.wikiClient.Canonicalize(ctx, canonical[i])
+		if errors.Is(err, wikiclient.ErrPageNotFound) {
+			hop := i - 1
+			if hop < 0 {
+				hop = 0
+			}
+
+			return canonical, &hop, nil
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to canonicalize %s", canonical[i])
+		}
+		canonical[i] = title
+
+		if i == 0 {
+			continue
+		}
+
+		linked, err := r.links(ctx, canonical[i-1], canonical[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !linked {
+			hop := i - 1
+			return canonical, &hop, nil
+		}
+	}
+
+	return canonical, nil, nil
+}
+
+// links reports whether the page links to the target or to any of its redirects.
+func (r *Referee) links(ctx context.Context, from, to string) (bool, error) {
+	links, err := r.wikiClient.GetMentionedPages(ctx, from)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to fetch links of %s", from)
+	}
+
+	if wikiclient.ContainsTitle(links, to) {
+		return true, nil
+	}
+
+	redirects, err := r.wikiClient.GetRedirects(ctx, to)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to fetch redirects to %s", to)
+	}
+
+	for _, redirect := range redirects {
+		if wikiclient.ContainsTitle(links, redirect) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// findOptimal scores the submission with the cached distance, or enqueues a task to compute it.
+// The task only looks for paths shorter than the submitted one.
+func (r *Referee) findOptimal(submission *Submission, priority pathtask.Priority) error {
+	from, to := submission.Pages[0], submission.Pages[len(submission.Pages)-1]
+	if submission.Hops() <= 1 {
+		r.setOptimal(submission, nil, submission.Hops())
+		return nil
+	}
+
+	cached, err := r.distances.Get(from, to)
+	if err != nil && !errors.Is(err, distcache.ErrNotFound) {
+		zlog.Error().Err(err).Str("from", from).Str("to", to).Msg("failed to get cached distance")
+	}
+	if err == nil && time.Since(cached.ComputedAt) < r.distanceCacheTTL {
+		r.setOptimal(submission, cached.Distance, cached.SearchedDistance)
+		return nil
+	}
+
+	task, err := taskqueue.Enqueue(r.tasks, r.producer, from, to, submission.Caller, priority, pathtask.Options{
+		Kind:        pathtask.KindDistance,
+		MaxDistance: uint(submission.Hops() - 1),
+	}, r.maxUnfinished)
+	if err != nil {
+		return err
+	}
+
+	submission.OptimalTaskID = &task.ID
+
+	return nil
+}
+
+// setOptimal scores the submission by the result of a distance search limited by searched.
+// The submitted path is optimal if the search found nothing shorter. If the search didn't reach
+// the length of the submitted path, the shortest distance is only known to be more than searched.
+func (r *Referee) setOptimal(submission *Submission, distance *int, searched int) {
+	hops := submission.Hops()
+
+	optimal, approximate := hops, false
+	switch {
+	case distance != nil && *distance < hops:
+		optimal = *distance
+
+	case distance == nil && searched+1 < hops:
+		optimal, approximate = searched+1, true
+	}
+
+	points := score(optimal, hops)
+	submission.OptimalDistance = &optimal
+	submission.Approximate = approximate
+	submission.Score = &points
+}
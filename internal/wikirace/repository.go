@@ -0,0 +1,58 @@
+package wikirace
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type Repository interface {
+	Create(submission *Submission) error
+	Get(id uuid.UUID) (*Submission, error)
+
+	// SetScore saves the shortest distance along with the score.
+	SetScore(id uuid.UUID, optimalDistance int, approximate bool, score int) error
+}
+
+type Repo struct {
+	db *sqlx.DB
+}
+
+func NewRepository(db *sqlx.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Create(submission *Submission) error {
+	query := `INSERT INTO "path_submissions" (id, created_at, caller, pages, invalid_hop, optimal_task_id, optimal_distance, approximate, score)
+							VALUES (:id, :created_at, :caller, :pages, :invalid_hop, :optimal_task_id, :optimal_distance, :approximate, :score)`
+	_, err := r.db.NamedExec(query, submission)
+	if err != nil {
+		return errors.Wrap(err, "database error")
+	}
+
+	return nil
+}
+
+func (r *Repo) Get(id uuid.UUID) (*Submission, error) {
+	submission := new(Submission)
+	err := r.db.Get(submission, `SELECT * FROM "path_submissions" WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "database error")
+	}
+
+	return submission, nil
+}
+
+func (r *Repo) SetScore(id uuid.UUID, optimalDistance int, approximate bool, score int) error {
+	_, err := r.db.Exec(`UPDATE "path_submissions" SET optimal_distance = $1, approximate = $2, score = $3 WHERE id = $4`,
+		optimalDistance, approximate, score, id)
+
+	return err
+}
@@ -0,0 +1,351 @@
+package wikirace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lodthe/wiki-graph/internal/distcache"
+	"github.com/lodthe/wiki-graph/internal/pathtask"
+	"github.com/lodthe/wiki-graph/internal/taskqueue"
+	"github.com/lodthe/wiki-graph/pkg/wikiclient"
+	"github.com/pkg/errors"
+	zlog "github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultRaceDuration is used when the duration of a race is not given.
+	DefaultRaceDuration = 10 * time.Minute
+
+	// MaxRaceDuration is the longest a race can last.
+	MaxRaceDuration = 24 * time.Hour
+
+	// MaxPlayerNameLength is the size of the name column.
+	MaxPlayerNameLength = 256
+)
+
+var (
+	ErrSamePages = errors.New("the start and target pages are the same")
+	ErrRaceOver  = errors.New("the race is over")
+
+	// ErrPlayerTaken is returned when a caller acts on behalf of a player joined by another caller.
+	ErrPlayerTaken = errors.New("the player has joined from another caller")
+)
+
+// RaceOptions describe a new race. Missing pages are picked at random.
+type RaceOptions struct {
+	StartPage  string
+	TargetPage string
+	Duration   time.Duration
+}
+
+// HopResult tells whether a click was accepted and the state of the player after it.
+type HopResult struct {
+	Accepted bool
+
+	// Explains why the hop was rejected.
+	Reason string
+
+	Player *Player
+}
+
+// CreateRace saves a new race and computes the shortest distance between its pages,
+// so that finished players can be scored. pathtask.ErrTooManyUnfinished is returned if the distance
+// has to be computed and the caller already has too many unfinished tasks.
+func (r *Referee) CreateRace(ctx context.Context, caller string, options RaceOptions) (*Race, error) {
+	duration := options.Duration
+	if duration <= 0 {
+		duration = DefaultRaceDuration
+	}
+	if duration > MaxRaceDuration {
+		duration = MaxRaceDuration
+	}
+
+	start, target, err := r.racePages(ctx, options.StartPage, options.TargetPage)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	race := &Race{
+		ID:         uuid.New(),
+		CreatedAt:  now,
+		EndsAt:     now.Add(duration),
+		Caller:     caller,
+		StartPage:  start,
+		TargetPage: target,
+	}
+
+	err = r.findRaceOptimal(race)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.races.CreateRace(race)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save the race")
+	}
+
+	zlog.Info().Fields(map[string]interface{}{
+		"id":      race.ID.String(),
+		"caller":  caller,
+		"start":   start,
+		"target":  target,
+		"ends_at": race.EndsAt,
+	}).Msg("race created")
+
+	return race, nil
+}
+
+// GetRace returns the race along with its leaderboard.
+func (r *Referee) GetRace(raceID uuid.UUID) (*Leaderboard, error) {
+	race, err := r.races.GetRace(raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.resolveRaceOptimal(race)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := r.races.ListPlayers(raceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the players")
+	}
+
+	return newLeaderboard(race, players), nil
+}
+
+// Join adds the player to the race, or returns the player's state if the same caller has already joined as the player.
+// Players can't join a race that is over, and names taken by other callers are rejected with ErrPlayerTaken.
+func (r *Referee) Join(raceID uuid.UUID, caller, name string) (*Race, *Player, error) {
+	race, err := r.races.GetRace(raceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if race.Over(time.Now()) {
+		return nil, nil, ErrRaceOver
+	}
+
+	player, err := r.races.AddPlayer(&Player{
+		RaceID:   raceID,
+		Name:     name,
+		JoinedAt: time.Now(),
+		Caller:   caller,
+		Path:     Pages{race.StartPage},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to add the player")
+	}
+	if player.Caller != caller {
+		return nil, nil, ErrPlayerTaken
+	}
+
+	zlog.Info().Str("race_id", raceID.String()).Str("player", name).Msg("player joined the race")
+
+	r.publish(raceID)
+
+	return race, player, nil
+}
+
+// Hop moves the player to the page if the current page of the player links to it.
+// The player finishes the race upon reaching the target page. Only the caller that joined as the player can move it.
+func (r *Referee) Hop(ctx context.Context, raceID uuid.UUID, caller, name, page string) (*HopResult, error) {
+	race, err := r.races.GetRace(raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := r.races.GetPlayer(raceID, name)
+	if err != nil {
+		return nil, err
+	}
+	if player.Caller != caller {
+		return nil, ErrPlayerTaken
+	}
+
+	now := time.Now()
+	switch {
+	case player.FinishedAt != nil:
+		return &HopResult{Reason: "the player has already finished", Player: player}, nil
+
+	case race.Over(now):
+		return &HopResult{Reason: ErrRaceOver.Error(), Player: player}, nil
+
+	case len(player.Path) >= MaxPages:
+		return &HopResult{Reason: "the path is too long", Player: player}, nil
+	}
+
+	canonical, err := r.wikiClient.Canonicalize(ctx, page)
+	if errors.Is(err, wikiclient.ErrPageNotFound) {
+		return &HopResult{Reason: page + " does not exist", Player: player}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to canonicalize %s", page)
+	}
+
+	current := player.CurrentPage()
+	linked, err := r.links(ctx, current, canonical)
+	if err != nil {
+		return nil, err
+	}
+	if !linked {
+		return &HopResult{Reason: current + " does not link to " + canonical, Player: player}, nil
+	}
+
+	var finishedAt *time.Time
+	if strings.EqualFold(canonical, race.TargetPage) {
+		finishedAt = &now
+	}
+
+	appended, err := r.races.AppendHop(raceID, name, player.Hops(), canonical, finishedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save the hop")
+	}
+	if !appended {
+		// Another connection of the same player has moved first.
+		player, err = r.races.GetPlayer(raceID, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &HopResult{Reason: "the player has moved from " + current, Player: player}, nil
+	}
+
+	player.Path = append(player.Path, canonical)
+	player.FinishedAt = finishedAt
+
+	zlog.Info().Fields(map[string]interface{}{
+		"race_id":  raceID.String(),
+		"player":   name,
+		"page":     canonical,
+		"hops":     player.Hops(),
+		"finished": finishedAt != nil,
+	}).Msg("race hop accepted")
+
+	r.publish(raceID)
+
+	return &HopResult{Accepted: true, Player: player}, nil
+}
+
+// Subscribe returns a channel of leaderboards that are published whenever a player joins the race
+// or makes a hop through this referee. Changes made elsewhere, e.g. through other server instances,
+// are published within leaderboardRefreshInterval. Only the latest leaderboard is kept until it's received.
+func (r *Referee) Subscribe(raceID uuid.UUID) (updates <-chan *Leaderboard, unsubscribe func()) {
+	return r.hub.subscribe(raceID)
+}
+
+func (r *Referee) publish(raceID uuid.UUID) {
+	leaderboard, err := r.GetRace(raceID)
+	if err != nil {
+		zlog.Error().Err(err).Str("race_id", raceID.String()).Msg("failed to build the leaderboard")
+		return
+	}
+
+	r.hub.publish(raceID, leaderboard)
+}
+
+// racePages canonicalizes the given pages and picks random articles for the missing ones.
+func (r *Referee) racePages(ctx context.Context, start, target string) (string, string, error) {
+	var err error
+	for _, page := range []*string{&start, &target} {
+		if *page == "" {
+			continue
+		}
+
+		title := *page
+		*page, err = r.wikiClient.Canonicalize(ctx, title)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to canonicalize %s", title)
+		}
+	}
+
+	if start == "" || target == "" {
+		random, err := r.wikiClient.GetRandomPages(ctx, 2)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to pick random pages")
+		}
+
+		for _, title := range random {
+			switch {
+			case start == "" && !strings.EqualFold(title, target):
+				start = title
+			case target == "" && !strings.EqualFold(title, start):
+				target = title
+			}
+		}
+
+		if start == "" || target == "" {
+			return "", "", errors.New("not enough random pages")
+		}
+	}
+
+	if strings.EqualFold(start, target) {
+		return "", "", ErrSamePages
+	}
+
+	return start, target, nil
+}
+
+// findRaceOptimal sets the cached distance between the pages, or enqueues a task to compute it.
+func (r *Referee) findRaceOptimal(race *Race) error {
+	cached, err := r.distances.Get(race.StartPage, race.TargetPage)
+	if err != nil && !errors.Is(err, distcache.ErrNotFound) {
+		zlog.Error().Err(err).Str("from", race.StartPage).Str("to", race.TargetPage).Msg("failed to get cached distance")
+	}
+	if err == nil && time.Since(cached.ComputedAt) < r.distanceCacheTTL && cached.Answers(0, int(r.distanceThreshold)) {
+		setRaceOptimal(race, cached.Distance, cached.SearchedDistance)
+		return nil
+	}
+
+	task, err := taskqueue.Enqueue(r.tasks, r.producer, race.StartPage, race.TargetPage, race.Caller, pathtask.PriorityNormal, pathtask.Options{
+		Kind: pathtask.KindDistance,
+	}, r.maxUnfinished)
+	if err != nil {
+		return err
+	}
+
+	race.OptimalTaskID = &task.ID
+
+	return nil
+}
+
+// resolveRaceOptimal sets the shortest distance once the distance task of the race is done.
+func (r *Referee) resolveRaceOptimal(race *Race) error {
+	if race.OptimalDistance != nil || race.OptimalTaskID == nil {
+		return nil
+	}
+
+	task, err := r.tasks.Get(*race.OptimalTaskID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the distance task")
+	}
+
+	if task.Status != pathtask.StatusDone || task.Result == nil {
+		return nil
+	}
+
+	setRaceOptimal(race, task.Result.Distance, int(task.Result.SearchedDistance))
+
+	err = r.races.SetRaceOptimal(race.ID, *race.OptimalDistance, race.Approximate)
+	if err != nil {
+		return errors.Wrap(err, "failed to save the shortest distance")
+	}
+
+	return nil
+}
+
+// setRaceOptimal sets the shortest distance found by a search limited by searched.
+// If nothing was found, the distance is only known to be more than searched.
+func setRaceOptimal(race *Race, distance *int, searched int) {
+	optimal, approximate := searched+1, true
+	if distance != nil {
+		optimal, approximate = *distance, false
+	}
+
+	race.OptimalDistance = &optimal
+	race.Approximate = approximate
+}
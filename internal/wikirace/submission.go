@@ -0,0 +1,75 @@
+// Package wikirace referees Wikipedia races: players submit paths between two pages,
+// and the paths are checked against the current links and scored by the shortest possible path.
+// Players can also race each other in sessions, clicking through the pages one hop at a time.
+package wikirace
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxScore is given for a path as short as the shortest one.
+const MaxScore = 100
+
+type Submission struct {
+	ID        uuid.UUID `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+
+	// Caller identifies the player.
+	Caller string `db:"caller"`
+
+	// Canonical titles of the submitted pages, from the start page to the target one.
+	Pages Pages `db:"pages"`
+
+	// Index of the first page that doesn't link to the next one, nil if the path is valid.
+	InvalidHop *int `db:"invalid_hop"`
+
+	// The task computing the shortest distance, nil if the distance was cached or the path is invalid.
+	OptimalTaskID *uuid.UUID `db:"optimal_task_id"`
+
+	// Set when the shortest distance is known. If Approximate, the shortest path is longer
+	// than the search limit, and OptimalDistance is the lower bound of its length.
+	OptimalDistance *int `db:"optimal_distance"`
+	Approximate     bool `db:"approximate"`
+
+	// Set when the submission is scored. Invalid paths score 0.
+	Score *int `db:"score"`
+}
+
+// Valid reports whether every page of the path links to the next one.
+func (s *Submission) Valid() bool {
+	return s.InvalidHop == nil
+}
+
+// Hops returns the number of clicks of the path.
+func (s *Submission) Hops() int {
+	return len(s.Pages) - 1
+}
+
+// score gives MaxScore for a path as short as the optimal one, and proportionally less for longer ones.
+func score(optimal, hops int) int {
+	if hops <= 0 || optimal >= hops {
+		return MaxScore
+	}
+
+	return MaxScore * optimal / hops
+}
+
+type Pages []string
+
+func (p Pages) Value() (driver.Value, error) {
+	return json.Marshal([]string(p))
+}
+
+func (p *Pages) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("value cannot be converted to []byte")
+	}
+
+	return json.Unmarshal(b, (*[]string)(p))
+}
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS revision_links;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS options;
+
+COMMIT;
\ No newline at end of file
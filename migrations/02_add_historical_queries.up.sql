@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS options jsonb default '{}'::jsonb not null;
+
+CREATE TABLE IF NOT EXISTS revision_links (
+      revision_id bigint primary key not null,
+      created_at timestamp without time zone default now() not null,
+
+      page_title varchar(512) not null,
+      links jsonb not null
+);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS page_links;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS page_links (
+      page_title varchar(512) primary key not null,
+      fetched_at timestamp without time zone default now() not null,
+
+      links jsonb not null
+);
+
+CREATE INDEX IF NOT EXISTS page_links_fetched_at_idx ON page_links USING btree(fetched_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS task_checkpoints (
+      task_id varchar(64) primary key not null,
+      updated_at timestamp without time zone default now() not null,
+
+      data bytea not null
+);
+
+COMMIT;
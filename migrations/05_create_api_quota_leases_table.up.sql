@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS api_quota_leases (
+      worker_id varchar(64) primary key not null,
+      rps integer not null,
+      expires_at timestamp without time zone not null
+);
+
+COMMIT;
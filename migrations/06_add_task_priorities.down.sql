@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tasks_caller_status_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS caller;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
+
+COMMIT;
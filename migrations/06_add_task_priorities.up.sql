@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS priority smallint default 2 not null;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS caller varchar(256) default '' not null;
+
+CREATE INDEX IF NOT EXISTS tasks_caller_status_idx ON tasks USING btree(caller, status);
+
+COMMIT;
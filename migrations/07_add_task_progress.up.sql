@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS progress jsonb;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS page_distances (
+      from_page varchar(512) not null,
+      to_page varchar(512) not null,
+      computed_at timestamp without time zone default now() not null,
+
+      distance integer,
+      searched_distance integer not null,
+
+      primary key (from_page, to_page)
+);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS tasks_status_verified_at_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS verification;
+ALTER TABLE tasks DROP COLUMN IF EXISTS verified_at;
+
+COMMIT;
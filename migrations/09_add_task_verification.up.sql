@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS verified_at timestamp without time zone;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS verification jsonb;
+
+CREATE INDEX IF NOT EXISTS tasks_status_verified_at_idx ON tasks USING btree(status, verified_at);
+
+COMMIT;
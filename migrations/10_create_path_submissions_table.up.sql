@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS path_submissions (
+      id varchar(64) primary key not null,
+      created_at timestamp without time zone default now() not null,
+      caller varchar(256) default '' not null,
+
+      pages jsonb not null,
+      invalid_hop integer,
+
+      optimal_task_id varchar(64),
+      optimal_distance integer,
+      approximate boolean default false not null,
+      score integer
+);
+
+CREATE INDEX IF NOT EXISTS path_submissions_created_at_idx ON path_submissions USING btree(created_at);
+
+COMMIT;
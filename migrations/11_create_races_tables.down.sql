@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS race_players;
+DROP TABLE IF EXISTS races;
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS races (
+      id varchar(64) primary key not null,
+      created_at timestamp without time zone default now() not null,
+      ends_at timestamp without time zone not null,
+      caller varchar(256) default '' not null,
+
+      start_page varchar(512) not null,
+      target_page varchar(512) not null,
+
+      optimal_task_id varchar(64),
+      optimal_distance integer,
+      approximate boolean default false not null
+);
+
+CREATE TABLE IF NOT EXISTS race_players (
+      race_id varchar(64) not null references races (id) on delete cascade,
+      name varchar(256) not null,
+      joined_at timestamp without time zone default now() not null,
+
+      path jsonb not null,
+      finished_at timestamp without time zone,
+
+      primary key (race_id, name)
+);
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sampling_runs (
+      id varchar(64) primary key not null,
+      created_at timestamp without time zone default now() not null,
+      finished_at timestamp without time zone,
+      caller varchar(256) default '' not null,
+
+      pairs integer not null,
+      max_distance integer default 0 not null,
+      task_ids jsonb not null,
+
+      stats jsonb
+);
+
+CREATE INDEX IF NOT EXISTS sampling_runs_created_at_idx ON sampling_runs USING btree(created_at);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS page_hub_rankings (
+      page_key varchar(512) primary key not null,
+      page_title varchar(512) not null,
+
+      rank integer not null,
+      score double precision not null,
+
+      pagerank double precision not null,
+      in_degree integer not null,
+      betweenness double precision not null,
+
+      computed_at timestamp without time zone default now() not null
+);
+
+CREATE INDEX IF NOT EXISTS page_hub_rankings_rank_idx ON page_hub_rankings USING btree(rank);
+
+COMMIT;
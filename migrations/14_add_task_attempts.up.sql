@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS attempts integer NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE race_players DROP COLUMN IF EXISTS caller;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE race_players ADD COLUMN IF NOT EXISTS caller varchar(256) NOT NULL DEFAULT '';
+
+COMMIT;
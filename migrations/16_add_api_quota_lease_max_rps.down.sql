@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE api_quota_leases DROP COLUMN IF EXISTS max_rps;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE api_quota_leases ADD COLUMN IF NOT EXISTS max_rps integer NOT NULL DEFAULT 0;
+
+COMMIT;
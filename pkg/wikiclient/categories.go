@@ -0,0 +1,75 @@
+package wikiclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// GetCategories returns titles of the categories the page belongs to.
+// Hidden (maintenance) categories are not included.
+func (c *Client) GetCategories(ctx context.Context, pageTitle string) (titles []string, err error) {
+	var cursor *string
+	for {
+		newBatch, nextCursor, err := c.getCategories(ctx, pageTitle, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		titles = append(titles, newBatch...)
+
+		cursor = nextCursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return titles, nil
+}
+
+func (c *Client) getCategories(ctx context.Context, title string, cursor *string) (titles []string, nextCursor *string, err error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("prop", "categories")
+	params.Add("clshow", "!hidden")
+	params.Add("cllimit", "max")
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("titles", title)
+	if cursor != nil {
+		params.Add("clcontinue", *cursor)
+	}
+
+	type Response struct {
+		Continue *struct {
+			Clcontinue string `json:"clcontinue"`
+			Continue   string `json:"continue"`
+		} `json:"continue"`
+		Query struct {
+			Pages []struct {
+				Title      string `json:"title"`
+				Categories []struct {
+					Ns    int    `json:"ns"`
+					Title string `json:"title"`
+				} `json:"categories"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err = c.query(ctx, params, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, page := range response.Query.Pages {
+		for _, category := range page.Categories {
+			titles = append(titles, category.Title)
+		}
+	}
+
+	if response.Continue != nil {
+		nextCursor = &response.Continue.Clcontinue
+	}
+
+	return titles, nextCursor, nil
+}
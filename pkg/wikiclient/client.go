@@ -1,6 +1,7 @@
 package wikiclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,15 +9,17 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	"go.uber.org/ratelimit"
 )
 
 const EnglishWikipediaURL = `https://en.wikipedia.org/w/api.php`
 const MaxRPS = 50
 
+// ErrPageNotFound is returned when the requested page does not exist.
+var ErrPageNotFound = errors.New("page not found")
+
 type Client struct {
 	apiURL  string
-	limiter ratelimit.Limiter
+	limiter *limiter
 
 	httpCli *http.Client
 }
@@ -25,7 +28,7 @@ func New(apiURL string, maxRPS int, httpCli ...*http.Client) *Client {
 	client := &Client{
 		httpCli: http.DefaultClient,
 		apiURL:  apiURL,
-		limiter: ratelimit.New(maxRPS),
+		limiter: newLimiter(maxRPS),
 	}
 
 	if len(httpCli) == 1 {
@@ -35,10 +38,16 @@ func New(apiURL string, maxRPS int, httpCli ...*http.Client) *Client {
 	return client
 }
 
-func (c *Client) GetMentionedPages(pageTitle string) (titles []string, err error) {
+// SetMaxRPS changes the rate limit of the client.
+// If maxRPS is not positive, requests are paused until a positive limit is set.
+func (c *Client) SetMaxRPS(maxRPS int) {
+	c.limiter.set(maxRPS)
+}
+
+func (c *Client) GetMentionedPages(ctx context.Context, pageTitle string) (titles []string, err error) {
 	var cursor *string
 	for {
-		newBatch, nextCursor, err := c.getLinks(pageTitle, cursor)
+		newBatch, nextCursor, err := c.getLinks(ctx, pageTitle, cursor)
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +63,7 @@ func (c *Client) GetMentionedPages(pageTitle string) (titles []string, err error
 	return titles, nil
 }
 
-func (c *Client) getLinks(title string, cursor *string) (titles []string, nextCursor *string, err error) {
+func (c *Client) getLinks(ctx context.Context, title string, cursor *string) (titles []string, nextCursor *string, err error) {
 	params := url.Values{}
 	params.Add("action", "query")
 	params.Add("prop", "links")
@@ -65,15 +74,6 @@ func (c *Client) getLinks(title string, cursor *string) (titles []string, nextCu
 		params.Add("plcontinue", *cursor)
 	}
 
-	c.limiter.Take()
-
-	resp, err := c.httpCli.Get(fmt.Sprintf("%s?%s", c.apiURL, params.Encode()))
-	if err != nil {
-		time.Sleep(time.Second)
-		return nil, nil, err
-	}
-	defer resp.Body.Close()
-
 	type Response struct {
 		Continue *struct {
 			Plcontinue string `json:"plcontinue"`
@@ -97,9 +97,9 @@ func (c *Client) getLinks(title string, cursor *string) (titles []string, nextCu
 	}
 
 	var response Response
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err = c.query(ctx, params, &response)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "decode failed")
+		return nil, nil, err
 	}
 
 	if len(response.Query.Pages) == 0 {
@@ -122,3 +122,36 @@ func (c *Client) getLinks(title string, cursor *string) (titles []string, nextCu
 
 	return titles, nextCursor, nil
 }
+
+// query sends a rate limited request to the API and decodes the JSON response into dst.
+// The request is not sent if ctx is cancelled while waiting for the limiter.
+func (c *Client) query(ctx context.Context, params url.Values, dst interface{}) error {
+	err := c.limiter.take(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", c.apiURL, params.Encode()), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to build request")
+	}
+
+	RequestCounterFromContext(ctx).Add(1)
+
+	resp, err := c.httpCli.Do(req)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		time.Sleep(time.Second)
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(dst)
+	if err != nil {
+		return errors.Wrap(err, "decode failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package wikiclient
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type counterKey struct{}
+
+// RequestCounter counts API requests sent on behalf of a single operation, e.g. a search.
+type RequestCounter struct {
+	count int64
+}
+
+// WithRequestCounter returns a context that counts requests sent with it to the counter.
+func WithRequestCounter(ctx context.Context, counter *RequestCounter) context.Context {
+	return context.WithValue(ctx, counterKey{}, counter)
+}
+
+// RequestCounterFromContext returns the counter attached to the context or nil.
+func RequestCounterFromContext(ctx context.Context) *RequestCounter {
+	counter, _ := ctx.Value(counterKey{}).(*RequestCounter)
+	return counter
+}
+
+// Add counts requests sent elsewhere, e.g. by other workers. A nil counter ignores them.
+func (c *RequestCounter) Add(n int64) {
+	if c == nil {
+		return
+	}
+
+	atomic.AddInt64(&c.count, n)
+}
+
+func (c *RequestCounter) Count() int64 {
+	if c == nil {
+		return 0
+	}
+
+	return atomic.LoadInt64(&c.count)
+}
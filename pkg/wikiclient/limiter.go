@@ -0,0 +1,71 @@
+package wikiclient
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/ratelimit"
+)
+
+// limiter is a rate limiter whose rate can be changed on the fly.
+type limiter struct {
+	mu      sync.Mutex
+	current ratelimit.Limiter
+	rps     int
+
+	// Closed when requests are resumed after a pause.
+	resumed chan struct{}
+}
+
+func newLimiter(rps int) *limiter {
+	l := &limiter{resumed: make(chan struct{})}
+	l.set(rps)
+
+	return l
+}
+
+// take waits for the next request slot. ctx is checked after waiting,
+// so a cancelled request doesn't reach the API.
+func (l *limiter) take(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		current, resumed := l.current, l.resumed
+		l.mu.Unlock()
+
+		if current != nil {
+			current.Take()
+			return ctx.Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-resumed:
+		}
+	}
+}
+
+// set changes the rate. Non-positive rps pauses requests until a positive rate is set.
+func (l *limiter) set(rps int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Leases are renewed with the same rate most of the time, a new limiter would lose the pacing.
+	if rps == l.rps && (rps <= 0) == (l.current == nil) {
+		return
+	}
+	l.rps = rps
+
+	if rps <= 0 {
+		if l.current != nil {
+			l.current = nil
+			l.resumed = make(chan struct{})
+		}
+
+		return
+	}
+
+	l.current = ratelimit.New(rps)
+	close(l.resumed)
+	l.resumed = make(chan struct{})
+}
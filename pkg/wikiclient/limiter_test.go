@@ -0,0 +1,66 @@
+package wikiclient
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter_PausesAndResumes(t *testing.T) {
+	l := newLimiter(0)
+
+	taken := make(chan error, 1)
+	go func() {
+		taken <- l.take(context.Background())
+	}()
+
+	select {
+	case err := <-taken:
+		t.Fatalf("request passed a paused limiter: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	l.set(1000)
+
+	select {
+	case err := <-taken:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("request was not resumed")
+	}
+
+	// Pausing again blocks new requests, and cancelled requests don't wait for the resume.
+	l.set(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		taken <- l.take(ctx)
+	}()
+	cancel()
+
+	select {
+	case err := <-taken:
+		if err == nil {
+			t.Fatal("cancelled request passed a paused limiter")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelled request was not released")
+	}
+}
+
+func TestLimiter_KeepsPacingOnUnchangedRate(t *testing.T) {
+	l := newLimiter(10)
+	current := l.current
+
+	l.set(10)
+	if l.current != current {
+		t.Error("the limiter was replaced for an unchanged rate")
+	}
+
+	l.set(20)
+	if l.current == current {
+		t.Error("the limiter was not replaced for a new rate")
+	}
+}
@@ -0,0 +1,131 @@
+package wikiclient
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	wikitextRefRegexp       = regexp.MustCompile(`(?s)<ref[^>/]*/>|<ref[^>/]*>.*?</ref>`)
+	wikitextTemplateRegexp  = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+	wikitextPipedLinkRegexp = regexp.MustCompile(`\[\[[^\[\]|]*\|([^\[\]]*)\]\]`)
+	wikitextLinkRegexp      = regexp.MustCompile(`\[\[([^\[\]|]*)\]\]`)
+	wikitextExternalRegexp  = regexp.MustCompile(`\[https?://[^\s\]]+ ?([^\]]*)\]`)
+	wikitextTagRegexp       = regexp.MustCompile(`<[^>]+>`)
+	wikitextHeadingRegexp   = regexp.MustCompile(`(?m)^(=+)\s*(.*?)\s*=+\s*$`)
+)
+
+// linkMarker marks the position of the link while the text around it is converted to plain text.
+const linkMarker = "\x00"
+
+// LinkContext describes where a page links to another one.
+type LinkContext struct {
+	// Heading of the section containing the link, empty for the lead section.
+	Section string
+
+	// The sentence containing the link converted to plain text.
+	Sentence string
+}
+
+// FindLinkContext finds the first link to any of the targets in the wikitext and returns the sentence containing it.
+// False is returned if the wikitext doesn't link to the targets, e.g. the link comes from a template.
+func FindLinkContext(text string, targets ...string) (*LinkContext, bool) {
+	text = stripWikitextComments(text)
+
+	wanted := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		title, ok := normalizeLinkTarget(target)
+		if ok {
+			wanted[title] = struct{}{}
+		}
+	}
+
+	offset := -1
+	scanLinks(text, func(title string, linkOffset int) bool {
+		if _, ok := wanted[title]; ok {
+			offset = linkOffset
+			return false
+		}
+
+		return true
+	})
+	if offset == -1 {
+		return nil, false
+	}
+
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	lineEnd := strings.IndexByte(text[offset:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += offset
+	}
+
+	line := text[lineStart:offset] + linkMarker + text[offset:lineEnd]
+
+	return &LinkContext{
+		Section:  sectionAt(text, offset),
+		Sentence: sentenceAtMarker(plainText(line)),
+	}, true
+}
+
+// sectionAt returns the heading of the section containing the offset.
+func sectionAt(text string, offset int) string {
+	var section string
+	for _, match := range wikitextHeadingRegexp.FindAllStringSubmatchIndex(text[:offset], -1) {
+		section = plainText(text[match[4]:match[5]])
+	}
+
+	return section
+}
+
+// plainText removes the markup: references, templates, formatting and tags. Links are replaced with their text.
+func plainText(text string) string {
+	text = wikitextRefRegexp.ReplaceAllString(text, "")
+
+	// Templates and links may be nested, so the innermost ones are replaced until nothing changes.
+	for {
+		replaced := wikitextTemplateRegexp.ReplaceAllString(text, "")
+		replaced = wikitextPipedLinkRegexp.ReplaceAllString(replaced, "$1")
+		replaced = wikitextLinkRegexp.ReplaceAllString(replaced, "$1")
+		if replaced == text {
+			break
+		}
+
+		text = replaced
+	}
+
+	text = wikitextExternalRegexp.ReplaceAllString(text, "$1")
+	text = wikitextTagRegexp.ReplaceAllString(text, "")
+	text = strings.ReplaceAll(text, "'''", "")
+	text = strings.ReplaceAll(text, "''", "")
+
+	// List items, table cells and template parameters.
+	text = strings.TrimLeft(text, "*#:;|!= ")
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// sentenceAtMarker returns the sentence containing the marker without the marker.
+func sentenceAtMarker(text string) string {
+	marker := strings.Index(text, linkMarker)
+	if marker == -1 {
+		return text
+	}
+
+	start := 0
+	for _, end := range []string{". ", "! ", "? "} {
+		if i := strings.LastIndex(text[:marker], end); i != -1 && i+len(end) > start {
+			start = i + len(end)
+		}
+	}
+
+	finish := len(text)
+	for _, end := range []string{". ", "! ", "? "} {
+		if i := strings.Index(text[marker:], end); i != -1 && marker+i+1 < finish {
+			finish = marker + i + 1
+		}
+	}
+
+	return strings.TrimSpace(strings.Replace(text[start:finish], linkMarker, "", 1))
+}
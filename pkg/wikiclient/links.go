@@ -0,0 +1,136 @@
+package wikiclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MaxLinksPageSize is the maximum number of links the API returns at once.
+const MaxLinksPageSize = 500
+
+// Link is a link between pages along with the namespace of the other page.
+type Link struct {
+	Namespace int
+	Title     string
+}
+
+// LinksPage is a part of the links of a page. NextCursor is empty if this is the last part.
+type LinksPage struct {
+	Links      []Link
+	NextCursor string
+}
+
+// ListLinks returns up to limit links of the page to the given namespaces, or to all namespaces if none are given.
+// Redirects are followed. An empty cursor starts from the first link.
+func (c *Client) ListLinks(ctx context.Context, pageTitle string, namespaces []int, limit int, cursor string) (*LinksPage, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("prop", "links")
+	params.Add("redirects", "1")
+	params.Add("pllimit", strconv.Itoa(limit))
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("titles", pageTitle)
+	if len(namespaces) != 0 {
+		params.Add("plnamespace", joinNamespaces(namespaces))
+	}
+	if cursor != "" {
+		params.Add("plcontinue", cursor)
+	}
+
+	type Response struct {
+		Continue *struct {
+			Plcontinue string `json:"plcontinue"`
+		} `json:"continue"`
+		Query struct {
+			Pages []struct {
+				Title   string `json:"title"`
+				Missing bool   `json:"missing"`
+				Invalid bool   `json:"invalid"`
+				Links   []struct {
+					Ns    int    `json:"ns"`
+					Title string `json:"title"`
+				} `json:"links"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Query.Pages) == 0 || response.Query.Pages[0].Missing || response.Query.Pages[0].Invalid {
+		return nil, ErrPageNotFound
+	}
+
+	page := new(LinksPage)
+	for _, link := range response.Query.Pages[0].Links {
+		page.Links = append(page.Links, Link{Namespace: link.Ns, Title: link.Title})
+	}
+
+	if response.Continue != nil {
+		page.NextCursor = response.Continue.Plcontinue
+	}
+
+	return page, nil
+}
+
+// ListLinkingPages returns up to limit pages of the given namespaces, or of all namespaces if none are given,
+// that link to the page. Redirects to the page are not included. An empty cursor starts from the first page.
+func (c *Client) ListLinkingPages(ctx context.Context, pageTitle string, namespaces []int, limit int, cursor string) (*LinksPage, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("list", "backlinks")
+	params.Add("bllimit", strconv.Itoa(limit))
+	params.Add("blfilterredir", "nonredirects")
+	params.Add("format", "json")
+	params.Add("bltitle", pageTitle)
+	if len(namespaces) != 0 {
+		params.Add("blnamespace", joinNamespaces(namespaces))
+	}
+	if cursor != "" {
+		params.Add("blcontinue", cursor)
+	}
+
+	type Response struct {
+		Continue *struct {
+			Blcontinue string `json:"blcontinue"`
+		} `json:"continue"`
+		Query struct {
+			Backlinks []struct {
+				Ns    int    `json:"ns"`
+				Title string `json:"title"`
+			} `json:"backlinks"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	page := new(LinksPage)
+	for _, link := range response.Query.Backlinks {
+		page.Links = append(page.Links, Link{Namespace: link.Ns, Title: link.Title})
+	}
+
+	if response.Continue != nil {
+		page.NextCursor = response.Continue.Blcontinue
+	}
+
+	return page, nil
+}
+
+func joinNamespaces(namespaces []int) string {
+	values := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		values = append(values, strconv.Itoa(ns))
+	}
+
+	return strings.Join(values, "|")
+}
@@ -0,0 +1,220 @@
+package wikiclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// Canonicalize returns the title of the page as it's stored in Wikipedia:
+// the title is normalized and redirects are followed.
+func (c *Client) Canonicalize(ctx context.Context, pageTitle string) (string, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("redirects", "1")
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("titles", pageTitle)
+
+	type Response struct {
+		Query struct {
+			Pages []struct {
+				Title   string `json:"title"`
+				Missing bool   `json:"missing"`
+				Invalid bool   `json:"invalid"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Query.Pages) == 0 {
+		return "", ErrPageNotFound
+	}
+
+	page := response.Query.Pages[0]
+	if page.Missing || page.Invalid {
+		return "", ErrPageNotFound
+	}
+
+	return page.Title, nil
+}
+
+// GetLinkingPages returns titles of the pages that link to the given page.
+// Redirects to the page are not included.
+func (c *Client) GetLinkingPages(ctx context.Context, pageTitle string) (titles []string, err error) {
+	var cursor *string
+	for {
+		newBatch, nextCursor, err := c.getBacklinks(ctx, pageTitle, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		titles = append(titles, newBatch...)
+
+		cursor = nextCursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return titles, nil
+}
+
+func (c *Client) getBacklinks(ctx context.Context, title string, cursor *string) (titles []string, nextCursor *string, err error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("list", "backlinks")
+	params.Add("bllimit", "max")
+	params.Add("blfilterredir", "nonredirects")
+	params.Add("format", "json")
+	params.Add("bltitle", title)
+	if cursor != nil {
+		params.Add("blcontinue", *cursor)
+	}
+
+	type Response struct {
+		Continue *struct {
+			Blcontinue string `json:"blcontinue"`
+			Continue   string `json:"continue"`
+		} `json:"continue"`
+		Query struct {
+			Backlinks []struct {
+				Ns    int    `json:"ns"`
+				Title string `json:"title"`
+			} `json:"backlinks"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err = c.query(ctx, params, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	titles = make([]string, 0, len(response.Query.Backlinks))
+	for _, link := range response.Query.Backlinks {
+		titles = append(titles, link.Title)
+	}
+
+	if response.Continue != nil {
+		nextCursor = &response.Continue.Blcontinue
+	}
+
+	return titles, nextCursor, nil
+}
+
+// GetWikitext returns the wikitext of the latest revision of the page. Redirects are followed.
+func (c *Client) GetWikitext(ctx context.Context, pageTitle string) (string, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("prop", "revisions")
+	params.Add("rvprop", "content")
+	params.Add("rvslots", "main")
+	params.Add("redirects", "1")
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("titles", pageTitle)
+
+	type Response struct {
+		Query struct {
+			Pages []struct {
+				Missing   bool `json:"missing"`
+				Invalid   bool `json:"invalid"`
+				Revisions []struct {
+					Slots struct {
+						Main struct {
+							Content string `json:"content"`
+						} `json:"main"`
+					} `json:"slots"`
+				} `json:"revisions"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Query.Pages) == 0 {
+		return "", ErrPageNotFound
+	}
+
+	page := response.Query.Pages[0]
+	if page.Missing || page.Invalid || len(page.Revisions) == 0 {
+		return "", ErrPageNotFound
+	}
+
+	return page.Revisions[0].Slots.Main.Content, nil
+}
+
+// GetRedirects returns titles of the pages redirecting to the given one.
+func (c *Client) GetRedirects(ctx context.Context, pageTitle string) (titles []string, err error) {
+	var cursor *string
+	for {
+		newBatch, nextCursor, err := c.getRedirects(ctx, pageTitle, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		titles = append(titles, newBatch...)
+
+		cursor = nextCursor
+		if cursor == nil {
+			break
+		}
+	}
+
+	return titles, nil
+}
+
+func (c *Client) getRedirects(ctx context.Context, title string, cursor *string) (titles []string, nextCursor *string, err error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("prop", "redirects")
+	params.Add("rdlimit", "max")
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("titles", title)
+	if cursor != nil {
+		params.Add("rdcontinue", *cursor)
+	}
+
+	type Response struct {
+		Continue *struct {
+			Rdcontinue string `json:"rdcontinue"`
+			Continue   string `json:"continue"`
+		} `json:"continue"`
+		Query struct {
+			Pages []struct {
+				Redirects []struct {
+					Ns    int    `json:"ns"`
+					Title string `json:"title"`
+				} `json:"redirects"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err = c.query(ctx, params, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, page := range response.Query.Pages {
+		for _, redirect := range page.Redirects {
+			titles = append(titles, redirect.Title)
+		}
+	}
+
+	if response.Continue != nil {
+		nextCursor = &response.Continue.Rdcontinue
+	}
+
+	return titles, nextCursor, nil
+}
@@ -0,0 +1,44 @@
+package wikiclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// MaxRandomPages is the maximum number of random pages the API returns at once.
+const MaxRandomPages = 500
+
+// GetRandomPages returns titles of up to count random articles, count must not exceed MaxRandomPages.
+// Redirects are not included.
+func (c *Client) GetRandomPages(ctx context.Context, count int) ([]string, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("list", "random")
+	params.Add("rnnamespace", "0")
+	params.Add("rnfilterredir", "nonredirects")
+	params.Add("rnlimit", strconv.Itoa(count))
+	params.Add("format", "json")
+
+	type Response struct {
+		Query struct {
+			Random []struct {
+				Ns    int    `json:"ns"`
+				Title string `json:"title"`
+			} `json:"random"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0, len(response.Query.Random))
+	for _, page := range response.Query.Random {
+		titles = append(titles, page.Title)
+	}
+
+	return titles, nil
+}
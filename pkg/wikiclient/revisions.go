@@ -0,0 +1,119 @@
+package wikiclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoRevision is returned when a page has no revision at the requested moment:
+// either the page does not exist or it was created later.
+var ErrNoRevision = errors.New("no revision")
+
+type Revision struct {
+	ID        int64
+	PageTitle string
+	Timestamp time.Time
+}
+
+// GetRevisionAt returns the revision of the page that was current at the given moment.
+// Redirects are not followed, so the revision of a redirect page is returned as is.
+func (c *Client) GetRevisionAt(ctx context.Context, pageTitle string, at time.Time) (*Revision, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("prop", "revisions")
+	params.Add("rvprop", "ids|timestamp")
+	params.Add("rvlimit", "1")
+	params.Add("rvdir", "older")
+	params.Add("rvstart", at.UTC().Format(time.RFC3339))
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("titles", pageTitle)
+
+	type Response struct {
+		Query struct {
+			Pages []struct {
+				Title     string `json:"title"`
+				Missing   bool   `json:"missing"`
+				Revisions []struct {
+					RevID     int64     `json:"revid"`
+					Timestamp time.Time `json:"timestamp"`
+				} `json:"revisions"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Query.Pages) == 0 {
+		return nil, ErrNoRevision
+	}
+
+	page := response.Query.Pages[0]
+	if page.Missing || len(page.Revisions) == 0 {
+		return nil, ErrNoRevision
+	}
+
+	return &Revision{
+		ID:        page.Revisions[0].RevID,
+		PageTitle: page.Title,
+		Timestamp: page.Revisions[0].Timestamp,
+	}, nil
+}
+
+// GetRevisionLinks fetches the wikitext of the revision and returns titles of the pages it links to.
+// Links that come from transcluded templates are not included.
+func (c *Client) GetRevisionLinks(ctx context.Context, revisionID int64) ([]string, error) {
+	text, err := c.GetRevisionWikitext(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseWikitextLinks(text), nil
+}
+
+// GetRevisionWikitext returns the wikitext of the revision.
+func (c *Client) GetRevisionWikitext(ctx context.Context, revisionID int64) (string, error) {
+	params := url.Values{}
+	params.Add("action", "query")
+	params.Add("prop", "revisions")
+	params.Add("rvprop", "content")
+	params.Add("rvslots", "main")
+	params.Add("format", "json")
+	params.Add("formatversion", "2")
+	params.Add("revids", strconv.FormatInt(revisionID, 10))
+
+	type Response struct {
+		Query struct {
+			BadRevIDs map[string]interface{} `json:"badrevids"`
+			Pages     []struct {
+				Revisions []struct {
+					Slots struct {
+						Main struct {
+							Content string `json:"content"`
+						} `json:"main"`
+					} `json:"slots"`
+				} `json:"revisions"`
+			} `json:"pages"`
+		} `json:"query"`
+	}
+
+	var response Response
+	err := c.query(ctx, params, &response)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Query.BadRevIDs) != 0 || len(response.Query.Pages) == 0 || len(response.Query.Pages[0].Revisions) == 0 {
+		return "", ErrNoRevision
+	}
+
+	return response.Query.Pages[0].Revisions[0].Slots.Main.Content, nil
+}
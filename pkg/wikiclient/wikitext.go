@@ -0,0 +1,149 @@
+package wikiclient
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	wikitextCommentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+	wikitextNowikiRegexp  = regexp.MustCompile(`(?s)<nowiki>.*?</nowiki>`)
+
+	// Interwiki and interlanguage prefixes such as "fr:" or "wikt:". Namespaces may be written in lowercase too,
+	// so known ones are checked first.
+	interwikiPrefixRegexp = regexp.MustCompile(`^[a-z][a-z-]*:`)
+)
+
+// Namespaces of Wikipedia and their aliases in lowercase.
+var namespaces = map[string]struct{}{
+	"talk": {}, "user": {}, "user talk": {}, "wikipedia": {}, "wikipedia talk": {}, "project": {}, "wp": {},
+	"file": {}, "file talk": {}, "image": {}, "media": {}, "mediawiki": {}, "template": {}, "template talk": {},
+	"help": {}, "help talk": {}, "category": {}, "category talk": {}, "portal": {}, "draft": {}, "module": {},
+	"special": {}, "timedtext": {},
+}
+
+// Links with these namespace prefixes embed files or categorize the page instead of linking.
+var nonLinkNamespaces = map[string]struct{}{"file": {}, "image": {}, "media": {}, "category": {}}
+
+// ParseWikitextLinks returns titles of the pages linked from the wikitext via [[...]] syntax.
+// Titles are normalized the same way MediaWiki does it: underscores become spaces
+// and the first letter is capitalized. Duplicates are removed, the order of first occurrences is kept.
+func ParseWikitextLinks(text string) []string {
+	seen := make(map[string]struct{})
+	var titles []string
+
+	scanLinks(stripWikitextComments(text), func(title string, _ int) bool {
+		if _, exists := seen[title]; !exists {
+			seen[title] = struct{}{}
+			titles = append(titles, title)
+		}
+
+		return true
+	})
+
+	return titles
+}
+
+func stripWikitextComments(text string) string {
+	text = wikitextCommentRegexp.ReplaceAllString(text, "")
+	text = wikitextNowikiRegexp.ReplaceAllString(text, "")
+
+	return text
+}
+
+// scanLinks calls fn with the normalized target and the offset of every [[...]] link until fn returns false.
+func scanLinks(text string, fn func(title string, offset int) bool) {
+	for pos := 0; ; {
+		start := strings.Index(text[pos:], "[[")
+		if start == -1 {
+			break
+		}
+		start += pos + 2
+		pos = start
+
+		// Links may be nested into file captions, so the scanning continues right after the opening brackets.
+		end := strings.IndexAny(text[start:], "|]\n[{")
+		if end == -1 {
+			break
+		}
+
+		// Targets built from templates cannot be resolved without expanding them.
+		if c := text[start+end]; c != '|' && c != ']' {
+			continue
+		}
+
+		title, ok := normalizeLinkTarget(text[start : start+end])
+		if !ok {
+			continue
+		}
+
+		if !fn(title, start-2) {
+			return
+		}
+	}
+}
+
+func normalizeLinkTarget(target string) (string, bool) {
+	if idx := strings.IndexByte(target, '#'); idx != -1 {
+		target = target[:idx]
+	}
+
+	target = strings.Join(strings.Fields(strings.ReplaceAll(target, "_", " ")), " ")
+
+	// A leading colon turns an embedding link (e.g. a category) into a plain one.
+	escaped := strings.HasPrefix(target, ":")
+	target = strings.TrimSpace(strings.TrimPrefix(target, ":"))
+	if target == "" {
+		return "", false
+	}
+
+	if idx := strings.IndexByte(target, ':'); idx > 0 {
+		ns := strings.ToLower(strings.TrimSpace(target[:idx]))
+		if _, known := namespaces[ns]; known {
+			if _, nonLink := nonLinkNamespaces[ns]; nonLink && !escaped {
+				return "", false
+			}
+
+			// Both the namespace and the title within it are capitalized, e.g. "category:foo" is "Category:Foo".
+			title := strings.TrimSpace(target[idx+1:])
+			if title == "" {
+				return "", false
+			}
+
+			return capitalize(strings.TrimSpace(target[:idx])) + ":" + capitalize(title), true
+		}
+	}
+
+	if interwikiPrefixRegexp.MatchString(target) {
+		return "", false
+	}
+
+	return capitalize(target), true
+}
+
+func capitalize(title string) string {
+	first, size := utf8.DecodeRuneInString(title)
+
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+// ContainsTitle reports whether the titles contain the given one. Titles are case-sensitive except for
+// the first letter, as in MediaWiki.
+func ContainsTitle(titles []string, title string) bool {
+	for _, t := range titles {
+		if sameTitle(t, title) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sameTitle(a, b string) bool {
+	firstA, sizeA := utf8.DecodeRuneInString(a)
+	firstB, sizeB := utf8.DecodeRuneInString(b)
+
+	return unicode.ToUpper(firstA) == unicode.ToUpper(firstB) && a[sizeA:] == b[sizeB:]
+}
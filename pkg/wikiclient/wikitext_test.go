@@ -0,0 +1,62 @@
+package wikiclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWikitextLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"plain", "See [[Moscow]] and [[Saint Petersburg]].", []string{"Moscow", "Saint Petersburg"}},
+		{"piped and anchored", "[[moscow|the capital]], [[Moscow#History|history]]", []string{"Moscow"}},
+		{"underscores and spaces", "[[New_York  City]]", []string{"New York City"}},
+		{"duplicates", "[[A]] [[B]] [[a]]", []string{"A", "B"}},
+		{"comments and nowiki", "<!-- [[Hidden]] --><nowiki>[[Raw]]</nowiki>[[Shown]]", []string{"Shown"}},
+		{"templates", "[[{{PAGENAME}}]] [[Real]]", []string{"Real"}},
+		{"links in file captions", "[[File:Map.png|thumb|A [[Map]] of [[Europe]]]]", []string{"Map", "Europe"}},
+		{"categories", "[[Category:Cities]] [[category:Towns]]", nil},
+		{"escaped categories", "[[:Category:Cities]] [[:category:towns]]", []string{"Category:Cities", "Category:Towns"}},
+		{"lowercase namespaces", "[[wikipedia:about]] [[help:contents]]", []string{"Wikipedia:About", "Help:Contents"}},
+		{"interwiki", "[[fr:Paris]] [[wikt:word]] [[:de:Berlin]]", nil},
+		{"unknown uppercase prefix", "[[Star Wars: Episode I]]", []string{"Star Wars: Episode I"}},
+		{"empty", "[[ ]] [[#Section]] [[Help:]]", nil},
+		{"unterminated", "[[Open", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseWikitextLinks(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWikitextLinks(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsTitle(t *testing.T) {
+	titles := []string{"Moscow", "iPhone", "Über", "Saint Petersburg"}
+
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Moscow", true},
+		{"moscow", true},
+		{"MOSCOW", false},
+		{"IPhone", true},
+		{"iphone", false},
+		{"über", true},
+		{"Saint petersburg", false},
+		{"Paris", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ContainsTitle(titles, tt.title); got != tt.want {
+			t.Errorf("ContainsTitle(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
@@ -9,6 +9,8 @@ package wikigraphpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +22,274 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Tasks with higher priorities are processed first.
+type Priority int32
+
+const (
+	// Treated as NORMAL.
+	Priority_PRIORITY_UNSPECIFIED Priority = 0
+	// Analytics and other background requests.
+	Priority_BATCH  Priority = 1
+	Priority_NORMAL Priority = 2
+	// Requests a user is waiting for.
+	Priority_INTERACTIVE Priority = 3
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "BATCH",
+		2: "NORMAL",
+		3: "INTERACTIVE",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"BATCH":                1,
+		"NORMAL":               2,
+		"INTERACTIVE":          3,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{0}
+}
+
+type TieBreak int32
+
+const (
+	// The path the target is discovered by first.
+	TieBreak_TIE_BREAK_UNSPECIFIED TieBreak = 0
+	// The path through the biggest hubs.
+	TieBreak_PREFER_HUBS TieBreak = 1
+	// The path through the smallest hubs.
+	TieBreak_AVOID_HUBS TieBreak = 2
+)
+
+// Enum value maps for TieBreak.
+var (
+	TieBreak_name = map[int32]string{
+		0: "TIE_BREAK_UNSPECIFIED",
+		1: "PREFER_HUBS",
+		2: "AVOID_HUBS",
+	}
+	TieBreak_value = map[string]int32{
+		"TIE_BREAK_UNSPECIFIED": 0,
+		"PREFER_HUBS":           1,
+		"AVOID_HUBS":            2,
+	}
+)
+
+func (x TieBreak) Enum() *TieBreak {
+	p := new(TieBreak)
+	*p = x
+	return p
+}
+
+func (x TieBreak) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TieBreak) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[1].Descriptor()
+}
+
+func (TieBreak) Type() protoreflect.EnumType {
+	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[1]
+}
+
+func (x TieBreak) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TieBreak.Descriptor instead.
+func (TieBreak) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{1}
+}
+
+type SearchMode int32
+
+const (
+	// Treated as BFS.
+	SearchMode_SEARCH_MODE_UNSPECIFIED SearchMode = 0
+	// Breadth-first search, the path is the shortest one.
+	SearchMode_BFS SearchMode = 1
+	// Pages similar to the target are expanded first. The path is found faster,
+	// but it might be longer than the shortest one.
+	SearchMode_BEST_FIRST SearchMode = 2
+)
+
+// Enum value maps for SearchMode.
+var (
+	SearchMode_name = map[int32]string{
+		0: "SEARCH_MODE_UNSPECIFIED",
+		1: "BFS",
+		2: "BEST_FIRST",
+	}
+	SearchMode_value = map[string]int32{
+		"SEARCH_MODE_UNSPECIFIED": 0,
+		"BFS":                     1,
+		"BEST_FIRST":              2,
+	}
+)
+
+func (x SearchMode) Enum() *SearchMode {
+	p := new(SearchMode)
+	*p = x
+	return p
+}
+
+func (x SearchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[2].Descriptor()
+}
+
+func (SearchMode) Type() protoreflect.EnumType {
+	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[2]
+}
+
+func (x SearchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchMode.Descriptor instead.
+func (SearchMode) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{2}
+}
+
+type TaskKind int32
+
+const (
+	// Treated as PATH.
+	TaskKind_TASK_KIND_UNSPECIFIED TaskKind = 0
+	TaskKind_PATH                  TaskKind = 1
+	// Only the distance between the pages is searched for.
+	TaskKind_DISTANCE TaskKind = 2
+	// Pages within a few clicks from the from page are listed.
+	TaskKind_NEIGHBORHOOD TaskKind = 3
+)
+
+// Enum value maps for TaskKind.
+var (
+	TaskKind_name = map[int32]string{
+		0: "TASK_KIND_UNSPECIFIED",
+		1: "PATH",
+		2: "DISTANCE",
+		3: "NEIGHBORHOOD",
+	}
+	TaskKind_value = map[string]int32{
+		"TASK_KIND_UNSPECIFIED": 0,
+		"PATH":                  1,
+		"DISTANCE":              2,
+		"NEIGHBORHOOD":          3,
+	}
+)
+
+func (x TaskKind) Enum() *TaskKind {
+	p := new(TaskKind)
+	*p = x
+	return p
+}
+
+func (x TaskKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[3].Descriptor()
+}
+
+func (TaskKind) Type() protoreflect.EnumType {
+	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[3]
+}
+
+func (x TaskKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskKind.Descriptor instead.
+func (TaskKind) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{3}
+}
+
+type LinkDirection int32
+
+const (
+	// Treated as OUTGOING.
+	LinkDirection_LINK_DIRECTION_UNSPECIFIED LinkDirection = 0
+	// Pages the page links to.
+	LinkDirection_OUTGOING LinkDirection = 1
+	// Pages linking to the page.
+	LinkDirection_INCOMING LinkDirection = 2
+)
+
+// Enum value maps for LinkDirection.
+var (
+	LinkDirection_name = map[int32]string{
+		0: "LINK_DIRECTION_UNSPECIFIED",
+		1: "OUTGOING",
+		2: "INCOMING",
+	}
+	LinkDirection_value = map[string]int32{
+		"LINK_DIRECTION_UNSPECIFIED": 0,
+		"OUTGOING":                   1,
+		"INCOMING":                   2,
+	}
+)
+
+func (x LinkDirection) Enum() *LinkDirection {
+	p := new(LinkDirection)
+	*p = x
+	return p
+}
+
+func (x LinkDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LinkDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[4].Descriptor()
+}
+
+func (LinkDirection) Type() protoreflect.EnumType {
+	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[4]
+}
+
+func (x LinkDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LinkDirection.Descriptor instead.
+func (LinkDirection) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{4}
+}
+
 type Task_Status int32
 
 const (
@@ -27,6 +297,8 @@ const (
 	Task_PENDING    Task_Status = 1
 	Task_PROCESSING Task_Status = 2
 	Task_DONE       Task_Status = 3
+	// The task cannot be completed, e.g. the search exceeded the memory budget.
+	Task_FAILED Task_Status = 4
 )
 
 // Enum value maps for Task_Status.
@@ -36,12 +308,14 @@ var (
 		1: "PENDING",
 		2: "PROCESSING",
 		3: "DONE",
+		4: "FAILED",
 	}
 	Task_Status_value = map[string]int32{
 		"UNKNOWN":    0,
 		"PENDING":    1,
 		"PROCESSING": 2,
 		"DONE":       3,
+		"FAILED":     4,
 	}
 )
 
@@ -56,11 +330,11 @@ func (x Task_Status) String() string {
 }
 
 func (Task_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[0].Descriptor()
+	return file_pkg_wikigraphpb_wikigraph_proto_enumTypes[5].Descriptor()
 }
 
 func (Task_Status) Type() protoreflect.EnumType {
-	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[0]
+	return &file_pkg_wikigraphpb_wikigraph_proto_enumTypes[5]
 }
 
 func (x Task_Status) Number() protoreflect.EnumNumber {
@@ -130,6 +404,37 @@ type Task struct {
 	To     string      `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
 	// If the status is DONE, this is the shortest path, otherwise empty.
 	Path []string `protobuf:"bytes,5,rep,name=path,proto3" json:"path,omitempty"`
+	// If set, links were taken from the page revisions current at this moment.
+	AsOf     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	Priority Priority               `protobuf:"varint,7,opt,name=priority,proto3,enum=wikigraph.Priority" json:"priority,omitempty"`
+	// If the status is DONE, this describes the cost of the search.
+	Stats *TaskStats `protobuf:"bytes,8,opt,name=stats,proto3" json:"stats,omitempty"`
+	// If the status is PROCESSING, this is the last reported progress, if any.
+	Progress *TaskProgress `protobuf:"bytes,9,opt,name=progress,proto3" json:"progress,omitempty"`
+	// If the status is DONE, complete is false when some pages could not be fetched
+	// and a shorter path (or any path, if none was found) might go through them.
+	Complete    bool     `protobuf:"varint,10,opt,name=complete,proto3" json:"complete,omitempty"`
+	FailedPages []string `protobuf:"bytes,11,rep,name=failed_pages,json=failedPages,proto3" json:"failed_pages,omitempty"`
+	// If the status is FAILED, this is the reason.
+	Error string     `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	Mode  SearchMode `protobuf:"varint,13,opt,name=mode,proto3,enum=wikigraph.SearchMode" json:"mode,omitempty"`
+	// True if the path was found by the best-first search and might be longer than the shortest one.
+	// If the optimality is being proven, the path is returned while the task is PROCESSING.
+	Heuristic bool `protobuf:"varint,14,opt,name=heuristic,proto3" json:"heuristic,omitempty"`
+	// If more than one path was requested, loopless paths in the explored part of the graph
+	// ordered by length, starting with the shortest one.
+	Paths []*Path `protobuf:"bytes,15,rep,name=paths,proto3" json:"paths,omitempty"`
+	// If the kind is DISTANCE and the status is DONE, this is the result.
+	DistanceResult *DistanceResult `protobuf:"bytes,16,opt,name=distance_result,json=distanceResult,proto3" json:"distance_result,omitempty"`
+	Kind           TaskKind        `protobuf:"varint,17,opt,name=kind,proto3,enum=wikigraph.TaskKind" json:"kind,omitempty"`
+	// If the kind is NEIGHBORHOOD and the status is DONE, these are the pages ordered by distance,
+	// the from page included. If truncated, only the closest pages are listed.
+	Neighborhood          []*Neighbor `protobuf:"bytes,18,rep,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	NeighborhoodTruncated bool        `protobuf:"varint,19,opt,name=neighborhood_truncated,json=neighborhoodTruncated,proto3" json:"neighborhood_truncated,omitempty"`
+	// If explanations were requested and the status is DONE, why every page of the path links to the next one.
+	Explanations []*HopExplanation `protobuf:"bytes,20,rep,name=explanations,proto3" json:"explanations,omitempty"`
+	// The last check of the path against the current links, if any.
+	Verification *PathVerification `protobuf:"bytes,21,opt,name=verification,proto3" json:"verification,omitempty"`
 }
 
 func (x *Task) Reset() {
@@ -199,133 +504,148 @@ func (x *Task) GetPath() []string {
 	return nil
 }
 
-type FindShortestPathRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+func (x *Task) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
 }
 
-func (x *FindShortestPathRequest) Reset() {
-	*x = FindShortestPathRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Task) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
 	}
+	return Priority_PRIORITY_UNSPECIFIED
 }
 
-func (x *FindShortestPathRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetStats() *TaskStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
 }
 
-func (*FindShortestPathRequest) ProtoMessage() {}
+func (x *Task) GetProgress() *TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
 
-func (x *FindShortestPathRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Task) GetComplete() bool {
+	if x != nil {
+		return x.Complete
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use FindShortestPathRequest.ProtoReflect.Descriptor instead.
-func (*FindShortestPathRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{2}
+func (x *Task) GetFailedPages() []string {
+	if x != nil {
+		return x.FailedPages
+	}
+	return nil
 }
 
-func (x *FindShortestPathRequest) GetFrom() string {
+func (x *Task) GetError() string {
 	if x != nil {
-		return x.From
+		return x.Error
 	}
 	return ""
 }
 
-func (x *FindShortestPathRequest) GetTo() string {
+func (x *Task) GetMode() SearchMode {
 	if x != nil {
-		return x.To
+		return x.Mode
 	}
-	return ""
+	return SearchMode_SEARCH_MODE_UNSPECIFIED
 }
 
-type FindShortestPathResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Task) GetHeuristic() bool {
+	if x != nil {
+		return x.Heuristic
+	}
+	return false
+}
 
-	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+func (x *Task) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
 }
 
-func (x *FindShortestPathResponse) Reset() {
-	*x = FindShortestPathResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Task) GetDistanceResult() *DistanceResult {
+	if x != nil {
+		return x.DistanceResult
 	}
+	return nil
 }
 
-func (x *FindShortestPathResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetKind() TaskKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TaskKind_TASK_KIND_UNSPECIFIED
 }
 
-func (*FindShortestPathResponse) ProtoMessage() {}
+func (x *Task) GetNeighborhood() []*Neighbor {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return nil
+}
 
-func (x *FindShortestPathResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Task) GetNeighborhoodTruncated() bool {
+	if x != nil {
+		return x.NeighborhoodTruncated
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use FindShortestPathResponse.ProtoReflect.Descriptor instead.
-func (*FindShortestPathResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{3}
+func (x *Task) GetExplanations() []*HopExplanation {
+	if x != nil {
+		return x.Explanations
+	}
+	return nil
 }
 
-func (x *FindShortestPathResponse) GetTaskId() *TaskId {
+func (x *Task) GetVerification() *PathVerification {
 	if x != nil {
-		return x.TaskId
+		return x.Verification
 	}
 	return nil
 }
 
-type GetTaskRequest struct {
+type PathVerification struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	VerifiedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	// False if some links of the path have been removed.
+	Valid      bool   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	BrokenHops []*Hop `protobuf:"bytes,3,rep,name=broken_hops,json=brokenHops,proto3" json:"broken_hops,omitempty"`
+	// If the path is broken and the search was run again, ID of the new task.
+	RerunTaskId *TaskId `protobuf:"bytes,4,opt,name=rerun_task_id,json=rerunTaskId,proto3" json:"rerun_task_id,omitempty"`
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
+func (x *PathVerification) Reset() {
+	*x = PathVerification{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[4]
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *PathVerification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*PathVerification) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[4]
+func (x *PathVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -336,28 +656,179 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use PathVerification.ProtoReflect.Descriptor instead.
+func (*PathVerification) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetTaskRequest) GetTaskId() *TaskId {
+func (x *PathVerification) GetVerifiedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TaskId
+		return x.VerifiedAt
 	}
 	return nil
 }
 
-type GetTaskResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *PathVerification) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
 
-	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+func (x *PathVerification) GetBrokenHops() []*Hop {
+	if x != nil {
+		return x.BrokenHops
+	}
+	return nil
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
+func (x *PathVerification) GetRerunTaskId() *TaskId {
+	if x != nil {
+		return x.RerunTaskId
+	}
+	return nil
+}
+
+type Hop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *Hop) Reset() {
+	*x = Hop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hop) ProtoMessage() {}
+
+func (x *Hop) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hop.ProtoReflect.Descriptor instead.
+func (*Hop) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Hop) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Hop) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type HopExplanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Heading of the section of the from page containing the link, empty for the lead section.
+	Section string `protobuf:"bytes,3,opt,name=section,proto3" json:"section,omitempty"`
+	// The sentence containing the link. Empty if the link cannot be found in the text of the page,
+	// e.g. it comes from a template.
+	Sentence string `protobuf:"bytes,4,opt,name=sentence,proto3" json:"sentence,omitempty"`
+}
+
+func (x *HopExplanation) Reset() {
+	*x = HopExplanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HopExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HopExplanation) ProtoMessage() {}
+
+func (x *HopExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HopExplanation.ProtoReflect.Descriptor instead.
+func (*HopExplanation) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HopExplanation) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *HopExplanation) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *HopExplanation) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *HopExplanation) GetSentence() string {
+	if x != nil {
+		return x.Sentence
+	}
+	return ""
+}
+
+type Neighbor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title    string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Distance uint32 `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+}
+
+func (x *Neighbor) Reset() {
+	*x = Neighbor{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -365,137 +836,4213 @@ func (x *GetTaskResponse) Reset() {
 	}
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *Neighbor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*Neighbor) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *Neighbor) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *GetTaskResponse) GetTask() *Task {
-	if x != nil {
-		return x.Task
-	}
-	return nil
-}
-
-var File_pkg_wikigraphpb_wikigraph_proto protoreflect.FileDescriptor
-
-var file_pkg_wikigraphpb_wikigraph_proto_rawDesc = []byte{
-	0x0a, 0x1f, 0x70, 0x6b, 0x67, 0x2f, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x70,
-	0x62, 0x2f, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x22, 0x18, 0x0a, 0x06,
-	0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xcf, 0x01, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12,
-	0x21, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69,
-	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54,
-	0x61, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x3c, 0x0a, 0x06, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0e,
-	0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x08,
-	0x0a, 0x04, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x22, 0x3d, 0x0a, 0x17, 0x46, 0x69, 0x6e, 0x64,
-	0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x46, 0x0a, 0x18, 0x46, 0x69, 0x6e, 0x64, 0x53,
-	0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22,
-	0x3c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54,
-	0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0x36, 0x0a,
-	0x0f, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x23, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
-	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52,
-	0x04, 0x74, 0x61, 0x73, 0x6b, 0x32, 0xaa, 0x01, 0x0a, 0x09, 0x57, 0x69, 0x6b, 0x69, 0x47, 0x72,
-	0x61, 0x70, 0x68, 0x12, 0x5b, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74,
-	0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x22, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74,
-	0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x77, 0x69,
-	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f, 0x72,
-	0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x19, 0x2e, 0x77, 0x69,
-	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6c, 0x6f, 0x64, 0x74, 0x68, 0x65, 0x2f, 0x77, 0x69, 0x6b, 0x69, 0x2d, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_pkg_wikigraphpb_wikigraph_proto_rawDescOnce sync.Once
-	file_pkg_wikigraphpb_wikigraph_proto_rawDescData = file_pkg_wikigraphpb_wikigraph_proto_rawDesc
-)
-
-func file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP() []byte {
-	file_pkg_wikigraphpb_wikigraph_proto_rawDescOnce.Do(func() {
-		file_pkg_wikigraphpb_wikigraph_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_wikigraphpb_wikigraph_proto_rawDescData)
-	})
-	return file_pkg_wikigraphpb_wikigraph_proto_rawDescData
-}
-
-var file_pkg_wikigraphpb_wikigraph_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pkg_wikigraphpb_wikigraph_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_pkg_wikigraphpb_wikigraph_proto_goTypes = []interface{}{
-	(Task_Status)(0),                 // 0: wikigraph.Task.Status
-	(*TaskId)(nil),                   // 1: wikigraph.TaskId
-	(*Task)(nil),                     // 2: wikigraph.Task
-	(*FindShortestPathRequest)(nil),  // 3: wikigraph.FindShortestPathRequest
-	(*FindShortestPathResponse)(nil), // 4: wikigraph.FindShortestPathResponse
-	(*GetTaskRequest)(nil),           // 5: wikigraph.GetTaskRequest
-	(*GetTaskResponse)(nil),          // 6: wikigraph.GetTaskResponse
-}
-var file_pkg_wikigraphpb_wikigraph_proto_depIdxs = []int32{
-	1, // 0: wikigraph.Task.id:type_name -> wikigraph.TaskId
-	0, // 1: wikigraph.Task.status:type_name -> wikigraph.Task.Status
-	1, // 2: wikigraph.FindShortestPathResponse.task_id:type_name -> wikigraph.TaskId
-	1, // 3: wikigraph.GetTaskRequest.task_id:type_name -> wikigraph.TaskId
-	2, // 4: wikigraph.GetTaskResponse.task:type_name -> wikigraph.Task
-	3, // 5: wikigraph.WikiGraph.FindShortestPath:input_type -> wikigraph.FindShortestPathRequest
-	5, // 6: wikigraph.WikiGraph.GetTask:input_type -> wikigraph.GetTaskRequest
-	4, // 7: wikigraph.WikiGraph.FindShortestPath:output_type -> wikigraph.FindShortestPathResponse
-	6, // 8: wikigraph.WikiGraph.GetTask:output_type -> wikigraph.GetTaskResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
-}
-
-func init() { file_pkg_wikigraphpb_wikigraph_proto_init() }
-func file_pkg_wikigraphpb_wikigraph_proto_init() {
-	if File_pkg_wikigraphpb_wikigraph_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TaskId); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Neighbor.ProtoReflect.Descriptor instead.
+func (*Neighbor) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Neighbor) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Neighbor) GetDistance() uint32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type Link struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Wikipedia namespace of the linked page, e.g. 0 for articles and 14 for categories.
+	Namespace int32 `protobuf:"varint,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Link) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Link) GetNamespace() int32 {
+	if x != nil {
+		return x.Namespace
+	}
+	return 0
+}
+
+type DistanceResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// False if the target is not reachable within searched_distance clicks.
+	Reachable        bool   `protobuf:"varint,1,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	Distance         uint32 `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	SearchedDistance uint32 `protobuf:"varint,3,opt,name=searched_distance,json=searchedDistance,proto3" json:"searched_distance,omitempty"`
+	// True if the result was taken from the cache.
+	Cached bool `protobuf:"varint,4,opt,name=cached,proto3" json:"cached,omitempty"`
+}
+
+func (x *DistanceResult) Reset() {
+	*x = DistanceResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DistanceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DistanceResult) ProtoMessage() {}
+
+func (x *DistanceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DistanceResult.ProtoReflect.Descriptor instead.
+func (*DistanceResult) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DistanceResult) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *DistanceResult) GetDistance() uint32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *DistanceResult) GetSearchedDistance() uint32 {
+	if x != nil {
+		return x.SearchedDistance
+	}
+	return 0
+}
+
+func (x *DistanceResult) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type Path struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pages []string `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Path) GetPages() []string {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+type TaskProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Distance of the layer being expanded.
+	Distance    uint32 `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	MaxDistance uint32 `protobuf:"varint,3,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	// Number of pages in the layer being expanded and how many of them are fetched.
+	FrontierSize      int64 `protobuf:"varint,4,opt,name=frontier_size,json=frontierSize,proto3" json:"frontier_size,omitempty"`
+	LayerPagesFetched int64 `protobuf:"varint,5,opt,name=layer_pages_fetched,json=layerPagesFetched,proto3" json:"layer_pages_fetched,omitempty"`
+	// Pages fetched since the start of the search.
+	PagesFetched int64 `protobuf:"varint,6,opt,name=pages_fetched,json=pagesFetched,proto3" json:"pages_fetched,omitempty"`
+	// Rough estimate of the fetches left in this and the next layer.
+	EstimatedRemainingFetches int64 `protobuf:"varint,7,opt,name=estimated_remaining_fetches,json=estimatedRemainingFetches,proto3" json:"estimated_remaining_fetches,omitempty"`
+}
+
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TaskProgress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *TaskProgress) GetDistance() uint32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetMaxDistance() uint32 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetFrontierSize() int64 {
+	if x != nil {
+		return x.FrontierSize
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetLayerPagesFetched() int64 {
+	if x != nil {
+		return x.LayerPagesFetched
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetPagesFetched() int64 {
+	if x != nil {
+		return x.PagesFetched
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetEstimatedRemainingFetches() int64 {
+	if x != nil {
+		return x.EstimatedRemainingFetches
+	}
+	return 0
+}
+
+type TaskStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PagesFetched int64 `protobuf:"varint,1,opt,name=pages_fetched,json=pagesFetched,proto3" json:"pages_fetched,omitempty"`
+	FetchErrors  int64 `protobuf:"varint,2,opt,name=fetch_errors,json=fetchErrors,proto3" json:"fetch_errors,omitempty"`
+	// Requests sent to the Wikipedia API.
+	ApiRequests int64 `protobuf:"varint,3,opt,name=api_requests,json=apiRequests,proto3" json:"api_requests,omitempty"`
+	// Pages left unfetched, because the target had been discovered in the middle of a layer.
+	SkippedFetches int64                `protobuf:"varint,4,opt,name=skipped_fetches,json=skippedFetches,proto3" json:"skipped_fetches,omitempty"`
+	Elapsed        *durationpb.Duration `protobuf:"bytes,5,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	Layers         []*TaskStats_Layer   `protobuf:"bytes,6,rep,name=layers,proto3" json:"layers,omitempty"`
+}
+
+func (x *TaskStats) Reset() {
+	*x = TaskStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStats) ProtoMessage() {}
+
+func (x *TaskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStats.ProtoReflect.Descriptor instead.
+func (*TaskStats) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TaskStats) GetPagesFetched() int64 {
+	if x != nil {
+		return x.PagesFetched
+	}
+	return 0
+}
+
+func (x *TaskStats) GetFetchErrors() int64 {
+	if x != nil {
+		return x.FetchErrors
+	}
+	return 0
+}
+
+func (x *TaskStats) GetApiRequests() int64 {
+	if x != nil {
+		return x.ApiRequests
+	}
+	return 0
+}
+
+func (x *TaskStats) GetSkippedFetches() int64 {
+	if x != nil {
+		return x.SkippedFetches
+	}
+	return 0
+}
+
+func (x *TaskStats) GetElapsed() *durationpb.Duration {
+	if x != nil {
+		return x.Elapsed
+	}
+	return nil
+}
+
+func (x *TaskStats) GetLayers() []*TaskStats_Layer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+type FindShortestPathRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Optional. If set, the graph is built from the page revisions that were
+	// current at this moment instead of the latest ones.
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Optional. Defaults to NORMAL.
+	Priority Priority `protobuf:"varint,4,opt,name=priority,proto3,enum=wikigraph.Priority" json:"priority,omitempty"`
+	// Optional. Defaults to BFS.
+	Mode SearchMode `protobuf:"varint,5,opt,name=mode,proto3,enum=wikigraph.SearchMode" json:"mode,omitempty"`
+	// Only for BEST_FIRST. If set, the found path is returned as a preliminary result,
+	// and then the breadth-first search looks for a shorter one.
+	ProveOptimal bool `protobuf:"varint,6,opt,name=prove_optimal,json=proveOptimal,proto3" json:"prove_optimal,omitempty"`
+	// Optional. Number of paths to find, up to 10. Alternative paths go through the pages
+	// explored while searching for the shortest one, so they are not necessarily the next shortest in Wikipedia.
+	NumPaths uint32 `protobuf:"varint,7,opt,name=num_paths,json=numPaths,proto3" json:"num_paths,omitempty"`
+	// Optional. If set, every hop of the shortest path is explained with the sentence containing the link.
+	Explain bool `protobuf:"varint,8,opt,name=explain,proto3" json:"explain,omitempty"`
+	// Optional, only for BFS. The path doesn't go through the top avoid_hubs hub pages (up to 1000),
+	// so it might be longer than the shortest one.
+	AvoidHubs uint32 `protobuf:"varint,9,opt,name=avoid_hubs,json=avoidHubs,proto3" json:"avoid_hubs,omitempty"`
+	// Optional, only for BFS with a single path. Chooses among the shortest paths by the hub scores of their pages.
+	TieBreak TieBreak `protobuf:"varint,10,opt,name=tie_break,json=tieBreak,proto3,enum=wikigraph.TieBreak" json:"tie_break,omitempty"`
+}
+
+func (x *FindShortestPathRequest) Reset() {
+	*x = FindShortestPathRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindShortestPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindShortestPathRequest) ProtoMessage() {}
+
+func (x *FindShortestPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindShortestPathRequest.ProtoReflect.Descriptor instead.
+func (*FindShortestPathRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FindShortestPathRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FindShortestPathRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *FindShortestPathRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *FindShortestPathRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *FindShortestPathRequest) GetMode() SearchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SearchMode_SEARCH_MODE_UNSPECIFIED
+}
+
+func (x *FindShortestPathRequest) GetProveOptimal() bool {
+	if x != nil {
+		return x.ProveOptimal
+	}
+	return false
+}
+
+func (x *FindShortestPathRequest) GetNumPaths() uint32 {
+	if x != nil {
+		return x.NumPaths
+	}
+	return 0
+}
+
+func (x *FindShortestPathRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+func (x *FindShortestPathRequest) GetAvoidHubs() uint32 {
+	if x != nil {
+		return x.AvoidHubs
+	}
+	return 0
+}
+
+func (x *FindShortestPathRequest) GetTieBreak() TieBreak {
+	if x != nil {
+		return x.TieBreak
+	}
+	return TieBreak_TIE_BREAK_UNSPECIFIED
+}
+
+type FindShortestPathResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+}
+
+func (x *FindShortestPathResponse) Reset() {
+	*x = FindShortestPathResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindShortestPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindShortestPathResponse) ProtoMessage() {}
+
+func (x *FindShortestPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindShortestPathResponse.ProtoReflect.Descriptor instead.
+func (*FindShortestPathResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FindShortestPathResponse) GetTaskId() *TaskId {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTaskRequest) GetTaskId() *TaskId {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type GetDistanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Optional. If set, the target is reported as unreachable if it's farther than this.
+	// Cannot be more than the limit of the workers.
+	MaxDistance uint32 `protobuf:"varint,3,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	// Optional. Defaults to NORMAL.
+	Priority Priority `protobuf:"varint,4,opt,name=priority,proto3,enum=wikigraph.Priority" json:"priority,omitempty"`
+}
+
+func (x *GetDistanceRequest) Reset() {
+	*x = GetDistanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDistanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDistanceRequest) ProtoMessage() {}
+
+func (x *GetDistanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDistanceRequest.ProtoReflect.Descriptor instead.
+func (*GetDistanceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetDistanceRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetDistanceRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetDistanceRequest) GetMaxDistance() uint32 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+func (x *GetDistanceRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+type GetDistanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Set if the distance is not cached and a task is enqueued.
+	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Set if the distance is cached.
+	Result *DistanceResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *GetDistanceResponse) Reset() {
+	*x = GetDistanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDistanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDistanceResponse) ProtoMessage() {}
+
+func (x *GetDistanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDistanceResponse.ProtoReflect.Descriptor instead.
+func (*GetDistanceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetDistanceResponse) GetTaskId() *TaskId {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+func (x *GetDistanceResponse) GetResult() *DistanceResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GetNeighborsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page string `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Optional. Defaults to OUTGOING.
+	Direction LinkDirection `protobuf:"varint,2,opt,name=direction,proto3,enum=wikigraph.LinkDirection" json:"direction,omitempty"`
+	// Optional. Only pages of these namespaces are listed, all namespaces if empty.
+	Namespaces []int32 `protobuf:"varint,3,rep,packed,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// Optional. Defaults to 100, cannot be more than 500.
+	PageSize uint32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. The next_page_token of the previous response to continue listing.
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *GetNeighborsRequest) Reset() {
+	*x = GetNeighborsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNeighborsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNeighborsRequest) ProtoMessage() {}
+
+func (x *GetNeighborsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNeighborsRequest.ProtoReflect.Descriptor instead.
+func (*GetNeighborsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetNeighborsRequest) GetPage() string {
+	if x != nil {
+		return x.Page
+	}
+	return ""
+}
+
+func (x *GetNeighborsRequest) GetDirection() LinkDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return LinkDirection_LINK_DIRECTION_UNSPECIFIED
+}
+
+func (x *GetNeighborsRequest) GetNamespaces() []int32 {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *GetNeighborsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetNeighborsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetNeighborsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Links []*Link `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	// Empty if there are no more links.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetNeighborsResponse) Reset() {
+	*x = GetNeighborsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNeighborsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNeighborsResponse) ProtoMessage() {}
+
+func (x *GetNeighborsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNeighborsResponse.ProtoReflect.Descriptor instead.
+func (*GetNeighborsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetNeighborsResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *GetNeighborsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetNeighborhoodRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page string `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Number of clicks from the page. Cannot be more than the limit of the workers.
+	Hops uint32 `protobuf:"varint,2,opt,name=hops,proto3" json:"hops,omitempty"`
+	// Optional. Defaults to NORMAL.
+	Priority Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=wikigraph.Priority" json:"priority,omitempty"`
+}
+
+func (x *GetNeighborhoodRequest) Reset() {
+	*x = GetNeighborhoodRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNeighborhoodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNeighborhoodRequest) ProtoMessage() {}
+
+func (x *GetNeighborhoodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNeighborhoodRequest.ProtoReflect.Descriptor instead.
+func (*GetNeighborhoodRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetNeighborhoodRequest) GetPage() string {
+	if x != nil {
+		return x.Page
+	}
+	return ""
+}
+
+func (x *GetNeighborhoodRequest) GetHops() uint32 {
+	if x != nil {
+		return x.Hops
+	}
+	return 0
+}
+
+func (x *GetNeighborhoodRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+type GetNeighborhoodResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+}
+
+func (x *GetNeighborhoodResponse) Reset() {
+	*x = GetNeighborhoodResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNeighborhoodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNeighborhoodResponse) ProtoMessage() {}
+
+func (x *GetNeighborhoodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNeighborhoodResponse.ProtoReflect.Descriptor instead.
+func (*GetNeighborhoodResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetNeighborhoodResponse) GetTaskId() *TaskId {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+type VerifyTaskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskId *TaskId `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Optional. If set and the path is broken, the search is run again in a new task.
+	RerunIfInvalid bool `protobuf:"varint,2,opt,name=rerun_if_invalid,json=rerunIfInvalid,proto3" json:"rerun_if_invalid,omitempty"`
+}
+
+func (x *VerifyTaskRequest) Reset() {
+	*x = VerifyTaskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTaskRequest) ProtoMessage() {}
+
+func (x *VerifyTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTaskRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerifyTaskRequest) GetTaskId() *TaskId {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+func (x *VerifyTaskRequest) GetRerunIfInvalid() bool {
+	if x != nil {
+		return x.RerunIfInvalid
+	}
+	return false
+}
+
+type VerifyTaskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Verification *PathVerification `protobuf:"bytes,1,opt,name=verification,proto3" json:"verification,omitempty"`
+}
+
+func (x *VerifyTaskResponse) Reset() {
+	*x = VerifyTaskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTaskResponse) ProtoMessage() {}
+
+func (x *VerifyTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTaskResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTaskResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyTaskResponse) GetVerification() *PathVerification {
+	if x != nil {
+		return x.Verification
+	}
+	return nil
+}
+
+type Submission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Canonical titles of the submitted pages.
+	Pages []string `protobuf:"bytes,3,rep,name=pages,proto3" json:"pages,omitempty"`
+	// False if some page doesn't link to the next one, invalid_hop is the first such hop.
+	Valid           bool   `protobuf:"varint,4,opt,name=valid,proto3" json:"valid,omitempty"`
+	InvalidHop      *Hop   `protobuf:"bytes,5,opt,name=invalid_hop,json=invalidHop,proto3" json:"invalid_hop,omitempty"`
+	InvalidHopIndex uint32 `protobuf:"varint,6,opt,name=invalid_hop_index,json=invalidHopIndex,proto3" json:"invalid_hop_index,omitempty"`
+	// False while the shortest distance is being computed by the optimal_task_id task.
+	Scored bool `protobuf:"varint,7,opt,name=scored,proto3" json:"scored,omitempty"`
+	// From 0 to 100: 100 for a path as short as the shortest one, proportionally less for longer ones,
+	// 0 for invalid paths.
+	Score           uint32 `protobuf:"varint,8,opt,name=score,proto3" json:"score,omitempty"`
+	OptimalDistance uint32 `protobuf:"varint,9,opt,name=optimal_distance,json=optimalDistance,proto3" json:"optimal_distance,omitempty"`
+	// True if the shortest path is longer than the search limit, and optimal_distance is its lower bound.
+	Approximate   bool    `protobuf:"varint,10,opt,name=approximate,proto3" json:"approximate,omitempty"`
+	OptimalTaskId *TaskId `protobuf:"bytes,11,opt,name=optimal_task_id,json=optimalTaskId,proto3" json:"optimal_task_id,omitempty"`
+}
+
+func (x *Submission) Reset() {
+	*x = Submission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Submission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Submission) ProtoMessage() {}
+
+func (x *Submission) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Submission.ProtoReflect.Descriptor instead.
+func (*Submission) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Submission) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Submission) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Submission) GetPages() []string {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *Submission) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *Submission) GetInvalidHop() *Hop {
+	if x != nil {
+		return x.InvalidHop
+	}
+	return nil
+}
+
+func (x *Submission) GetInvalidHopIndex() uint32 {
+	if x != nil {
+		return x.InvalidHopIndex
+	}
+	return 0
+}
+
+func (x *Submission) GetScored() bool {
+	if x != nil {
+		return x.Scored
+	}
+	return false
+}
+
+func (x *Submission) GetScore() uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Submission) GetOptimalDistance() uint32 {
+	if x != nil {
+		return x.OptimalDistance
+	}
+	return 0
+}
+
+func (x *Submission) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+func (x *Submission) GetOptimalTaskId() *TaskId {
+	if x != nil {
+		return x.OptimalTaskId
+	}
+	return nil
+}
+
+type SubmitPathRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Titles of the pages from the start page to the target one, up to 50.
+	Pages []string `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	// Optional. Priority of the task computing the shortest distance. Defaults to NORMAL.
+	Priority Priority `protobuf:"varint,2,opt,name=priority,proto3,enum=wikigraph.Priority" json:"priority,omitempty"`
+}
+
+func (x *SubmitPathRequest) Reset() {
+	*x = SubmitPathRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPathRequest) ProtoMessage() {}
+
+func (x *SubmitPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPathRequest.ProtoReflect.Descriptor instead.
+func (*SubmitPathRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SubmitPathRequest) GetPages() []string {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *SubmitPathRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+type SubmitPathResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Submission *Submission `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+}
+
+func (x *SubmitPathResponse) Reset() {
+	*x = SubmitPathResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPathResponse) ProtoMessage() {}
+
+func (x *SubmitPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPathResponse.ProtoReflect.Descriptor instead.
+func (*SubmitPathResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SubmitPathResponse) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+type GetSubmissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubmissionId string `protobuf:"bytes,1,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+}
+
+func (x *GetSubmissionRequest) Reset() {
+	*x = GetSubmissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionRequest) ProtoMessage() {}
+
+func (x *GetSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetSubmissionRequest) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+type GetSubmissionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Submission *Submission `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+}
+
+func (x *GetSubmissionResponse) Reset() {
+	*x = GetSubmissionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubmissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionResponse) ProtoMessage() {}
+
+func (x *GetSubmissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionResponse.ProtoReflect.Descriptor instead.
+func (*GetSubmissionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetSubmissionResponse) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+type Race struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Hops are not accepted after this moment.
+	EndsAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Over   bool                   `protobuf:"varint,4,opt,name=over,proto3" json:"over,omitempty"`
+	// Canonical titles of the pages.
+	StartPage  string `protobuf:"bytes,5,opt,name=start_page,json=startPage,proto3" json:"start_page,omitempty"`
+	TargetPage string `protobuf:"bytes,6,opt,name=target_page,json=targetPage,proto3" json:"target_page,omitempty"`
+	// False while the shortest distance is being computed by the optimal_task_id task.
+	OptimalKnown    bool   `protobuf:"varint,7,opt,name=optimal_known,json=optimalKnown,proto3" json:"optimal_known,omitempty"`
+	OptimalDistance uint32 `protobuf:"varint,8,opt,name=optimal_distance,json=optimalDistance,proto3" json:"optimal_distance,omitempty"`
+	// True if the shortest path is longer than the search limit, and optimal_distance is its lower bound.
+	Approximate   bool    `protobuf:"varint,9,opt,name=approximate,proto3" json:"approximate,omitempty"`
+	OptimalTaskId *TaskId `protobuf:"bytes,10,opt,name=optimal_task_id,json=optimalTaskId,proto3" json:"optimal_task_id,omitempty"`
+}
+
+func (x *Race) Reset() {
+	*x = Race{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Race) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Race) ProtoMessage() {}
+
+func (x *Race) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Race.ProtoReflect.Descriptor instead.
+func (*Race) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Race) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Race) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Race) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Race) GetOver() bool {
+	if x != nil {
+		return x.Over
+	}
+	return false
+}
+
+func (x *Race) GetStartPage() string {
+	if x != nil {
+		return x.StartPage
+	}
+	return ""
+}
+
+func (x *Race) GetTargetPage() string {
+	if x != nil {
+		return x.TargetPage
+	}
+	return ""
+}
+
+func (x *Race) GetOptimalKnown() bool {
+	if x != nil {
+		return x.OptimalKnown
+	}
+	return false
+}
+
+func (x *Race) GetOptimalDistance() uint32 {
+	if x != nil {
+		return x.OptimalDistance
+	}
+	return 0
+}
+
+func (x *Race) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+func (x *Race) GetOptimalTaskId() *TaskId {
+	if x != nil {
+		return x.OptimalTaskId
+	}
+	return nil
+}
+
+type RacePlayer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JoinedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	// Pages the player has clicked through, starting with the start page of the race.
+	Path       []string               `protobuf:"bytes,3,rep,name=path,proto3" json:"path,omitempty"`
+	Hops       uint32                 `protobuf:"varint,4,opt,name=hops,proto3" json:"hops,omitempty"`
+	Finished   bool                   `protobuf:"varint,5,opt,name=finished,proto3" json:"finished,omitempty"`
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+}
+
+func (x *RacePlayer) Reset() {
+	*x = RacePlayer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RacePlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RacePlayer) ProtoMessage() {}
+
+func (x *RacePlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RacePlayer.ProtoReflect.Descriptor instead.
+func (*RacePlayer) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RacePlayer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RacePlayer) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+func (x *RacePlayer) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *RacePlayer) GetHops() uint32 {
+	if x != nil {
+		return x.Hops
+	}
+	return 0
+}
+
+func (x *RacePlayer) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *RacePlayer) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank   uint32      `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Player *RacePlayer `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	// Set for finished players once the shortest distance is known, computed as for submitted paths.
+	Scored bool   `protobuf:"varint,3,opt,name=scored,proto3" json:"scored,omitempty"`
+	Score  uint32 `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LeaderboardEntry) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetPlayer() *RacePlayer {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetScored() bool {
+	if x != nil {
+		return x.Scored
+	}
+	return false
+}
+
+func (x *LeaderboardEntry) GetScore() uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// Finished players are ranked by the number of hops and then by the finish time.
+// Players still racing follow them in the order of joining.
+type Leaderboard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Race    *Race               `protobuf:"bytes,1,opt,name=race,proto3" json:"race,omitempty"`
+	Entries []*LeaderboardEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *Leaderboard) Reset() {
+	*x = Leaderboard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Leaderboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leaderboard) ProtoMessage() {}
+
+func (x *Leaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leaderboard.ProtoReflect.Descriptor instead.
+func (*Leaderboard) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Leaderboard) GetRace() *Race {
+	if x != nil {
+		return x.Race
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type CreateRaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Optional. Random articles are picked if empty.
+	StartPage  string `protobuf:"bytes,1,opt,name=start_page,json=startPage,proto3" json:"start_page,omitempty"`
+	TargetPage string `protobuf:"bytes,2,opt,name=target_page,json=targetPage,proto3" json:"target_page,omitempty"`
+	// Optional. Defaults to 10 minutes, cannot be more than 24 hours.
+	Duration *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *CreateRaceRequest) Reset() {
+	*x = CreateRaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRaceRequest) ProtoMessage() {}
+
+func (x *CreateRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateRaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateRaceRequest) GetStartPage() string {
+	if x != nil {
+		return x.StartPage
+	}
+	return ""
+}
+
+func (x *CreateRaceRequest) GetTargetPage() string {
+	if x != nil {
+		return x.TargetPage
+	}
+	return ""
+}
+
+func (x *CreateRaceRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type CreateRaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Race *Race `protobuf:"bytes,1,opt,name=race,proto3" json:"race,omitempty"`
+}
+
+func (x *CreateRaceResponse) Reset() {
+	*x = CreateRaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRaceResponse) ProtoMessage() {}
+
+func (x *CreateRaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateRaceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateRaceResponse) GetRace() *Race {
+	if x != nil {
+		return x.Race
+	}
+	return nil
+}
+
+type GetRaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RaceId string `protobuf:"bytes,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+}
+
+func (x *GetRaceRequest) Reset() {
+	*x = GetRaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaceRequest) ProtoMessage() {}
+
+func (x *GetRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaceRequest.ProtoReflect.Descriptor instead.
+func (*GetRaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetRaceRequest) GetRaceId() string {
+	if x != nil {
+		return x.RaceId
+	}
+	return ""
+}
+
+type GetRaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Leaderboard *Leaderboard `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+}
+
+func (x *GetRaceResponse) Reset() {
+	*x = GetRaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaceResponse) ProtoMessage() {}
+
+func (x *GetRaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaceResponse.ProtoReflect.Descriptor instead.
+func (*GetRaceResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetRaceResponse) GetLeaderboard() *Leaderboard {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+type JoinRace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RaceId string `protobuf:"bytes,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// Optional. Identifies the player within the race, defaults to the caller.
+	// Joining with a taken name continues the race of that player.
+	Player string `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+}
+
+func (x *JoinRace) Reset() {
+	*x = JoinRace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRace) ProtoMessage() {}
+
+func (x *JoinRace) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRace.ProtoReflect.Descriptor instead.
+func (*JoinRace) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *JoinRace) GetRaceId() string {
+	if x != nil {
+		return x.RaceId
+	}
+	return ""
+}
+
+func (x *JoinRace) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+type RaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Action:
+	//	*RaceRequest_Join
+	//	*RaceRequest_Hop
+	Action isRaceRequest_Action `protobuf_oneof:"action"`
+}
+
+func (x *RaceRequest) Reset() {
+	*x = RaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaceRequest) ProtoMessage() {}
+
+func (x *RaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaceRequest.ProtoReflect.Descriptor instead.
+func (*RaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{37}
+}
+
+func (m *RaceRequest) GetAction() isRaceRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (x *RaceRequest) GetJoin() *JoinRace {
+	if x, ok := x.GetAction().(*RaceRequest_Join); ok {
+		return x.Join
+	}
+	return nil
+}
+
+func (x *RaceRequest) GetHop() string {
+	if x, ok := x.GetAction().(*RaceRequest_Hop); ok {
+		return x.Hop
+	}
+	return ""
+}
+
+type isRaceRequest_Action interface {
+	isRaceRequest_Action()
+}
+
+type RaceRequest_Join struct {
+	// Must be the first message of the stream.
+	Join *JoinRace `protobuf:"bytes,1,opt,name=join,proto3,oneof"`
+}
+
+type RaceRequest_Hop struct {
+	// Title of the page to click through to from the current page.
+	Hop string `protobuf:"bytes,2,opt,name=hop,proto3,oneof"`
+}
+
+func (*RaceRequest_Join) isRaceRequest_Action() {}
+
+func (*RaceRequest_Hop) isRaceRequest_Action() {}
+
+type HopResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted bool `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Explains why the hop was rejected.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The state of the player after the hop.
+	Player *RacePlayer `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+}
+
+func (x *HopResult) Reset() {
+	*x = HopResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HopResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HopResult) ProtoMessage() {}
+
+func (x *HopResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HopResult.ProtoReflect.Descriptor instead.
+func (*HopResult) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *HopResult) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *HopResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *HopResult) GetPlayer() *RacePlayer {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type RaceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*RaceEvent_Joined
+	//	*RaceEvent_Hop
+	//	*RaceEvent_Leaderboard
+	Event isRaceEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *RaceEvent) Reset() {
+	*x = RaceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RaceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaceEvent) ProtoMessage() {}
+
+func (x *RaceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaceEvent.ProtoReflect.Descriptor instead.
+func (*RaceEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{39}
+}
+
+func (m *RaceEvent) GetEvent() isRaceEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *RaceEvent) GetJoined() *RacePlayer {
+	if x, ok := x.GetEvent().(*RaceEvent_Joined); ok {
+		return x.Joined
+	}
+	return nil
+}
+
+func (x *RaceEvent) GetHop() *HopResult {
+	if x, ok := x.GetEvent().(*RaceEvent_Hop); ok {
+		return x.Hop
+	}
+	return nil
+}
+
+func (x *RaceEvent) GetLeaderboard() *Leaderboard {
+	if x, ok := x.GetEvent().(*RaceEvent_Leaderboard); ok {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+type isRaceEvent_Event interface {
+	isRaceEvent_Event()
+}
+
+type RaceEvent_Joined struct {
+	// Sent in reply to the join message.
+	Joined *RacePlayer `protobuf:"bytes,1,opt,name=joined,proto3,oneof"`
+}
+
+type RaceEvent_Hop struct {
+	// Sent in reply to every hop.
+	Hop *HopResult `protobuf:"bytes,2,opt,name=hop,proto3,oneof"`
+}
+
+type RaceEvent_Leaderboard struct {
+	// Sent whenever a player joins the race or makes a hop.
+	Leaderboard *Leaderboard `protobuf:"bytes,3,opt,name=leaderboard,proto3,oneof"`
+}
+
+func (*RaceEvent_Joined) isRaceEvent_Event() {}
+
+func (*RaceEvent_Hop) isRaceEvent_Event() {}
+
+func (*RaceEvent_Leaderboard) isRaceEvent_Event() {}
+
+type SamplingRun struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set when none of the distance tasks are pending.
+	Finished    bool                   `protobuf:"varint,3,opt,name=finished,proto3" json:"finished,omitempty"`
+	FinishedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Pairs       uint32                 `protobuf:"varint,5,opt,name=pairs,proto3" json:"pairs,omitempty"`
+	MaxDistance uint32                 `protobuf:"varint,6,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	// Pairs whose distance tasks are done or failed. The rest are still being processed.
+	Done   uint32 `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	Failed uint32 `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
+	// histogram[d] is the number of pairs at distance d.
+	Histogram []uint32 `protobuf:"varint,9,rep,packed,name=histogram,proto3" json:"histogram,omitempty"`
+	Reachable uint32   `protobuf:"varint,10,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// Pairs with no path within searched_distance clicks, and their share among the pairs with a definite answer.
+	Unreachable      uint32  `protobuf:"varint,11,opt,name=unreachable,proto3" json:"unreachable,omitempty"`
+	UnreachableRatio float64 `protobuf:"fixed64,12,opt,name=unreachable_ratio,json=unreachableRatio,proto3" json:"unreachable_ratio,omitempty"`
+	SearchedDistance uint32  `protobuf:"varint,13,opt,name=searched_distance,json=searchedDistance,proto3" json:"searched_distance,omitempty"`
+	// Pairs with no path found because some pages could not be fetched.
+	Incomplete uint32 `protobuf:"varint,14,opt,name=incomplete,proto3" json:"incomplete,omitempty"`
+	// Over the reachable pairs.
+	MeanDistance   float64 `protobuf:"fixed64,15,opt,name=mean_distance,json=meanDistance,proto3" json:"mean_distance,omitempty"`
+	MedianDistance uint32  `protobuf:"varint,16,opt,name=median_distance,json=medianDistance,proto3" json:"median_distance,omitempty"`
+}
+
+func (x *SamplingRun) Reset() {
+	*x = SamplingRun{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SamplingRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplingRun) ProtoMessage() {}
+
+func (x *SamplingRun) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplingRun.ProtoReflect.Descriptor instead.
+func (*SamplingRun) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SamplingRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SamplingRun) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SamplingRun) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *SamplingRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *SamplingRun) GetPairs() uint32 {
+	if x != nil {
+		return x.Pairs
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetMaxDistance() uint32 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetDone() uint32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetHistogram() []uint32 {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *SamplingRun) GetReachable() uint32 {
+	if x != nil {
+		return x.Reachable
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetUnreachable() uint32 {
+	if x != nil {
+		return x.Unreachable
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetUnreachableRatio() float64 {
+	if x != nil {
+		return x.UnreachableRatio
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetSearchedDistance() uint32 {
+	if x != nil {
+		return x.SearchedDistance
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetIncomplete() uint32 {
+	if x != nil {
+		return x.Incomplete
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetMeanDistance() float64 {
+	if x != nil {
+		return x.MeanDistance
+	}
+	return 0
+}
+
+func (x *SamplingRun) GetMedianDistance() uint32 {
+	if x != nil {
+		return x.MedianDistance
+	}
+	return 0
+}
+
+type StartSamplingRunRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of random pairs, up to 1000. The distance tasks of sampling runs have their own limit
+	// of unfinished tasks of the caller, the other tasks of the caller are not counted towards it.
+	Pairs uint32 `protobuf:"varint,1,opt,name=pairs,proto3" json:"pairs,omitempty"`
+	// Optional. Distances are searched within this many clicks, not farther than the worker limit.
+	MaxDistance uint32 `protobuf:"varint,2,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	// Optional. Priority of the distance tasks. Defaults to BATCH.
+	Priority Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=wikigraph.Priority" json:"priority,omitempty"`
+}
+
+func (x *StartSamplingRunRequest) Reset() {
+	*x = StartSamplingRunRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartSamplingRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSamplingRunRequest) ProtoMessage() {}
+
+func (x *StartSamplingRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSamplingRunRequest.ProtoReflect.Descriptor instead.
+func (*StartSamplingRunRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StartSamplingRunRequest) GetPairs() uint32 {
+	if x != nil {
+		return x.Pairs
+	}
+	return 0
+}
+
+func (x *StartSamplingRunRequest) GetMaxDistance() uint32 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+func (x *StartSamplingRunRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+type StartSamplingRunResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Run *SamplingRun `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+}
+
+func (x *StartSamplingRunResponse) Reset() {
+	*x = StartSamplingRunResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartSamplingRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSamplingRunResponse) ProtoMessage() {}
+
+func (x *StartSamplingRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSamplingRunResponse.ProtoReflect.Descriptor instead.
+func (*StartSamplingRunResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *StartSamplingRunResponse) GetRun() *SamplingRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+type GetSamplingRunRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+}
+
+func (x *GetSamplingRunRequest) Reset() {
+	*x = GetSamplingRunRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSamplingRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSamplingRunRequest) ProtoMessage() {}
+
+func (x *GetSamplingRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSamplingRunRequest.ProtoReflect.Descriptor instead.
+func (*GetSamplingRunRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetSamplingRunRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type GetSamplingRunResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Run *SamplingRun `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+}
+
+func (x *GetSamplingRunResponse) Reset() {
+	*x = GetSamplingRunResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSamplingRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSamplingRunResponse) ProtoMessage() {}
+
+func (x *GetSamplingRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSamplingRunResponse.ProtoReflect.Descriptor instead.
+func (*GetSamplingRunResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetSamplingRunResponse) GetRun() *SamplingRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+type ListSamplingRunsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Optional. Defaults to 20, cannot be more than 100.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListSamplingRunsRequest) Reset() {
+	*x = ListSamplingRunsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSamplingRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSamplingRunsRequest) ProtoMessage() {}
+
+func (x *ListSamplingRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSamplingRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListSamplingRunsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListSamplingRunsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSamplingRunsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Runs []*SamplingRun `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+}
+
+func (x *ListSamplingRunsResponse) Reset() {
+	*x = ListSamplingRunsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSamplingRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSamplingRunsResponse) ProtoMessage() {}
+
+func (x *ListSamplingRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSamplingRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListSamplingRunsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListSamplingRunsResponse) GetRuns() []*SamplingRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type Hub struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page string `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// 1 for the biggest hub.
+	Rank uint32 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Value of the metric the pages were ranked by.
+	Score       float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Pagerank    float64                `protobuf:"fixed64,4,opt,name=pagerank,proto3" json:"pagerank,omitempty"`
+	InDegree    uint32                 `protobuf:"varint,5,opt,name=in_degree,json=inDegree,proto3" json:"in_degree,omitempty"`
+	Betweenness float64                `protobuf:"fixed64,6,opt,name=betweenness,proto3" json:"betweenness,omitempty"`
+	ComputedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+}
+
+func (x *Hub) Reset() {
+	*x = Hub{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hub) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hub) ProtoMessage() {}
+
+func (x *Hub) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hub.ProtoReflect.Descriptor instead.
+func (*Hub) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Hub) GetPage() string {
+	if x != nil {
+		return x.Page
+	}
+	return ""
+}
+
+func (x *Hub) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Hub) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Hub) GetPagerank() float64 {
+	if x != nil {
+		return x.Pagerank
+	}
+	return 0
+}
+
+func (x *Hub) GetInDegree() uint32 {
+	if x != nil {
+		return x.InDegree
+	}
+	return 0
+}
+
+func (x *Hub) GetBetweenness() float64 {
+	if x != nil {
+		return x.Betweenness
+	}
+	return 0
+}
+
+func (x *Hub) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+type ListHubsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Optional. Defaults to 100, cannot be more than 1000.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListHubsRequest) Reset() {
+	*x = ListHubsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHubsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHubsRequest) ProtoMessage() {}
+
+func (x *ListHubsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHubsRequest.ProtoReflect.Descriptor instead.
+func (*ListHubsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListHubsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListHubsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hubs []*Hub `protobuf:"bytes,1,rep,name=hubs,proto3" json:"hubs,omitempty"`
+}
+
+func (x *ListHubsResponse) Reset() {
+	*x = ListHubsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListHubsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHubsResponse) ProtoMessage() {}
+
+func (x *ListHubsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHubsResponse.ProtoReflect.Descriptor instead.
+func (*ListHubsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListHubsResponse) GetHubs() []*Hub {
+	if x != nil {
+		return x.Hubs
+	}
+	return nil
+}
+
+type TaskStats_Layer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Distance uint32 `protobuf:"varint,1,opt,name=distance,proto3" json:"distance,omitempty"`
+	// Number of pages to be expanded.
+	FrontierSize int64 `protobuf:"varint,2,opt,name=frontier_size,json=frontierSize,proto3" json:"frontier_size,omitempty"`
+	PagesFetched int64 `protobuf:"varint,3,opt,name=pages_fetched,json=pagesFetched,proto3" json:"pages_fetched,omitempty"`
+	FetchErrors  int64 `protobuf:"varint,4,opt,name=fetch_errors,json=fetchErrors,proto3" json:"fetch_errors,omitempty"`
+	// Number of new pages found in the layer.
+	Discovered int64                `protobuf:"varint,5,opt,name=discovered,proto3" json:"discovered,omitempty"`
+	Elapsed    *durationpb.Duration `protobuf:"bytes,6,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	// Pages that could not be fetched after all retries.
+	FailedPages int64 `protobuf:"varint,7,opt,name=failed_pages,json=failedPages,proto3" json:"failed_pages,omitempty"`
+	// Only for DISTANCE tasks: "forward" layers follow links from the start page,
+	// "backward" layers follow links to the target.
+	Direction string `protobuf:"bytes,8,opt,name=direction,proto3" json:"direction,omitempty"`
+}
+
+func (x *TaskStats_Layer) Reset() {
+	*x = TaskStats_Layer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskStats_Layer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStats_Layer) ProtoMessage() {}
+
+func (x *TaskStats_Layer) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_wikigraphpb_wikigraph_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStats_Layer.ProtoReflect.Descriptor instead.
+func (*TaskStats_Layer) Descriptor() ([]byte, []int) {
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *TaskStats_Layer) GetDistance() uint32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *TaskStats_Layer) GetFrontierSize() int64 {
+	if x != nil {
+		return x.FrontierSize
+	}
+	return 0
+}
+
+func (x *TaskStats_Layer) GetPagesFetched() int64 {
+	if x != nil {
+		return x.PagesFetched
+	}
+	return 0
+}
+
+func (x *TaskStats_Layer) GetFetchErrors() int64 {
+	if x != nil {
+		return x.FetchErrors
+	}
+	return 0
+}
+
+func (x *TaskStats_Layer) GetDiscovered() int64 {
+	if x != nil {
+		return x.Discovered
+	}
+	return 0
+}
+
+func (x *TaskStats_Layer) GetElapsed() *durationpb.Duration {
+	if x != nil {
+		return x.Elapsed
+	}
+	return nil
+}
+
+func (x *TaskStats_Layer) GetFailedPages() int64 {
+	if x != nil {
+		return x.FailedPages
+	}
+	return 0
+}
+
+func (x *TaskStats_Layer) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+var File_pkg_wikigraphpb_wikigraph_proto protoreflect.FileDescriptor
+
+var file_pkg_wikigraphpb_wikigraph_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x70, 0x6b, 0x67, 0x2f, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x70,
+	0x62, 0x2f, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x18, 0x0a,
+	0x06, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xc0, 0x07, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x21, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x05, 0x61,
+	0x73, 0x5f, 0x6f, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x2f, 0x0a, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2a, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x15, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x25, 0x0a, 0x05,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x37, 0x0a, 0x0c, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64,
+	0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x2e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x52, 0x0c, 0x6e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x12, 0x35, 0x0a, 0x16, 0x6e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x5f, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6e, 0x65, 0x69, 0x67, 0x68,
+	0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x3d, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x2e, 0x48, 0x6f, 0x70, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3f, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x48, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49,
+	0x4e, 0x47, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49,
+	0x4e, 0x47, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x22, 0xcd, 0x01, 0x0a, 0x10, 0x50,
+	0x61, 0x74, 0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3b, 0x0a, 0x0b, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x12, 0x2f, 0x0a, 0x0b, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x68, 0x6f, 0x70,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x48, 0x6f, 0x70, 0x52, 0x0a, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x6e, 0x48,
+	0x6f, 0x70, 0x73, 0x12, 0x35, 0x0a, 0x0d, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x5f, 0x74, 0x61, 0x73,
+	0x6b, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b,
+	0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x0b, 0x72,
+	0x65, 0x72, 0x75, 0x6e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0x29, 0x0a, 0x03, 0x48, 0x6f,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x6a, 0x0a, 0x0e, 0x48, 0x6f, 0x70, 0x45, 0x78, 0x70, 0x6c,
+	0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x3c, 0x0a, 0x08, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22,
+	0x3a, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x0e,
+	0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x64, 0x44, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0x1c, 0x0a,
+	0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x22, 0xc2, 0x02, 0x0a, 0x0c,
+	0x54, 0x61, 0x73, 0x6b, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x39, 0x0a, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x69,
+	0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66,
+	0x72, 0x6f, 0x6e, 0x74, 0x69, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50,
+	0x61, 0x67, 0x65, 0x73, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70,
+	0x61, 0x67, 0x65, 0x73, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x70, 0x61, 0x67, 0x65, 0x73, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x12, 0x3e, 0x0a, 0x1b, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65,
+	0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x19, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x22, 0xb1, 0x04, 0x0a, 0x09, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x61, 0x67, 0x65, 0x73, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x70, 0x69, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x70,
+	0x69, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6b, 0x69,
+	0x70, 0x70, 0x65, 0x64, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x4c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x1a, 0xa6, 0x02, 0x0a, 0x05,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x69, 0x65, 0x72, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x69,
+	0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f,
+	0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70,
+	0x61, 0x67, 0x65, 0x73, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x66, 0x65, 0x74, 0x63, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x33,
+	0x0a, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x6c, 0x61, 0x70,
+	0x73, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xf7, 0x02, 0x0a, 0x17, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f,
+	0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x2f, 0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6d,
+	0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x61,
+	0x74, 0x68, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x61, 0x76, 0x6f, 0x69, 0x64, 0x5f, 0x68, 0x75, 0x62, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x61, 0x76, 0x6f, 0x69, 0x64, 0x48, 0x75, 0x62, 0x73, 0x12, 0x30, 0x0a, 0x09,
+	0x74, 0x69, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x69, 0x65, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x52, 0x08, 0x74, 0x69, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x22, 0x46,
+	0x0a, 0x18, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61,
+	0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61,
+	0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06,
+	0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b, 0x69,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06, 0x74, 0x61,
+	0x73, 0x6b, 0x49, 0x64, 0x22, 0x36, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0x8c, 0x01, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x74, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x31,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x22, 0xbd, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x36, 0x0a,
+	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x18, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e,
+	0x6b, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x65, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x71, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4e,
+	0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x45, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b,
+	0x49, 0x64, 0x22, 0x69, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x61, 0x73, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x06, 0x74, 0x61, 0x73,
+	0x6b, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x5f, 0x69, 0x66, 0x5f,
+	0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72,
+	0x65, 0x72, 0x75, 0x6e, 0x49, 0x66, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x22, 0x55, 0x0a,
+	0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x69, 0x6b, 0x69,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x96, 0x03, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x2f, 0x0a, 0x0b, 0x69, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x48, 0x6f, 0x70, 0x52,
+	0x0a, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x48, 0x6f, 0x70, 0x12, 0x2a, 0x0a, 0x11, 0x69,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x68, 0x6f, 0x70, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x48,
+	0x6f, 0x70, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x12, 0x39, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x74, 0x61,
+	0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x0d,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0x5a, 0x0a,
+	0x11, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x69, 0x6b,
+	0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x4b, 0x0a, 0x12, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x87, 0x03, 0x0a, 0x04, 0x52, 0x61, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x73, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6f, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6f, 0x76, 0x65, 0x72,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c,
+	0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x12, 0x39, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x74, 0x61,
+	0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x52, 0x0d,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0xda, 0x01,
+	0x0a, 0x0a, 0x52, 0x61, 0x63, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x37, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x68, 0x6f, 0x70,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x3b, 0x0a,
+	0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x10, 0x4c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x52, 0x61, 0x63, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x22, 0x69, 0x0a, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
+	0x23, 0x0a, 0x04, 0x72, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x52, 0x04,
+	0x72, 0x61, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x11,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x04, 0x72, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x52, 0x04, 0x72,
+	0x61, 0x63, 0x65, 0x22, 0x29, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x22, 0x4b,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x38, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0b,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x22, 0x3b, 0x0a, 0x08, 0x4a,
+	0x6f, 0x69, 0x6e, 0x52, 0x61, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x61, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x22, 0x56, 0x0a, 0x0b, 0x52, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x6a, 0x6f, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x61, 0x63, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x6f,
+	0x69, 0x6e, 0x12, 0x12, 0x0a, 0x03, 0x68, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x03, 0x68, 0x6f, 0x70, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x6e, 0x0a, 0x09, 0x48, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61,
+	0x63, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x22, 0xab, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2f,
+	0x0a, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12,
+	0x28, 0x0a, 0x03, 0x68, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x48, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x48, 0x00, 0x52, 0x03, 0x68, 0x6f, 0x70, 0x12, 0x3a, 0x0a, 0x0b, 0x6c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0xbc,
+	0x04, 0x0a, 0x0b, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f,
+	0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63,
+	0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x10, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x61, 0x74,
+	0x69, 0x6f, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x64,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x64, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x65, 0x61, 0x6e, 0x44, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x64,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x6e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x83, 0x01,
+	0x0a, 0x17, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52,
+	0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x69,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x22, 0x44, 0x0a, 0x18, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x28, 0x0a, 0x03, 0x72, 0x75, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e,
+	0x67, 0x52, 0x75, 0x6e, 0x52, 0x03, 0x72, 0x75, 0x6e, 0x22, 0x2e, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x72, 0x75, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x03, 0x72, 0x75, 0x6e, 0x22, 0x2f, 0x0a,
+	0x17, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x46,
+	0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x72, 0x75,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e,
+	0x52, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x03, 0x48, 0x75, 0x62, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x64,
+	0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x44,
+	0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e,
+	0x6e, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x62, 0x65, 0x74, 0x77,
+	0x65, 0x65, 0x6e, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x27, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x75, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x36, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x75, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x22, 0x0a, 0x04, 0x68, 0x75, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x48, 0x75, 0x62, 0x52,
+	0x04, 0x68, 0x75, 0x62, 0x73, 0x2a, 0x4c, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x42,
+	0x41, 0x54, 0x43, 0x48, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c,
+	0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x41, 0x43, 0x54, 0x49, 0x56,
+	0x45, 0x10, 0x03, 0x2a, 0x46, 0x0a, 0x08, 0x54, 0x69, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x12,
+	0x19, 0x0a, 0x15, 0x54, 0x49, 0x45, 0x5f, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52,
+	0x45, 0x46, 0x45, 0x52, 0x5f, 0x48, 0x55, 0x42, 0x53, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x41,
+	0x56, 0x4f, 0x49, 0x44, 0x5f, 0x48, 0x55, 0x42, 0x53, 0x10, 0x02, 0x2a, 0x42, 0x0a, 0x0a, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x41,
+	0x52, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x46, 0x53, 0x10, 0x01, 0x12,
+	0x0e, 0x0a, 0x0a, 0x42, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x02, 0x2a,
+	0x4f, 0x0a, 0x08, 0x54, 0x61, 0x73, 0x6b, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x19, 0x0a, 0x15, 0x54,
+	0x41, 0x53, 0x4b, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x41, 0x54, 0x48, 0x10, 0x01,
+	0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x53, 0x54, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x02, 0x12, 0x10,
+	0x0a, 0x0c, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x48, 0x4f, 0x4f, 0x44, 0x10, 0x03,
+	0x2a, 0x4b, 0x0a, 0x0d, 0x4c, 0x69, 0x6e, 0x6b, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x55, 0x54, 0x47, 0x4f, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x43, 0x4f, 0x4d, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x32, 0xaa, 0x09,
+	0x0a, 0x09, 0x57, 0x69, 0x6b, 0x69, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x5b, 0x0a, 0x10, 0x46,
+	0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x22, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x46, 0x69, 0x6e, 0x64,
+	0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x46, 0x69, 0x6e, 0x64, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x12, 0x19, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61,
+	0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x77, 0x69, 0x6b, 0x69,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4e,
+	0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x12, 0x1e, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x12, 0x21, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x69, 0x67,
+	0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x4e,
+	0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x61, 0x73,
+	0x6b, 0x12, 0x1c, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49,
+	0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x69, 0x6b,
+	0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x77, 0x69, 0x6b,
+	0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a,
+	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x63, 0x65, 0x12, 0x1c, 0x2e, 0x77, 0x69,
+	0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x69, 0x6b, 0x69,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x52,
+	0x61, 0x63, 0x65, 0x12, 0x19, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x52, 0x61,
+	0x63, 0x65, 0x12, 0x16, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x77, 0x69, 0x6b,
+	0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x28, 0x01, 0x30, 0x01, 0x12, 0x5b, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x12, 0x22, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69,
+	0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x55, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
+	0x52, 0x75, 0x6e, 0x12, 0x20, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x75, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x75, 0x62,
+	0x73, 0x12, 0x1a, 0x2e, 0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x48, 0x75, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x77, 0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x75,
+	0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6f, 0x64, 0x74, 0x68, 0x65, 0x2f,
+	0x77, 0x69, 0x6b, 0x69, 0x2d, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x77,
+	0x69, 0x6b, 0x69, 0x67, 0x72, 0x61, 0x70, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_pkg_wikigraphpb_wikigraph_proto_rawDescOnce sync.Once
+	file_pkg_wikigraphpb_wikigraph_proto_rawDescData = file_pkg_wikigraphpb_wikigraph_proto_rawDesc
+)
+
+func file_pkg_wikigraphpb_wikigraph_proto_rawDescGZIP() []byte {
+	file_pkg_wikigraphpb_wikigraph_proto_rawDescOnce.Do(func() {
+		file_pkg_wikigraphpb_wikigraph_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_wikigraphpb_wikigraph_proto_rawDescData)
+	})
+	return file_pkg_wikigraphpb_wikigraph_proto_rawDescData
+}
+
+var file_pkg_wikigraphpb_wikigraph_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_pkg_wikigraphpb_wikigraph_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
+var file_pkg_wikigraphpb_wikigraph_proto_goTypes = []interface{}{
+	(Priority)(0),                    // 0: wikigraph.Priority
+	(TieBreak)(0),                    // 1: wikigraph.TieBreak
+	(SearchMode)(0),                  // 2: wikigraph.SearchMode
+	(TaskKind)(0),                    // 3: wikigraph.TaskKind
+	(LinkDirection)(0),               // 4: wikigraph.LinkDirection
+	(Task_Status)(0),                 // 5: wikigraph.Task.Status
+	(*TaskId)(nil),                   // 6: wikigraph.TaskId
+	(*Task)(nil),                     // 7: wikigraph.Task
+	(*PathVerification)(nil),         // 8: wikigraph.PathVerification
+	(*Hop)(nil),                      // 9: wikigraph.Hop
+	(*HopExplanation)(nil),           // 10: wikigraph.HopExplanation
+	(*Neighbor)(nil),                 // 11: wikigraph.Neighbor
+	(*Link)(nil),                     // 12: wikigraph.Link
+	(*DistanceResult)(nil),           // 13: wikigraph.DistanceResult
+	(*Path)(nil),                     // 14: wikigraph.Path
+	(*TaskProgress)(nil),             // 15: wikigraph.TaskProgress
+	(*TaskStats)(nil),                // 16: wikigraph.TaskStats
+	(*FindShortestPathRequest)(nil),  // 17: wikigraph.FindShortestPathRequest
+	(*FindShortestPathResponse)(nil), // 18: wikigraph.FindShortestPathResponse
+	(*GetTaskRequest)(nil),           // 19: wikigraph.GetTaskRequest
+	(*GetTaskResponse)(nil),          // 20: wikigraph.GetTaskResponse
+	(*GetDistanceRequest)(nil),       // 21: wikigraph.GetDistanceRequest
+	(*GetDistanceResponse)(nil),      // 22: wikigraph.GetDistanceResponse
+	(*GetNeighborsRequest)(nil),      // 23: wikigraph.GetNeighborsRequest
+	(*GetNeighborsResponse)(nil),     // 24: wikigraph.GetNeighborsResponse
+	(*GetNeighborhoodRequest)(nil),   // 25: wikigraph.GetNeighborhoodRequest
+	(*GetNeighborhoodResponse)(nil),  // 26: wikigraph.GetNeighborhoodResponse
+	(*VerifyTaskRequest)(nil),        // 27: wikigraph.VerifyTaskRequest
+	(*VerifyTaskResponse)(nil),       // 28: wikigraph.VerifyTaskResponse
+	(*Submission)(nil),               // 29: wikigraph.Submission
+	(*SubmitPathRequest)(nil),        // 30: wikigraph.SubmitPathRequest
+	(*SubmitPathResponse)(nil),       // 31: wikigraph.SubmitPathResponse
+	(*GetSubmissionRequest)(nil),     // 32: wikigraph.GetSubmissionRequest
+	(*GetSubmissionResponse)(nil),    // 33: wikigraph.GetSubmissionResponse
+	(*Race)(nil),                     // 34: wikigraph.Race
+	(*RacePlayer)(nil),               // 35: wikigraph.RacePlayer
+	(*LeaderboardEntry)(nil),         // 36: wikigraph.LeaderboardEntry
+	(*Leaderboard)(nil),              // 37: wikigraph.Leaderboard
+	(*CreateRaceRequest)(nil),        // 38: wikigraph.CreateRaceRequest
+	(*CreateRaceResponse)(nil),       // 39: wikigraph.CreateRaceResponse
+	(*GetRaceRequest)(nil),           // 40: wikigraph.GetRaceRequest
+	(*GetRaceResponse)(nil),          // 41: wikigraph.GetRaceResponse
+	(*JoinRace)(nil),                 // 42: wikigraph.JoinRace
+	(*RaceRequest)(nil),              // 43: wikigraph.RaceRequest
+	(*HopResult)(nil),                // 44: wikigraph.HopResult
+	(*RaceEvent)(nil),                // 45: wikigraph.RaceEvent
+	(*SamplingRun)(nil),              // 46: wikigraph.SamplingRun
+	(*StartSamplingRunRequest)(nil),  // 47: wikigraph.StartSamplingRunRequest
+	(*StartSamplingRunResponse)(nil), // 48: wikigraph.StartSamplingRunResponse
+	(*GetSamplingRunRequest)(nil),    // 49: wikigraph.GetSamplingRunRequest
+	(*GetSamplingRunResponse)(nil),   // 50: wikigraph.GetSamplingRunResponse
+	(*ListSamplingRunsRequest)(nil),  // 51: wikigraph.ListSamplingRunsRequest
+	(*ListSamplingRunsResponse)(nil), // 52: wikigraph.ListSamplingRunsResponse
+	(*Hub)(nil),                      // 53: wikigraph.Hub
+	(*ListHubsRequest)(nil),          // 54: wikigraph.ListHubsRequest
+	(*ListHubsResponse)(nil),         // 55: wikigraph.ListHubsResponse
+	(*TaskStats_Layer)(nil),          // 56: wikigraph.TaskStats.Layer
+	(*timestamppb.Timestamp)(nil),    // 57: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 58: google.protobuf.Duration
+}
+var file_pkg_wikigraphpb_wikigraph_proto_depIdxs = []int32{
+	6,  // 0: wikigraph.Task.id:type_name -> wikigraph.TaskId
+	5,  // 1: wikigraph.Task.status:type_name -> wikigraph.Task.Status
+	57, // 2: wikigraph.Task.as_of:type_name -> google.protobuf.Timestamp
+	0,  // 3: wikigraph.Task.priority:type_name -> wikigraph.Priority
+	16, // 4: wikigraph.Task.stats:type_name -> wikigraph.TaskStats
+	15, // 5: wikigraph.Task.progress:type_name -> wikigraph.TaskProgress
+	2,  // 6: wikigraph.Task.mode:type_name -> wikigraph.SearchMode
+	14, // 7: wikigraph.Task.paths:type_name -> wikigraph.Path
+	13, // 8: wikigraph.Task.distance_result:type_name -> wikigraph.DistanceResult
+	3,  // 9: wikigraph.Task.kind:type_name -> wikigraph.TaskKind
+	11, // 10: wikigraph.Task.neighborhood:type_name -> wikigraph.Neighbor
+	10, // 11: wikigraph.Task.explanations:type_name -> wikigraph.HopExplanation
+	8,  // 12: wikigraph.Task.verification:type_name -> wikigraph.PathVerification
+	57, // 13: wikigraph.PathVerification.verified_at:type_name -> google.protobuf.Timestamp
+	9,  // 14: wikigraph.PathVerification.broken_hops:type_name -> wikigraph.Hop
+	6,  // 15: wikigraph.PathVerification.rerun_task_id:type_name -> wikigraph.TaskId
+	57, // 16: wikigraph.TaskProgress.updated_at:type_name -> google.protobuf.Timestamp
+	58, // 17: wikigraph.TaskStats.elapsed:type_name -> google.protobuf.Duration
+	56, // 18: wikigraph.TaskStats.layers:type_name -> wikigraph.TaskStats.Layer
+	57, // 19: wikigraph.FindShortestPathRequest.as_of:type_name -> google.protobuf.Timestamp
+	0,  // 20: wikigraph.FindShortestPathRequest.priority:type_name -> wikigraph.Priority
+	2,  // 21: wikigraph.FindShortestPathRequest.mode:type_name -> wikigraph.SearchMode
+	1,  // 22: wikigraph.FindShortestPathRequest.tie_break:type_name -> wikigraph.TieBreak
+	6,  // 23: wikigraph.FindShortestPathResponse.task_id:type_name -> wikigraph.TaskId
+	6,  // 24: wikigraph.GetTaskRequest.task_id:type_name -> wikigraph.TaskId
+	7,  // 25: wikigraph.GetTaskResponse.task:type_name -> wikigraph.Task
+	0,  // 26: wikigraph.GetDistanceRequest.priority:type_name -> wikigraph.Priority
+	6,  // 27: wikigraph.GetDistanceResponse.task_id:type_name -> wikigraph.TaskId
+	13, // 28: wikigraph.GetDistanceResponse.result:type_name -> wikigraph.DistanceResult
+	4,  // 29: wikigraph.GetNeighborsRequest.direction:type_name -> wikigraph.LinkDirection
+	12, // 30: wikigraph.GetNeighborsResponse.links:type_name -> wikigraph.Link
+	0,  // 31: wikigraph.GetNeighborhoodRequest.priority:type_name -> wikigraph.Priority
+	6,  // 32: wikigraph.GetNeighborhoodResponse.task_id:type_name -> wikigraph.TaskId
+	6,  // 33: wikigraph.VerifyTaskRequest.task_id:type_name -> wikigraph.TaskId
+	8,  // 34: wikigraph.VerifyTaskResponse.verification:type_name -> wikigraph.PathVerification
+	57, // 35: wikigraph.Submission.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 36: wikigraph.Submission.invalid_hop:type_name -> wikigraph.Hop
+	6,  // 37: wikigraph.Submission.optimal_task_id:type_name -> wikigraph.TaskId
+	0,  // 38: wikigraph.SubmitPathRequest.priority:type_name -> wikigraph.Priority
+	29, // 39: wikigraph.SubmitPathResponse.submission:type_name -> wikigraph.Submission
+	29, // 40: wikigraph.GetSubmissionResponse.submission:type_name -> wikigraph.Submission
+	57, // 41: wikigraph.Race.created_at:type_name -> google.protobuf.Timestamp
+	57, // 42: wikigraph.Race.ends_at:type_name -> google.protobuf.Timestamp
+	6,  // 43: wikigraph.Race.optimal_task_id:type_name -> wikigraph.TaskId
+	57, // 44: wikigraph.RacePlayer.joined_at:type_name -> google.protobuf.Timestamp
+	57, // 45: wikigraph.RacePlayer.finished_at:type_name -> google.protobuf.Timestamp
+	35, // 46: wikigraph.LeaderboardEntry.player:type_name -> wikigraph.RacePlayer
+	34, // 47: wikigraph.Leaderboard.race:type_name -> wikigraph.Race
+	36, // 48: wikigraph.Leaderboard.entries:type_name -> wikigraph.LeaderboardEntry
+	58, // 49: wikigraph.CreateRaceRequest.duration:type_name -> google.protobuf.Duration
+	34, // 50: wikigraph.CreateRaceResponse.race:type_name -> wikigraph.Race
+	37, // 51: wikigraph.GetRaceResponse.leaderboard:type_name -> wikigraph.Leaderboard
+	42, // 52: wikigraph.RaceRequest.join:type_name -> wikigraph.JoinRace
+	35, // 53: wikigraph.HopResult.player:type_name -> wikigraph.RacePlayer
+	35, // 54: wikigraph.RaceEvent.joined:type_name -> wikigraph.RacePlayer
+	44, // 55: wikigraph.RaceEvent.hop:type_name -> wikigraph.HopResult
+	37, // 56: wikigraph.RaceEvent.leaderboard:type_name -> wikigraph.Leaderboard
+	57, // 57: wikigraph.SamplingRun.created_at:type_name -> google.protobuf.Timestamp
+	57, // 58: wikigraph.SamplingRun.finished_at:type_name -> google.protobuf.Timestamp
+	0,  // 59: wikigraph.StartSamplingRunRequest.priority:type_name -> wikigraph.Priority
+	46, // 60: wikigraph.StartSamplingRunResponse.run:type_name -> wikigraph.SamplingRun
+	46, // 61: wikigraph.GetSamplingRunResponse.run:type_name -> wikigraph.SamplingRun
+	46, // 62: wikigraph.ListSamplingRunsResponse.runs:type_name -> wikigraph.SamplingRun
+	57, // 63: wikigraph.Hub.computed_at:type_name -> google.protobuf.Timestamp
+	53, // 64: wikigraph.ListHubsResponse.hubs:type_name -> wikigraph.Hub
+	58, // 65: wikigraph.TaskStats.Layer.elapsed:type_name -> google.protobuf.Duration
+	17, // 66: wikigraph.WikiGraph.FindShortestPath:input_type -> wikigraph.FindShortestPathRequest
+	19, // 67: wikigraph.WikiGraph.GetTask:input_type -> wikigraph.GetTaskRequest
+	21, // 68: wikigraph.WikiGraph.GetDistance:input_type -> wikigraph.GetDistanceRequest
+	23, // 69: wikigraph.WikiGraph.GetNeighbors:input_type -> wikigraph.GetNeighborsRequest
+	25, // 70: wikigraph.WikiGraph.GetNeighborhood:input_type -> wikigraph.GetNeighborhoodRequest
+	27, // 71: wikigraph.WikiGraph.VerifyTask:input_type -> wikigraph.VerifyTaskRequest
+	30, // 72: wikigraph.WikiGraph.SubmitPath:input_type -> wikigraph.SubmitPathRequest
+	32, // 73: wikigraph.WikiGraph.GetSubmission:input_type -> wikigraph.GetSubmissionRequest
+	38, // 74: wikigraph.WikiGraph.CreateRace:input_type -> wikigraph.CreateRaceRequest
+	40, // 75: wikigraph.WikiGraph.GetRace:input_type -> wikigraph.GetRaceRequest
+	43, // 76: wikigraph.WikiGraph.Race:input_type -> wikigraph.RaceRequest
+	47, // 77: wikigraph.WikiGraph.StartSamplingRun:input_type -> wikigraph.StartSamplingRunRequest
+	49, // 78: wikigraph.WikiGraph.GetSamplingRun:input_type -> wikigraph.GetSamplingRunRequest
+	51, // 79: wikigraph.WikiGraph.ListSamplingRuns:input_type -> wikigraph.ListSamplingRunsRequest
+	54, // 80: wikigraph.WikiGraph.ListHubs:input_type -> wikigraph.ListHubsRequest
+	18, // 81: wikigraph.WikiGraph.FindShortestPath:output_type -> wikigraph.FindShortestPathResponse
+	20, // 82: wikigraph.WikiGraph.GetTask:output_type -> wikigraph.GetTaskResponse
+	22, // 83: wikigraph.WikiGraph.GetDistance:output_type -> wikigraph.GetDistanceResponse
+	24, // 84: wikigraph.WikiGraph.GetNeighbors:output_type -> wikigraph.GetNeighborsResponse
+	26, // 85: wikigraph.WikiGraph.GetNeighborhood:output_type -> wikigraph.GetNeighborhoodResponse
+	28, // 86: wikigraph.WikiGraph.VerifyTask:output_type -> wikigraph.VerifyTaskResponse
+	31, // 87: wikigraph.WikiGraph.SubmitPath:output_type -> wikigraph.SubmitPathResponse
+	33, // 88: wikigraph.WikiGraph.GetSubmission:output_type -> wikigraph.GetSubmissionResponse
+	39, // 89: wikigraph.WikiGraph.CreateRace:output_type -> wikigraph.CreateRaceResponse
+	41, // 90: wikigraph.WikiGraph.GetRace:output_type -> wikigraph.GetRaceResponse
+	45, // 91: wikigraph.WikiGraph.Race:output_type -> wikigraph.RaceEvent
+	48, // 92: wikigraph.WikiGraph.StartSamplingRun:output_type -> wikigraph.StartSamplingRunResponse
+	50, // 93: wikigraph.WikiGraph.GetSamplingRun:output_type -> wikigraph.GetSamplingRunResponse
+	52, // 94: wikigraph.WikiGraph.ListSamplingRuns:output_type -> wikigraph.ListSamplingRunsResponse
+	55, // 95: wikigraph.WikiGraph.ListHubs:output_type -> wikigraph.ListHubsResponse
+	81, // [81:96] is the sub-list for method output_type
+	66, // [66:81] is the sub-list for method input_type
+	66, // [66:66] is the sub-list for extension type_name
+	66, // [66:66] is the sub-list for extension extendee
+	0,  // [0:66] is the sub-list for field type_name
+}
+
+func init() { file_pkg_wikigraphpb_wikigraph_proto_init() }
+func file_pkg_wikigraphpb_wikigraph_proto_init() {
+	if File_pkg_wikigraphpb_wikigraph_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Task); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathVerification); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HopExplanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Neighbor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Link); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DistanceResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Path); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindShortestPathRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindShortestPathResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDistanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDistanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNeighborsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNeighborsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNeighborhoodRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNeighborhoodResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTaskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTaskResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Submission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitPathRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitPathResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubmissionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -506,8 +5053,8 @@ func file_pkg_wikigraphpb_wikigraph_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Task); i {
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubmissionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -518,8 +5065,8 @@ func file_pkg_wikigraphpb_wikigraph_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindShortestPathRequest); i {
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Race); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -530,8 +5077,8 @@ func file_pkg_wikigraphpb_wikigraph_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindShortestPathResponse); i {
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RacePlayer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -542,8 +5089,8 @@ func file_pkg_wikigraphpb_wikigraph_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTaskRequest); i {
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderboardEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -554,8 +5101,200 @@ func file_pkg_wikigraphpb_wikigraph_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTaskResponse); i {
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Leaderboard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinRace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HopResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RaceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SamplingRun); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartSamplingRunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartSamplingRunResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSamplingRunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSamplingRunResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSamplingRunsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSamplingRunsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hub); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -566,14 +5305,59 @@ func file_pkg_wikigraphpb_wikigraph_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHubsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListHubsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_wikigraphpb_wikigraph_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskStats_Layer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_pkg_wikigraphpb_wikigraph_proto_msgTypes[37].OneofWrappers = []interface{}{
+		(*RaceRequest_Join)(nil),
+		(*RaceRequest_Hop)(nil),
+	}
+	file_pkg_wikigraphpb_wikigraph_proto_msgTypes[39].OneofWrappers = []interface{}{
+		(*RaceEvent_Joined)(nil),
+		(*RaceEvent_Hop)(nil),
+		(*RaceEvent_Leaderboard)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_wikigraphpb_wikigraph_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      6,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,34 @@ type WikiGraphClient interface {
 	// Enqueue a task to find the shortest path between two wikipedia pages.
 	FindShortestPath(ctx context.Context, in *FindShortestPathRequest, opts ...grpc.CallOption) (*FindShortestPathResponse, error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
+	// Find the number of clicks between two wikipedia pages. Cached distances are returned immediately,
+	// otherwise a task is enqueued and the result can be taken from its distance_result.
+	GetDistance(ctx context.Context, in *GetDistanceRequest, opts ...grpc.CallOption) (*GetDistanceResponse, error)
+	// List links of a page straight from Wikipedia, a page of links at a time.
+	GetNeighbors(ctx context.Context, in *GetNeighborsRequest, opts ...grpc.CallOption) (*GetNeighborsResponse, error)
+	// Enqueue a task to find all pages within a few clicks from a page.
+	GetNeighborhood(ctx context.Context, in *GetNeighborhoodRequest, opts ...grpc.CallOption) (*GetNeighborhoodResponse, error)
+	// Check whether the path found by a DONE task still exists in Wikipedia.
+	VerifyTask(ctx context.Context, in *VerifyTaskRequest, opts ...grpc.CallOption) (*VerifyTaskResponse, error)
+	// Referee a Wikipedia race: check a path found by a player and score it by the shortest one.
+	SubmitPath(ctx context.Context, in *SubmitPathRequest, opts ...grpc.CallOption) (*SubmitPathResponse, error)
+	// Get a submitted path. It's scored as soon as the shortest distance is known.
+	GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*GetSubmissionResponse, error)
+	// Create a race session between two pages. Missing pages are picked at random.
+	CreateRace(ctx context.Context, in *CreateRaceRequest, opts ...grpc.CallOption) (*CreateRaceResponse, error)
+	// Get a race along with its leaderboard.
+	GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*GetRaceResponse, error)
+	// Take part in a race: join it with the first message, then send clicks one at a time.
+	// The server replies to every message and streams the leaderboard as it changes.
+	Race(ctx context.Context, opts ...grpc.CallOption) (WikiGraph_RaceClient, error)
+	// Sample random pairs of articles and compute the distances between them with distance tasks.
+	StartSamplingRun(ctx context.Context, in *StartSamplingRunRequest, opts ...grpc.CallOption) (*StartSamplingRunResponse, error)
+	// Get a sampling run with the distance histogram aggregated so far.
+	GetSamplingRun(ctx context.Context, in *GetSamplingRunRequest, opts ...grpc.CallOption) (*GetSamplingRunResponse, error)
+	// List the most recent sampling runs, the scheduled ones included.
+	ListSamplingRuns(ctx context.Context, in *ListSamplingRunsRequest, opts ...grpc.CallOption) (*ListSamplingRunsResponse, error)
+	// List the hub pages ranked by the offline job, the biggest first.
+	ListHubs(ctx context.Context, in *ListHubsRequest, opts ...grpc.CallOption) (*ListHubsResponse, error)
 }
 
 type wikiGraphClient struct {
@@ -53,6 +81,145 @@ func (c *wikiGraphClient) GetTask(ctx context.Context, in *GetTaskRequest, opts
 	return out, nil
 }
 
+func (c *wikiGraphClient) GetDistance(ctx context.Context, in *GetDistanceRequest, opts ...grpc.CallOption) (*GetDistanceResponse, error) {
+	out := new(GetDistanceResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/GetDistance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) GetNeighbors(ctx context.Context, in *GetNeighborsRequest, opts ...grpc.CallOption) (*GetNeighborsResponse, error) {
+	out := new(GetNeighborsResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/GetNeighbors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) GetNeighborhood(ctx context.Context, in *GetNeighborhoodRequest, opts ...grpc.CallOption) (*GetNeighborhoodResponse, error) {
+	out := new(GetNeighborhoodResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/GetNeighborhood", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) VerifyTask(ctx context.Context, in *VerifyTaskRequest, opts ...grpc.CallOption) (*VerifyTaskResponse, error) {
+	out := new(VerifyTaskResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/VerifyTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) SubmitPath(ctx context.Context, in *SubmitPathRequest, opts ...grpc.CallOption) (*SubmitPathResponse, error) {
+	out := new(SubmitPathResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/SubmitPath", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*GetSubmissionResponse, error) {
+	out := new(GetSubmissionResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/GetSubmission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) CreateRace(ctx context.Context, in *CreateRaceRequest, opts ...grpc.CallOption) (*CreateRaceResponse, error) {
+	out := new(CreateRaceResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/CreateRace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*GetRaceResponse, error) {
+	out := new(GetRaceResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/GetRace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) Race(ctx context.Context, opts ...grpc.CallOption) (WikiGraph_RaceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WikiGraph_ServiceDesc.Streams[0], "/wikigraph.WikiGraph/Race", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wikiGraphRaceClient{stream}
+	return x, nil
+}
+
+type WikiGraph_RaceClient interface {
+	Send(*RaceRequest) error
+	Recv() (*RaceEvent, error)
+	grpc.ClientStream
+}
+
+type wikiGraphRaceClient struct {
+	grpc.ClientStream
+}
+
+func (x *wikiGraphRaceClient) Send(m *RaceRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *wikiGraphRaceClient) Recv() (*RaceEvent, error) {
+	m := new(RaceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wikiGraphClient) StartSamplingRun(ctx context.Context, in *StartSamplingRunRequest, opts ...grpc.CallOption) (*StartSamplingRunResponse, error) {
+	out := new(StartSamplingRunResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/StartSamplingRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) GetSamplingRun(ctx context.Context, in *GetSamplingRunRequest, opts ...grpc.CallOption) (*GetSamplingRunResponse, error) {
+	out := new(GetSamplingRunResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/GetSamplingRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) ListSamplingRuns(ctx context.Context, in *ListSamplingRunsRequest, opts ...grpc.CallOption) (*ListSamplingRunsResponse, error) {
+	out := new(ListSamplingRunsResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/ListSamplingRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wikiGraphClient) ListHubs(ctx context.Context, in *ListHubsRequest, opts ...grpc.CallOption) (*ListHubsResponse, error) {
+	out := new(ListHubsResponse)
+	err := c.cc.Invoke(ctx, "/wikigraph.WikiGraph/ListHubs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WikiGraphServer is the server API for WikiGraph service.
 // All implementations must embed UnimplementedWikiGraphServer
 // for forward compatibility
@@ -60,6 +227,34 @@ type WikiGraphServer interface {
 	// Enqueue a task to find the shortest path between two wikipedia pages.
 	FindShortestPath(context.Context, *FindShortestPathRequest) (*FindShortestPathResponse, error)
 	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
+	// Find the number of clicks between two wikipedia pages. Cached distances are returned immediately,
+	// otherwise a task is enqueued and the result can be taken from its distance_result.
+	GetDistance(context.Context, *GetDistanceRequest) (*GetDistanceResponse, error)
+	// List links of a page straight from Wikipedia, a page of links at a time.
+	GetNeighbors(context.Context, *GetNeighborsRequest) (*GetNeighborsResponse, error)
+	// Enqueue a task to find all pages within a few clicks from a page.
+	GetNeighborhood(context.Context, *GetNeighborhoodRequest) (*GetNeighborhoodResponse, error)
+	// Check whether the path found by a DONE task still exists in Wikipedia.
+	VerifyTask(context.Context, *VerifyTaskRequest) (*VerifyTaskResponse, error)
+	// Referee a Wikipedia race: check a path found by a player and score it by the shortest one.
+	SubmitPath(context.Context, *SubmitPathRequest) (*SubmitPathResponse, error)
+	// Get a submitted path. It's scored as soon as the shortest distance is known.
+	GetSubmission(context.Context, *GetSubmissionRequest) (*GetSubmissionResponse, error)
+	// Create a race session between two pages. Missing pages are picked at random.
+	CreateRace(context.Context, *CreateRaceRequest) (*CreateRaceResponse, error)
+	// Get a race along with its leaderboard.
+	GetRace(context.Context, *GetRaceRequest) (*GetRaceResponse, error)
+	// Take part in a race: join it with the first message, then send clicks one at a time.
+	// The server replies to every message and streams the leaderboard as it changes.
+	Race(WikiGraph_RaceServer) error
+	// Sample random pairs of articles and compute the distances between them with distance tasks.
+	StartSamplingRun(context.Context, *StartSamplingRunRequest) (*StartSamplingRunResponse, error)
+	// Get a sampling run with the distance histogram aggregated so far.
+	GetSamplingRun(context.Context, *GetSamplingRunRequest) (*GetSamplingRunResponse, error)
+	// List the most recent sampling runs, the scheduled ones included.
+	ListSamplingRuns(context.Context, *ListSamplingRunsRequest) (*ListSamplingRunsResponse, error)
+	// List the hub pages ranked by the offline job, the biggest first.
+	ListHubs(context.Context, *ListHubsRequest) (*ListHubsResponse, error)
 	mustEmbedUnimplementedWikiGraphServer()
 }
 
@@ -73,6 +268,45 @@ func (UnimplementedWikiGraphServer) FindShortestPath(context.Context, *FindShort
 func (UnimplementedWikiGraphServer) GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
 }
+func (UnimplementedWikiGraphServer) GetDistance(context.Context, *GetDistanceRequest) (*GetDistanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDistance not implemented")
+}
+func (UnimplementedWikiGraphServer) GetNeighbors(context.Context, *GetNeighborsRequest) (*GetNeighborsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNeighbors not implemented")
+}
+func (UnimplementedWikiGraphServer) GetNeighborhood(context.Context, *GetNeighborhoodRequest) (*GetNeighborhoodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNeighborhood not implemented")
+}
+func (UnimplementedWikiGraphServer) VerifyTask(context.Context, *VerifyTaskRequest) (*VerifyTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTask not implemented")
+}
+func (UnimplementedWikiGraphServer) SubmitPath(context.Context, *SubmitPathRequest) (*SubmitPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPath not implemented")
+}
+func (UnimplementedWikiGraphServer) GetSubmission(context.Context, *GetSubmissionRequest) (*GetSubmissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmission not implemented")
+}
+func (UnimplementedWikiGraphServer) CreateRace(context.Context, *CreateRaceRequest) (*CreateRaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRace not implemented")
+}
+func (UnimplementedWikiGraphServer) GetRace(context.Context, *GetRaceRequest) (*GetRaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRace not implemented")
+}
+func (UnimplementedWikiGraphServer) Race(WikiGraph_RaceServer) error {
+	return status.Errorf(codes.Unimplemented, "method Race not implemented")
+}
+func (UnimplementedWikiGraphServer) StartSamplingRun(context.Context, *StartSamplingRunRequest) (*StartSamplingRunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSamplingRun not implemented")
+}
+func (UnimplementedWikiGraphServer) GetSamplingRun(context.Context, *GetSamplingRunRequest) (*GetSamplingRunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSamplingRun not implemented")
+}
+func (UnimplementedWikiGraphServer) ListSamplingRuns(context.Context, *ListSamplingRunsRequest) (*ListSamplingRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSamplingRuns not implemented")
+}
+func (UnimplementedWikiGraphServer) ListHubs(context.Context, *ListHubsRequest) (*ListHubsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHubs not implemented")
+}
 func (UnimplementedWikiGraphServer) mustEmbedUnimplementedWikiGraphServer() {}
 
 // UnsafeWikiGraphServer may be embedded to opt out of forward compatibility for this service.
@@ -122,6 +356,248 @@ func _WikiGraph_GetTask_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WikiGraph_GetDistance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDistanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).GetDistance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/GetDistance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).GetDistance(ctx, req.(*GetDistanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_GetNeighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNeighborsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).GetNeighbors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/GetNeighbors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).GetNeighbors(ctx, req.(*GetNeighborsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_GetNeighborhood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNeighborhoodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).GetNeighborhood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/GetNeighborhood",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).GetNeighborhood(ctx, req.(*GetNeighborhoodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_VerifyTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).VerifyTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/VerifyTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).VerifyTask(ctx, req.(*VerifyTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_SubmitPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).SubmitPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/SubmitPath",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).SubmitPath(ctx, req.(*SubmitPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_GetSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).GetSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/GetSubmission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).GetSubmission(ctx, req.(*GetSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_CreateRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).CreateRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/CreateRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).CreateRace(ctx, req.(*CreateRaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_GetRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).GetRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/GetRace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).GetRace(ctx, req.(*GetRaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_Race_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WikiGraphServer).Race(&wikiGraphRaceServer{stream})
+}
+
+type WikiGraph_RaceServer interface {
+	Send(*RaceEvent) error
+	Recv() (*RaceRequest, error)
+	grpc.ServerStream
+}
+
+type wikiGraphRaceServer struct {
+	grpc.ServerStream
+}
+
+func (x *wikiGraphRaceServer) Send(m *RaceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *wikiGraphRaceServer) Recv() (*RaceRequest, error) {
+	m := new(RaceRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _WikiGraph_StartSamplingRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSamplingRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).StartSamplingRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/StartSamplingRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).StartSamplingRun(ctx, req.(*StartSamplingRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_GetSamplingRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSamplingRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).GetSamplingRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/GetSamplingRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).GetSamplingRun(ctx, req.(*GetSamplingRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_ListSamplingRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSamplingRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).ListSamplingRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/ListSamplingRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).ListSamplingRuns(ctx, req.(*ListSamplingRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WikiGraph_ListHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHubsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WikiGraphServer).ListHubs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikigraph.WikiGraph/ListHubs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WikiGraphServer).ListHubs(ctx, req.(*ListHubsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WikiGraph_ServiceDesc is the grpc.ServiceDesc for WikiGraph service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -137,7 +613,62 @@ var WikiGraph_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTask",
 			Handler:    _WikiGraph_GetTask_Handler,
 		},
+		{
+			MethodName: "GetDistance",
+			Handler:    _WikiGraph_GetDistance_Handler,
+		},
+		{
+			MethodName: "GetNeighbors",
+			Handler:    _WikiGraph_GetNeighbors_Handler,
+		},
+		{
+			MethodName: "GetNeighborhood",
+			Handler:    _WikiGraph_GetNeighborhood_Handler,
+		},
+		{
+			MethodName: "VerifyTask",
+			Handler:    _WikiGraph_VerifyTask_Handler,
+		},
+		{
+			MethodName: "SubmitPath",
+			Handler:    _WikiGraph_SubmitPath_Handler,
+		},
+		{
+			MethodName: "GetSubmission",
+			Handler:    _WikiGraph_GetSubmission_Handler,
+		},
+		{
+			MethodName: "CreateRace",
+			Handler:    _WikiGraph_CreateRace_Handler,
+		},
+		{
+			MethodName: "GetRace",
+			Handler:    _WikiGraph_GetRace_Handler,
+		},
+		{
+			MethodName: "StartSamplingRun",
+			Handler:    _WikiGraph_StartSamplingRun_Handler,
+		},
+		{
+			MethodName: "GetSamplingRun",
+			Handler:    _WikiGraph_GetSamplingRun_Handler,
+		},
+		{
+			MethodName: "ListSamplingRuns",
+			Handler:    _WikiGraph_ListSamplingRuns_Handler,
+		},
+		{
+			MethodName: "ListHubs",
+			Handler:    _WikiGraph_ListHubs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Race",
+			Handler:       _WikiGraph_Race_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/wikigraphpb/wikigraph.proto",
 }